@@ -1,7 +1,7 @@
 #!/usr/bin/env python3
 """
 Merge NIST ACVP-Server prompt and expectedResults JSON files into
-simplified test vector files for go-qrllib ML-DSA-87 testing.
+simplified test vector files for go-qrllib ML-DSA and SLH-DSA testing.
 
 The ACVP-Server separates test inputs (prompt.json) from expected
 outputs (expectedResults.json). This script merges them by tcId and
@@ -13,13 +13,22 @@ Input format (ACVP-Server):
 
 Output format (simplified):
   keygen.json: [{ tcId, seed, pk, sk }]
+               (SLH-DSA: [{ tcId, skSeed, skPrf, pkSeed, pk, sk }])
   siggen.json: [{ tcId, sk, message, context, signature }]
+  siggen_prehash.json: [{ tcId, sk, message, context, hashAlg, signature }]
+
+With --include-hedged, non-deterministic sigGen vectors are kept too and
+carry their additionalRandomness (SLH-DSA only; ML-DSA hedged vectors
+supply rnd, which the ML-DSA tests do not consume).
 """
 
 import argparse
 import json
 import sys
 
+# Key generation inputs: ML-DSA has a single seed, SLH-DSA three.
+KEYGEN_INPUTS = ("seed", "skSeed", "skPrf", "pkSeed")
+
 
 def merge_keygen(prompt_path, results_path, param_set):
     with open(prompt_path) as f:
@@ -43,17 +52,19 @@ def merge_keygen(prompt_path, results_path, param_set):
                 print(f"WARNING: tcId {tcid} missing from expectedResults", file=sys.stderr)
                 continue
             exp = expected[tcid]
-            merged.append({
-                "tcId": tcid,
-                "seed": tc["seed"],
-                "pk": exp["pk"],
-                "sk": exp["sk"],
-            })
+            vector = {"tcId": tcid}
+            for field in KEYGEN_INPUTS:
+                if field in tc:
+                    vector[field] = tc[field]
+            vector["pk"] = exp["pk"]
+            vector["sk"] = exp["sk"]
+            merged.append(vector)
 
     return merged
 
 
-def merge_siggen(prompt_path, results_path, param_set):
+def merge_siggen(prompt_path, results_path, param_set, mode="pure",
+                 include_hedged=False):
     with open(prompt_path) as f:
         prompt = json.load(f)
     with open(results_path) as f:
@@ -70,19 +81,21 @@ def merge_siggen(prompt_path, results_path, param_set):
         if tg["parameterSet"] != param_set:
             continue
 
-        # Only test deterministic, external, pure (non-preHash) vectors.
+        # Only test deterministic, external vectors of the requested mode.
         # - deterministic: go-qrllib uses deterministic signing (rnd=zeros)
+        #   unless include_hedged is set and the vector carries its
+        #   additionalRandomness
         # - external: tests the full Sign() API including context encoding
-        # - pure: go-qrllib implements pure ML-DSA, not pre-hash variant
+        # - mode: "pure" for Sign, "preHash" for SignPrehash
         deterministic = tg.get("deterministic", False)
         interface = tg.get("signatureInterface", "")
         pre_hash = tg.get("preHash", "")
 
-        if not deterministic:
+        if not deterministic and not include_hedged:
             continue
         if interface != "external":
             continue
-        if pre_hash != "pure":
+        if pre_hash != mode:
             continue
 
         for tc in tg["tests"]:
@@ -91,13 +104,20 @@ def merge_siggen(prompt_path, results_path, param_set):
                 print(f"WARNING: tcId {tcid} missing from expectedResults", file=sys.stderr)
                 continue
             exp = expected[tcid]
-            merged.append({
+            vector = {
                 "tcId": tcid,
                 "sk": tc["sk"],
                 "message": tc.get("message", ""),
                 "context": tc.get("context", ""),
                 "signature": exp["signature"],
-            })
+            }
+            if mode == "preHash":
+                vector["hashAlg"] = tc["hashAlg"]
+            if not deterministic:
+                if "additionalRandomness" not in tc:
+                    continue
+                vector["additionalRandomness"] = tc["additionalRandomness"]
+            merged.append(vector)
 
     return merged
 
@@ -109,7 +129,10 @@ def main():
     parser.add_argument("--siggen-prompt", required=True)
     parser.add_argument("--siggen-results", required=True)
     parser.add_argument("--parameter-set", required=True,
-                        help="e.g. ML-DSA-87")
+                        help="e.g. ML-DSA-87 or SLH-DSA-SHAKE-256s")
+    parser.add_argument("--include-hedged", action="store_true",
+                        help="also keep non-deterministic sigGen vectors "
+                             "(SLH-DSA additionalRandomness)")
     parser.add_argument("--output-dir", required=True)
     args = parser.parse_args()
 
@@ -119,18 +142,27 @@ def main():
     keygen = merge_keygen(args.keygen_prompt, args.keygen_results,
                           args.parameter_set)
     siggen = merge_siggen(args.siggen_prompt, args.siggen_results,
-                          args.parameter_set)
+                          args.parameter_set,
+                          include_hedged=args.include_hedged)
+    siggen_prehash = merge_siggen(args.siggen_prompt, args.siggen_results,
+                                  args.parameter_set, mode="preHash",
+                                  include_hedged=args.include_hedged)
 
     keygen_path = os.path.join(args.output_dir, "keygen.json")
     siggen_path = os.path.join(args.output_dir, "siggen.json")
+    siggen_prehash_path = os.path.join(args.output_dir, "siggen_prehash.json")
 
     with open(keygen_path, "w") as f:
         json.dump(keygen, f, indent=2)
     with open(siggen_path, "w") as f:
         json.dump(siggen, f, indent=2)
+    with open(siggen_prehash_path, "w") as f:
+        json.dump(siggen_prehash, f, indent=2)
 
     print(f"Wrote {len(keygen)} keygen vectors to {keygen_path}")
     print(f"Wrote {len(siggen)} siggen vectors to {siggen_path}")
+    print(f"Wrote {len(siggen_prehash)} pre-hash siggen vectors to "
+          f"{siggen_prehash_path}")
 
     if len(keygen) == 0:
         print(f"ERROR: No keygen vectors found for {args.parameter_set}",
@@ -140,6 +172,10 @@ def main():
         print(f"ERROR: No siggen vectors found for {args.parameter_set}",
               file=sys.stderr)
         sys.exit(1)
+    if len(siggen_prehash) == 0:
+        print(f"ERROR: No pre-hash siggen vectors found for "
+              f"{args.parameter_set}", file=sys.stderr)
+        sys.exit(1)
 
 
 if __name__ == "__main__":
@@ -0,0 +1,140 @@
+/*
+ * xmssmt_sign_ref.c - Reverse-direction XMSS^MT cross-verify signer.
+ *
+ * Counterpart to xmssmt_sign.go: the reference produces an XMSS^MT
+ * signature for go-qrllib to verify via the rfc8391 sub-package. The
+ * OID is taken from the optional first argument and defaults to
+ * XMSSMT-SHA2_20/4_256 (0x00000002). Determinism works as in xmss_sign_ref.c: the
+ * pinned reference has no seeded-keypair API, so randombytes() is
+ * overridden to consume a fixed 96-byte expanded seed in order, and
+ * the link command omits the upstream randombytes.c.
+ *
+ * The signature is made at a fixed index deep in the hypertree rather
+ * than at 0, so every layer signs with a non-zero leaf. The reference
+ * xmss_core.c recomputes all state per signature, so setting the
+ * index bytes of sk is enough.
+ *
+ * Compile (note: no randombytes.c):
+ *   gcc -I. -o xmssmt_sign_ref xmssmt_sign_ref.c \
+ *       params.c hash.c fips202.c hash_address.c utils.c \
+ *       wots.c xmss.c xmss_commons.c xmss_core.c -lcrypto
+ */
+#include <stdio.h>
+#include <stdint.h>
+#include <string.h>
+#include <stdlib.h>
+#include "params.h"
+#include "xmss.h"
+
+#define XMSSMT_SHA2_20_4_256_OID 0x00000002
+#define EXPANDED_SEED_BYTES      96
+#define SIGN_INDEX               0x54321ULL
+
+/* ---------- deterministic randombytes override ----------
+ * See xmss_sign_ref.c: xmssmt_core_keypair draws SK_SEED || SK_PRF
+ * and then PUB_SEED, matching the [SK_SEED | SK_PRF | PUB_SEED]
+ * layout rfc8391.NewMTKeyPair consumes. */
+static const unsigned char *g_seed_buf;
+static size_t               g_seed_pos;
+static size_t               g_seed_len;
+
+void randombytes(unsigned char *x, unsigned long long xlen) {
+    if (g_seed_pos + (size_t)xlen > g_seed_len) {
+        fprintf(stderr,
+                "deterministic randombytes underrun: pos=%zu xlen=%llu len=%zu\n",
+                g_seed_pos, (unsigned long long)xlen, g_seed_len);
+        exit(2);
+    }
+    memcpy(x, g_seed_buf + g_seed_pos, (size_t)xlen);
+    g_seed_pos += (size_t)xlen;
+}
+
+int main(int argc, char **argv) {
+    uint32_t oid = XMSSMT_SHA2_20_4_256_OID;
+    if (argc > 1) {
+        oid = (uint32_t)strtoul(argv[1], NULL, 0);
+    }
+
+    /* The same deterministic 96-byte expanded seed that xmssmt_verify.go
+     * will pass into rfc8391.NewMTKeyPair on the go-qrllib side. */
+    unsigned char expanded_seed[EXPANDED_SEED_BYTES];
+    for (size_t i = 0; i < EXPANDED_SEED_BYTES; i++) {
+        expanded_seed[i] = (unsigned char)i;
+    }
+    g_seed_buf = expanded_seed;
+    g_seed_pos = 0;
+    g_seed_len = EXPANDED_SEED_BYTES;
+
+    xmss_params params;
+    if (xmssmt_parse_oid(&params, oid) != 0) {
+        fprintf(stderr, "xmssmt_parse_oid failed\n");
+        return 1;
+    }
+
+    unsigned char  pk[XMSS_OID_LEN + params.pk_bytes];
+    unsigned char *sk = calloc(1, XMSS_OID_LEN + params.sk_bytes);
+    if (!sk) { fprintf(stderr, "alloc fail\n"); return 1; }
+
+    if (xmssmt_keypair(pk, sk, oid) != 0) {
+        fprintf(stderr, "xmssmt_keypair failed\n");
+        free(sk);
+        return 1;
+    }
+
+    /* Move to SIGN_INDEX: the index is the first index_bytes of sk
+     * after the OID, big-endian. */
+    for (unsigned int i = 0; i < params.index_bytes; i++) {
+        sk[XMSS_OID_LEN + i] =
+            (unsigned char)(SIGN_INDEX >> (8 * (params.index_bytes - 1 - i)));
+    }
+
+    unsigned char msg[64];
+    memcpy(msg, "XMSS^MT reference -> go-qrllib verification", 43);
+    size_t msglen = 43;
+
+    unsigned char *sm = malloc(params.sig_bytes + msglen);
+    if (!sm) { fprintf(stderr, "alloc fail\n"); free(sk); return 1; }
+    unsigned long long smlen;
+    if (xmssmt_sign(sk, sm, &smlen, msg, msglen) != 0) {
+        fprintf(stderr, "xmssmt_sign failed\n");
+        free(sk);
+        free(sm);
+        return 1;
+    }
+
+    /* xmssmt_sign emits sig || msg; split sig out for the verifier. */
+    size_t siglen = (size_t)(smlen - msglen);
+
+    FILE *f;
+
+    f = fopen("/tmp/xmssmt_ref_pk_rfc.bin", "wb");
+    if (!f) { fprintf(stderr, "open xmssmt_ref_pk_rfc.bin\n"); return 1; }
+    fwrite(pk, 1, XMSS_OID_LEN + params.pk_bytes, f);
+    fclose(f);
+
+    f = fopen("/tmp/xmssmt_ref_sig.bin", "wb");
+    if (!f) { fprintf(stderr, "open xmssmt_ref_sig.bin\n"); return 1; }
+    fwrite(sm, 1, siglen, f);
+    fclose(f);
+
+    f = fopen("/tmp/xmssmt_ref_msg.bin", "wb");
+    if (!f) { fprintf(stderr, "open xmssmt_ref_msg.bin\n"); return 1; }
+    fwrite(msg, 1, msglen, f);
+    fclose(f);
+
+    f = fopen("/tmp/xmssmt_ref_expanded_seed.bin", "wb");
+    if (!f) { fprintf(stderr, "open xmssmt_ref_expanded_seed.bin\n"); return 1; }
+    fwrite(expanded_seed, 1, EXPANDED_SEED_BYTES, f);
+    fclose(f);
+
+    printf("Reference XMSS^MT signer, OID 0x%08x (pre-SP-800-208 pin):\n", oid);
+    printf("  PK size (OID||root||pub_seed):  %u bytes\n",
+           (unsigned)(XMSS_OID_LEN + params.pk_bytes));
+    printf("  Sig size:                       %zu bytes\n", siglen);
+    printf("  Msg size:                       %zu bytes\n", msglen);
+    printf("  Signed at index:                %llu\n", SIGN_INDEX);
+
+    free(sk);
+    free(sm);
+    return 0;
+}
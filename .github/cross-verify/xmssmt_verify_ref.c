@@ -0,0 +1,91 @@
+/*
+ * xmssmt_verify_ref.c - Verify go-qrllib XMSS^MT signature with reference implementation
+ *
+ * go-qrllib writes the public key in the RFC 8391 layout already, so it
+ * is passed to xmssmt_sign_open() unchanged. The OID is taken from the
+ * optional first argument and defaults to XMSSMT-SHA2_20/4_256.
+ *
+ * pk format:  [OID(4) | root(32) | pub_seed(32)] = 68 bytes
+ * sig format: [idx(3) | r(32) | 4 x (WOTS_SIG(2144) | AUTH(5*32))] = 9251 bytes
+ *             for the 20/4 parameter sets
+ *
+ * Compile: gcc -I. -o xmssmt_verify xmssmt_verify_ref.c -L. -lxmss -lcrypto
+ */
+#include <stdio.h>
+#include <stdint.h>
+#include <string.h>
+#include <stdlib.h>
+#include "params.h"
+#include "xmss.h"
+
+/* Default: XMSSMT-SHA2_20/4_256 */
+#define XMSSMT_SHA2_20_4_256_OID 0x00000002
+#define REF_PK_BYTES 68
+
+int main(int argc, char **argv) {
+    uint8_t pk[REF_PK_BYTES];
+    uint8_t msg[256];
+    uint8_t *sig, *sm, *msg_out;
+    unsigned long long msg_out_len;
+    size_t msglen, siglen;
+    xmss_params params;
+    FILE *f;
+    int ret;
+
+    uint32_t oid = XMSSMT_SHA2_20_4_256_OID;
+    if (argc > 1) {
+        oid = (uint32_t)strtoul(argv[1], NULL, 0);
+    }
+
+    if (xmssmt_parse_oid(&params, oid) != 0) {
+        printf("xmssmt_parse_oid failed\n"); return 1;
+    }
+
+    /* Read go-qrllib public key */
+    f = fopen("/tmp/xmssmt_pk.bin", "rb");
+    if (!f) { printf("Cannot open pk\n"); return 1; }
+    if (fread(pk, 1, REF_PK_BYTES, f) != REF_PK_BYTES) {
+        printf("Failed to read pk\n"); return 1;
+    }
+    fclose(f);
+
+    /* Read signature */
+    sig = malloc(params.sig_bytes + 1);
+    if (!sig) { printf("Memory allocation failed\n"); return 1; }
+    f = fopen("/tmp/xmssmt_sig.bin", "rb");
+    if (!f) { printf("Cannot open sig\n"); return 1; }
+    siglen = fread(sig, 1, params.sig_bytes + 1, f);
+    fclose(f);
+
+    /* Read message */
+    f = fopen("/tmp/xmssmt_msg.bin", "rb");
+    if (!f) { printf("Cannot open msg\n"); return 1; }
+    msglen = fread(msg, 1, sizeof(msg), f);
+    fclose(f);
+
+    printf("XMSS reference (XMSS^MT OID 0x%08x) verifier:\n", oid);
+    printf("  PK size:  %d bytes\n", REF_PK_BYTES);
+    printf("  Sig size: %zu bytes (expected %u)\n", siglen, params.sig_bytes);
+    printf("  Msg size: %zu bytes\n", msglen);
+    if (siglen != params.sig_bytes) {
+        printf("  Verification: FAILED (signature size)\n");
+        return 1;
+    }
+
+    /* Construct signed message format: sig || msg */
+    sm = malloc(siglen + msglen);
+    msg_out = malloc(siglen + msglen);
+    if (!sm || !msg_out) { printf("Memory allocation failed\n"); return 1; }
+    memcpy(sm, sig, siglen);
+    memcpy(sm + siglen, msg, msglen);
+
+    /* Verify using reference */
+    ret = xmssmt_sign_open(msg_out, &msg_out_len, sm, siglen + msglen, pk);
+    free(sm);
+    free(msg_out);
+    free(sig);
+
+    printf("  Verification: %s\n", ret == 0 ? "PASSED" : "FAILED");
+
+    return ret != 0 ? 1 : 0;
+}
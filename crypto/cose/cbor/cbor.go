@@ -0,0 +1,386 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"unicode/utf8"
+)
+
+// Major types (RFC 8949 §3.1).
+const (
+	majorUnsigned = 0
+	majorNegative = 1
+	majorBytes    = 2
+	majorText     = 3
+	majorArray    = 4
+	majorMap      = 5
+	majorTag      = 6
+	majorSimple   = 7
+)
+
+// Additional information values for major type 7.
+const (
+	simpleFalse = 20
+	simpleTrue  = 21
+	simpleNull  = 22
+)
+
+// maxDepth bounds the nesting of arrays, maps and tags.
+const maxDepth = 32
+
+var (
+	// ErrMalformed is returned for input that is not well-formed CBOR,
+	// including truncated items and trailing bytes.
+	ErrMalformed = errors.New("cbor: malformed data")
+	// ErrNotDeterministic is returned for well-formed input that breaks
+	// the core deterministic encoding rules.
+	ErrNotDeterministic = errors.New("cbor: data is not deterministically encoded")
+	// ErrUnsupportedType is returned for Go values and CBOR items
+	// outside the supported data model.
+	ErrUnsupportedType = errors.New("cbor: unsupported type")
+)
+
+// Tag is a tagged data item (major type 6).
+type Tag struct {
+	Number  uint64
+	Content any
+}
+
+// RawMessage is a single encoded data item. [Marshal] copies it to the
+// output after checking that it is deterministically encoded.
+type RawMessage []byte
+
+// Marshal returns the deterministic encoding of v.
+func Marshal(v any) ([]byte, error) {
+	var e encoder
+	if err := e.encode(v, 0); err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
+
+// Unmarshal decodes the single data item in data. It rejects trailing
+// bytes and any item that is not deterministically encoded.
+func Unmarshal(data []byte) (any, error) {
+	d := decoder{data: data}
+	v, err := d.decode(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.off != len(data) {
+		return nil, fmt.Errorf("%w: trailing data", ErrMalformed)
+	}
+	return v, nil
+}
+
+type encoder struct {
+	buf []byte
+}
+
+// writeHead appends the shortest head for major type m and argument n.
+func (e *encoder) writeHead(m byte, n uint64) {
+	m <<= 5
+	switch {
+	case n < 24:
+		e.buf = append(e.buf, m|byte(n))
+	case n <= math.MaxUint8:
+		e.buf = append(e.buf, m|24, byte(n))
+	case n <= math.MaxUint16:
+		e.buf = binary.BigEndian.AppendUint16(append(e.buf, m|25), uint16(n))
+	case n <= math.MaxUint32:
+		e.buf = binary.BigEndian.AppendUint32(append(e.buf, m|26), uint32(n))
+	default:
+		e.buf = binary.BigEndian.AppendUint64(append(e.buf, m|27), n)
+	}
+}
+
+func (e *encoder) writeInt(n int64) {
+	if n >= 0 {
+		e.writeHead(majorUnsigned, uint64(n))
+		return
+	}
+	e.writeHead(majorNegative, uint64(-(n + 1)))
+}
+
+func (e *encoder) encode(v any, depth int) error {
+	if depth > maxDepth {
+		return fmt.Errorf("%w: nesting deeper than %d", ErrUnsupportedType, maxDepth)
+	}
+	switch v := v.(type) {
+	case nil:
+		e.buf = append(e.buf, majorSimple<<5|simpleNull)
+	case bool:
+		if v {
+			e.buf = append(e.buf, majorSimple<<5|simpleTrue)
+		} else {
+			e.buf = append(e.buf, majorSimple<<5|simpleFalse)
+		}
+	case int:
+		e.writeInt(int64(v))
+	case int8:
+		e.writeInt(int64(v))
+	case int16:
+		e.writeInt(int64(v))
+	case int32:
+		e.writeInt(int64(v))
+	case int64:
+		e.writeInt(v)
+	case uint:
+		e.writeHead(majorUnsigned, uint64(v))
+	case uint8:
+		e.writeHead(majorUnsigned, uint64(v))
+	case uint16:
+		e.writeHead(majorUnsigned, uint64(v))
+	case uint32:
+		e.writeHead(majorUnsigned, uint64(v))
+	case uint64:
+		e.writeHead(majorUnsigned, v)
+	case []byte:
+		e.writeHead(majorBytes, uint64(len(v)))
+		e.buf = append(e.buf, v...)
+	case string:
+		if !utf8.ValidString(v) {
+			return fmt.Errorf("%w: text string is not valid UTF-8", ErrUnsupportedType)
+		}
+		e.writeHead(majorText, uint64(len(v)))
+		e.buf = append(e.buf, v...)
+	case []any:
+		e.writeHead(majorArray, uint64(len(v)))
+		for _, item := range v {
+			if err := e.encode(item, depth+1); err != nil {
+				return err
+			}
+		}
+	case map[any]any:
+		return e.encodeMap(v, depth)
+	case Tag:
+		e.writeHead(majorTag, v.Number)
+		return e.encode(v.Content, depth+1)
+	case RawMessage:
+		d := decoder{data: v}
+		if _, err := d.decode(depth); err != nil {
+			return err
+		}
+		if d.off != len(v) {
+			return fmt.Errorf("%w: trailing data", ErrMalformed)
+		}
+		e.buf = append(e.buf, v...)
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedType, v)
+	}
+	return nil
+}
+
+// encodeMap writes m with its entries sorted by the bytewise order of
+// their encoded keys (RFC 8949 §4.2.1).
+func (e *encoder) encodeMap(m map[any]any, depth int) error {
+	type entry struct {
+		key []byte
+		val any
+	}
+	entries := make([]entry, 0, len(m))
+	for k, v := range m {
+		if !isKeyType(k) {
+			return fmt.Errorf("%w: map key %T", ErrUnsupportedType, k)
+		}
+		var ke encoder
+		if err := ke.encode(k, depth+1); err != nil {
+			return err
+		}
+		entries = append(entries, entry{key: ke.buf, val: v})
+	}
+	slices.SortFunc(entries, func(a, b entry) int { return bytes.Compare(a.key, b.key) })
+
+	e.writeHead(majorMap, uint64(len(entries)))
+	for i, ent := range entries {
+		// Distinct Go keys such as int(1) and int64(1) encode alike.
+		if i > 0 && bytes.Equal(entries[i-1].key, ent.key) {
+			return fmt.Errorf("%w: duplicate map key", ErrUnsupportedType)
+		}
+		e.buf = append(e.buf, ent.key...)
+		if err := e.encode(ent.val, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isKeyType(k any) bool {
+	switch k.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, string:
+		return true
+	}
+	return false
+}
+
+type decoder struct {
+	data []byte
+	off  int
+}
+
+func (d *decoder) remaining() uint64 {
+	return uint64(len(d.data) - d.off)
+}
+
+// readHead reads an initial byte and its argument, rejecting arguments
+// that are not in their shortest form. Indefinite lengths (additional
+// information 31) are returned as ErrNotDeterministic.
+func (d *decoder) readHead() (major byte, info byte, n uint64, err error) {
+	if d.off >= len(d.data) {
+		return 0, 0, 0, fmt.Errorf("%w: unexpected end of data", ErrMalformed)
+	}
+	ib := d.data[d.off]
+	d.off++
+	major, info = ib>>5, ib&0x1f
+
+	var size int
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	case info == 31 && major >= majorBytes && major <= majorMap:
+		return 0, 0, 0, fmt.Errorf("%w: indefinite length", ErrNotDeterministic)
+	default:
+		return 0, 0, 0, fmt.Errorf("%w: reserved additional information %d", ErrMalformed, info)
+	}
+	if d.remaining() < uint64(size) {
+		return 0, 0, 0, fmt.Errorf("%w: unexpected end of data", ErrMalformed)
+	}
+	arg := d.data[d.off : d.off+size]
+	d.off += size
+	switch size {
+	case 1:
+		n = uint64(arg[0])
+	case 2:
+		n = uint64(binary.BigEndian.Uint16(arg))
+	case 4:
+		n = uint64(binary.BigEndian.Uint32(arg))
+	default:
+		n = binary.BigEndian.Uint64(arg)
+	}
+	// Major type 7 uses 25-27 for floats, whose width is not a length.
+	if major != majorSimple && n < minArgument[size] {
+		return 0, 0, 0, fmt.Errorf("%w: argument not in shortest form", ErrNotDeterministic)
+	}
+	return major, info, n, nil
+}
+
+// minArgument is the smallest argument that needs a head of the given
+// extra size.
+var minArgument = map[int]uint64{
+	1: 24,
+	2: math.MaxUint8 + 1,
+	4: math.MaxUint16 + 1,
+	8: math.MaxUint32 + 1,
+}
+
+func (d *decoder) decode(depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%w: nesting deeper than %d", ErrUnsupportedType, maxDepth)
+	}
+	major, info, n, err := d.readHead()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case majorUnsigned:
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+		return int64(n), nil
+	case majorNegative:
+		if n > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: negative integer below int64 range", ErrUnsupportedType)
+		}
+		return -1 - int64(n), nil
+	case majorBytes, majorText:
+		if n > d.remaining() {
+			return nil, fmt.Errorf("%w: string longer than input", ErrMalformed)
+		}
+		b := d.data[d.off : d.off+int(n)]
+		d.off += int(n)
+		if major == majorText {
+			if !utf8.Valid(b) {
+				return nil, fmt.Errorf("%w: text string is not valid UTF-8", ErrMalformed)
+			}
+			return string(b), nil
+		}
+		return bytes.Clone(b), nil
+	case majorArray:
+		// Every item takes at least one byte.
+		if n > d.remaining() {
+			return nil, fmt.Errorf("%w: array longer than input", ErrMalformed)
+		}
+		arr := make([]any, n)
+		for i := range arr {
+			if arr[i], err = d.decode(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	case majorMap:
+		return d.decodeMap(n, depth)
+	case majorTag:
+		content, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return Tag{Number: n, Content: content}, nil
+	default:
+		switch info {
+		case simpleFalse:
+			return false, nil
+		case simpleTrue:
+			return true, nil
+		case simpleNull:
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: simple value or float", ErrUnsupportedType)
+	}
+}
+
+func (d *decoder) decodeMap(n uint64, depth int) (any, error) {
+	// Every entry takes at least two bytes.
+	if n > d.remaining()/2 {
+		return nil, fmt.Errorf("%w: map longer than input", ErrMalformed)
+	}
+	m := make(map[any]any, n)
+	var prevKey []byte
+	for range n {
+		start := d.off
+		k, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if !isKeyType(k) {
+			return nil, fmt.Errorf("%w: map key %T", ErrUnsupportedType, k)
+		}
+		key := d.data[start:d.off]
+		if prevKey != nil {
+			switch bytes.Compare(prevKey, key) {
+			case 0:
+				return nil, fmt.Errorf("%w: duplicate map key", ErrMalformed)
+			case 1:
+				return nil, fmt.Errorf("%w: map keys out of order", ErrNotDeterministic)
+			}
+		}
+		prevKey = key
+
+		if m[k], err = d.decode(depth + 1); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
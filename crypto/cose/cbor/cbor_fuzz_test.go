@@ -0,0 +1,28 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func FuzzUnmarshal(f *testing.F) {
+	for _, tc := range rfcVectors {
+		b, _ := hex.DecodeString(tc.hex)
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		v, err := Unmarshal(data)
+		if err != nil {
+			return
+		}
+		// Anything accepted is deterministic, so it re-encodes exactly.
+		got, err := Marshal(v)
+		if err != nil {
+			t.Fatalf("Marshal of decoded value: %v", err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("re-encoding %x gave %x", data, got)
+		}
+	})
+}
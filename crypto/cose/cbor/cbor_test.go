@@ -0,0 +1,201 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Vectors from RFC 8949 Appendix A that fall inside the data model and
+// are already in deterministic form.
+var rfcVectors = []struct {
+	value any
+	hex   string
+}{
+	{int64(0), "00"},
+	{int64(1), "01"},
+	{int64(10), "0a"},
+	{int64(23), "17"},
+	{int64(24), "1818"},
+	{int64(25), "1819"},
+	{int64(100), "1864"},
+	{int64(1000), "1903e8"},
+	{int64(1000000), "1a000f4240"},
+	{int64(1000000000000), "1b000000e8d4a51000"},
+	{uint64(18446744073709551615), "1bffffffffffffffff"},
+	{int64(-1), "20"},
+	{int64(-10), "29"},
+	{int64(-100), "3863"},
+	{int64(-1000), "3903e7"},
+	{int64(math.MinInt64), "3b7fffffffffffffff"},
+	{false, "f4"},
+	{true, "f5"},
+	{nil, "f6"},
+	{Tag{Number: 0, Content: "2013-03-21T20:04:00Z"}, "c074323031332d30332d32315432303a30343a30305a"},
+	{Tag{Number: 1, Content: int64(1363896240)}, "c11a514b67b0"},
+	{Tag{Number: 23, Content: []byte{1, 2, 3, 4}}, "d74401020304"},
+	{[]byte{}, "40"},
+	{[]byte{1, 2, 3, 4}, "4401020304"},
+	{"", "60"},
+	{"a", "6161"},
+	{"IETF", "6449455446"},
+	{"\"\\", "62225c"},
+	{"ü", "62c3bc"},
+	{"水", "63e6b0b4"},
+	{"\U00010151", "64f0908591"},
+	{[]any{}, "80"},
+	{[]any{int64(1), int64(2), int64(3)}, "83010203"},
+	{[]any{int64(1), []any{int64(2), int64(3)}, []any{int64(4), int64(5)}}, "8301820203820405"},
+	{map[any]any{}, "a0"},
+	{map[any]any{int64(1): int64(2), int64(3): int64(4)}, "a201020304"},
+	{map[any]any{"a": int64(1), "b": []any{int64(2), int64(3)}}, "a26161016162820203"},
+	{[]any{"a", map[any]any{"b": "c"}}, "826161a161626163"},
+}
+
+func TestRFC8949Vectors(t *testing.T) {
+	for _, tc := range rfcVectors {
+		want, _ := hex.DecodeString(tc.hex)
+		got, err := Marshal(tc.value)
+		if err != nil {
+			t.Errorf("Marshal(%#v): %v", tc.value, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("Marshal(%#v) = %x, want %s", tc.value, got, tc.hex)
+		}
+		v, err := Unmarshal(want)
+		if err != nil {
+			t.Errorf("Unmarshal(%s): %v", tc.hex, err)
+			continue
+		}
+		if !reflect.DeepEqual(v, tc.value) {
+			t.Errorf("Unmarshal(%s) = %#v, want %#v", tc.hex, v, tc.value)
+		}
+	}
+}
+
+func TestMarshalGoIntegerTypes(t *testing.T) {
+	for _, v := range []any{int(-500), int8(-100), int16(-500), int32(-500), uint(500), uint8(250), uint16(500), uint32(500)} {
+		got, err := Marshal(v)
+		if err != nil {
+			t.Fatalf("Marshal(%T): %v", v, err)
+		}
+		want, _ := Marshal(reflect.ValueOf(v).Convert(reflect.TypeOf(int64(0))).Interface())
+		if !bytes.Equal(got, want) {
+			t.Errorf("Marshal(%T(%v)) = %x, want %x", v, v, got, want)
+		}
+	}
+}
+
+func TestMarshalSortsMapKeys(t *testing.T) {
+	// Bytewise order of encoded keys: 0x01 < 0x20 (-1) < 0x21 (-2) <
+	// 0x61 ("a") < 0x62 ("b") < 0x18 0x64 would break length-first order.
+	m := map[any]any{"b": 1, -2: 2, 100: 3, "a": 4, -1: 5, 1: 6}
+	got, err := Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := "a6" + "0106" + "1864" + "03" + "2005" + "2102" + "616104" + "616201"
+	if hex.EncodeToString(got) != want {
+		t.Errorf("Marshal = %x, want %s", got, want)
+	}
+	if _, err := Unmarshal(got); err != nil {
+		t.Errorf("Unmarshal of own output: %v", err)
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	deep := any(int64(0))
+	for range maxDepth + 1 {
+		deep = []any{deep}
+	}
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"float", 1.5},
+		{"struct", struct{}{}},
+		{"invalid_utf8", "\xff"},
+		{"bytes_key", map[any]any{"a": 1, true: 2}},
+		{"duplicate_key", map[any]any{1: "a", int64(1): "b"}},
+		{"nested_unsupported", []any{map[any]any{1: 1.5}}},
+		{"too_deep", deep},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Marshal(tc.value); !errors.Is(err, ErrUnsupportedType) {
+				t.Errorf("error = %v, want %v", err, ErrUnsupportedType)
+			}
+		})
+	}
+}
+
+func TestMarshalRawMessage(t *testing.T) {
+	got, err := Marshal([]any{RawMessage{0x82, 0x01, 0x02}, int64(3)})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if hex.EncodeToString(got) != "8282010203" {
+		t.Errorf("Marshal = %x", got)
+	}
+	if _, err := Marshal(RawMessage{0x18, 0x01}); !errors.Is(err, ErrNotDeterministic) {
+		t.Errorf("non-deterministic RawMessage error = %v", err)
+	}
+	if _, err := Marshal(RawMessage{0x01, 0x02}); !errors.Is(err, ErrMalformed) {
+		t.Errorf("two-item RawMessage error = %v", err)
+	}
+}
+
+func TestUnmarshalRejects(t *testing.T) {
+	deep := strings.Repeat("81", maxDepth+1) + "00"
+	tests := []struct {
+		name string
+		hex  string
+		want error
+	}{
+		{"empty", "", ErrMalformed},
+		{"trailing", "0000", ErrMalformed},
+		{"truncated_head", "19 03", ErrMalformed},
+		{"truncated_bytes", "44 0102", ErrMalformed},
+		{"truncated_array", "83 0102", ErrMalformed},
+		{"huge_array", "9b ffffffffffffffff", ErrMalformed},
+		{"huge_map", "bb 7fffffffffffffff", ErrMalformed},
+		{"reserved_info", "1c", ErrMalformed},
+		{"break", "ff", ErrMalformed},
+		{"indefinite_uint", "1f", ErrMalformed},
+		{"invalid_utf8", "61 ff", ErrMalformed},
+		{"duplicate_key", "a2 0101 0102", ErrMalformed},
+		{"non_shortest_1", "18 17", ErrNotDeterministic},
+		{"non_shortest_2", "19 00ff", ErrNotDeterministic},
+		{"non_shortest_4", "1a 0000ffff", ErrNotDeterministic},
+		{"non_shortest_8", "1b 00000000ffffffff", ErrNotDeterministic},
+		{"non_shortest_length", "58 01 00", ErrNotDeterministic},
+		{"indefinite_bytes", "5f 4101 ff", ErrNotDeterministic},
+		{"indefinite_array", "9f 01 ff", ErrNotDeterministic},
+		{"indefinite_map", "bf 01 02 ff", ErrNotDeterministic},
+		{"unsorted_keys", "a2 0201 0102", ErrNotDeterministic},
+		{"length_first_order", "a2 6161 01 1864 02", ErrNotDeterministic},
+		{"float16", "f9 3c00", ErrUnsupportedType},
+		{"float64", "fb 3ff199999999999a", ErrUnsupportedType},
+		{"undefined", "f7", ErrUnsupportedType},
+		{"simple_255", "f8 ff", ErrUnsupportedType},
+		{"negative_overflow", "3b 8000000000000000", ErrUnsupportedType},
+		{"bytes_key", "a1 40 01", ErrUnsupportedType},
+		{"too_deep", deep, ErrUnsupportedType},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := hex.DecodeString(strings.ReplaceAll(tc.hex, " ", ""))
+			if err != nil {
+				t.Fatalf("bad test hex: %v", err)
+			}
+			if _, err := Unmarshal(data); !errors.Is(err, tc.want) {
+				t.Errorf("error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Package cbor implements the subset of CBOR (RFC 8949) that COSE
+// needs, using the core deterministic encoding rules of RFC 8949
+// §4.2.1.
+//
+// # Data model
+//
+// [Marshal] accepts nil, bool, signed and unsigned Go integers, []byte,
+// string, []any, map[any]any, [Tag] and [RawMessage]. [Unmarshal]
+// produces the same types, with every integer as int64 (or uint64 for
+// unsigned values above math.MaxInt64). Map keys must be integers or
+// text strings, which covers every COSE label. Floating-point numbers,
+// undefined and other simple values are not supported.
+//
+// # Determinism
+//
+// The encoder always emits the shortest head for every integer and
+// length, definite-length items only, and map entries sorted by the
+// bytewise order of their encoded keys. The decoder enforces the same
+// rules and returns [ErrNotDeterministic] for input that is well formed
+// but not in deterministic form, so any value it accepts re-encodes to
+// exactly the input bytes.
+//
+// Nesting is limited to 32 levels, and declared lengths are checked
+// against the remaining input before anything is allocated.
+package cbor
@@ -0,0 +1,116 @@
+package cose
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+)
+
+// Algorithm is a COSE algorithm identifier (RFC 9053 §2).
+type Algorithm int64
+
+// ALG_ML_DSA_87 is the COSE algorithm value for ML-DSA-87.
+const ALG_ML_DSA_87 Algorithm = -50
+
+// String returns the algorithm's registered name.
+func (a Algorithm) String() string {
+	switch a {
+	case ALG_ML_DSA_87:
+		return "ML-DSA-87"
+	default:
+		return fmt.Sprintf("Algorithm(%d)", int64(a))
+	}
+}
+
+// Common header parameter labels (RFC 9052 §3.1).
+const (
+	HEADER_ALGORITHM    = 1
+	HEADER_CRITICAL     = 2
+	HEADER_CONTENT_TYPE = 3
+	HEADER_KEY_ID       = 4
+)
+
+// TAG_SIGN1 is the CBOR tag for COSE_Sign1.
+const TAG_SIGN1 = 18
+
+var (
+	// ErrMalformedMessage is returned for data that is not a COSE_Sign1
+	// message.
+	ErrMalformedMessage = errors.New("cose: malformed message")
+	// ErrUnsupportedAlgorithm is returned when a message or key names
+	// an algorithm other than the one in use.
+	ErrUnsupportedAlgorithm = errors.New("cose: unsupported algorithm")
+	// ErrUnsupportedCritical is returned when a message lists critical
+	// header parameters, none of which this package implements.
+	ErrUnsupportedCritical = errors.New("cose: unsupported critical header parameter")
+	// ErrInvalidSignature is returned when a signature does not verify.
+	ErrInvalidSignature = errors.New("cose: invalid signature")
+	// ErrInvalidKey is returned for missing or malformed keys.
+	ErrInvalidKey = errors.New("cose: invalid key")
+	// ErrKeyMismatch is returned when a COSE_Key's private and public
+	// keys do not belong together.
+	ErrKeyMismatch = errors.New("cose: private key does not match public key")
+)
+
+// Signer produces signatures for one COSE algorithm.
+type Signer interface {
+	// Algorithm returns the value written to the alg header.
+	Algorithm() Algorithm
+	// Sign signs toBeSigned, the encoded Sig_structure. rand, if
+	// non-nil, is the source of signing randomness.
+	Sign(rand io.Reader, toBeSigned []byte) ([]byte, error)
+}
+
+// Verifier checks signatures for one COSE algorithm.
+type Verifier interface {
+	// Algorithm returns the alg header value this verifier accepts.
+	Algorithm() Algorithm
+	// Verify returns [ErrInvalidSignature] unless signature is valid
+	// for toBeSigned.
+	Verify(toBeSigned, signature []byte) error
+}
+
+type mldsa87Signer struct {
+	s *ml_dsa_87.CryptoSigner
+}
+
+// NewMLDSA87Signer returns a [Signer] for ALG_ML_DSA_87 backed by s.
+// Signatures use an empty context.
+func NewMLDSA87Signer(s *ml_dsa_87.CryptoSigner) Signer {
+	return &mldsa87Signer{s: s}
+}
+
+func (m *mldsa87Signer) Algorithm() Algorithm { return ALG_ML_DSA_87 }
+
+func (m *mldsa87Signer) Sign(rand io.Reader, toBeSigned []byte) ([]byte, error) {
+	return m.s.SignMessage(rand, toBeSigned, nil)
+}
+
+type mldsa87Verifier struct {
+	pk [ml_dsa_87.CRYPTO_PUBLIC_KEY_BYTES]uint8
+}
+
+// NewMLDSA87Verifier returns a [Verifier] for ALG_ML_DSA_87 and pub,
+// or nil if pub is nil.
+func NewMLDSA87Verifier(pub *ml_dsa_87.CryptoPublicKey) Verifier {
+	if pub == nil {
+		return nil
+	}
+	return &mldsa87Verifier{pk: pub.Bytes()}
+}
+
+func (m *mldsa87Verifier) Algorithm() Algorithm { return ALG_ML_DSA_87 }
+
+func (m *mldsa87Verifier) Verify(toBeSigned, signature []byte) error {
+	if len(signature) != ml_dsa_87.CRYPTO_BYTES {
+		return ErrInvalidSignature
+	}
+	var sig [ml_dsa_87.CRYPTO_BYTES]uint8
+	copy(sig[:], signature)
+	if !ml_dsa_87.Verify(nil, toBeSigned, sig, &m.pk) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
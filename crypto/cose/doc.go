@@ -0,0 +1,31 @@
+// Package cose implements COSE_Sign1 messages and COSE_Key encoding
+// (RFC 9052) for ML-DSA-87, with the algorithm identifiers registered
+// by the IETF COSE ML-DSA draft.
+//
+// # Algorithms
+//
+// ML-DSA-87 is COSE algorithm -50 ([ALG_ML_DSA_87]). Signatures are
+// pure ML-DSA-87 over the Sig_structure with an empty context, as the
+// draft specifies; [NewMLDSA87Signer] and [NewMLDSA87Verifier] adapt
+// [github.com/theQRL/go-qrllib/crypto/ml_dsa_87.CryptoSigner] and
+// [ml_dsa_87.Verify] to the [Signer] and [Verifier] interfaces.
+//
+// Other schemes plug in through the same two interfaces. SLH-DSA is
+// expected to arrive this way: an implementation returns the algorithm
+// value assigned by the COSE SLH-DSA draft from Algorithm and signs the
+// bytes it is given, and [Sign1Message] needs no change.
+//
+// # Keys
+//
+// [Key] encodes ML-DSA-87 keys as COSE_Key maps of key type AKP (7):
+// the public key under label -1 and, for private keys, the 32-byte seed
+// under label -2.
+//
+// # Verification policy
+//
+// [Sign1Message.Verify] requires the alg header in the protected bucket
+// and equal to the verifier's algorithm, so a message cannot downgrade
+// itself, and rejects any crit header since this package understands
+// no extension parameters. Messages are decoded with the strict
+// deterministic decoder in [github.com/theQRL/go-qrllib/crypto/cose/cbor].
+package cose
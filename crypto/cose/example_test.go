@@ -0,0 +1,63 @@
+package cose_test
+
+import (
+	"fmt"
+
+	"github.com/theQRL/go-qrllib/crypto/cose"
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+)
+
+// Example signs a COSE_Sign1 attestation with an ML-DSA-87 key and
+// verifies it with the public COSE_Key a relying party would hold.
+func Example() {
+	d, err := ml_dsa_87.New()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer d.Zeroize()
+
+	signer := ml_dsa_87.NewCryptoSigner(d)
+	msg := &cose.Sign1Message{
+		Unprotected: map[any]any{cose.HEADER_KEY_ID: []byte("device-42")},
+		Payload:     []byte("measurement: ok"),
+	}
+	if err := msg.Sign(nil, cose.NewMLDSA87Signer(signer), nil); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	encoded, err := msg.MarshalCBOR()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	// The relying party's copy of the device key.
+	pubKey, err := (&cose.Key{PublicKey: signer.Public().(*ml_dsa_87.CryptoPublicKey)}).MarshalCBOR()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	var key cose.Key
+	if err := key.UnmarshalCBOR(pubKey); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	verifier, err := key.Verifier()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	var received cose.Sign1Message
+	if err := received.UnmarshalCBOR(encoded); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	if err := received.Verify(verifier, nil); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("%s: %s\n", received.KeyID(), received.Payload)
+	// Output: device-42: measurement: ok
+}
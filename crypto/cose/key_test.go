@@ -0,0 +1,160 @@
+package cose
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/theQRL/go-qrllib/crypto/cose/cbor"
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+)
+
+func TestKeyPublicRoundTrip(t *testing.T) {
+	d := newTestKey(t, 1)
+	pk := d.GetPK()
+	k := &Key{KeyID: []byte("k1"), PublicKey: ml_dsa_87.NewCryptoPublicKey(&pk)}
+
+	data, err := k.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("MarshalCBOR: %v", err)
+	}
+	// {1: 7, 2: h'6b31', 3: -50, -1: h'<2592 bytes>'}
+	want := "a4" + "0107" + "02426b31" + "033831" + "20590a20" + hex.EncodeToString(pk[:])
+	if hex.EncodeToString(data) != want {
+		t.Fatalf("COSE_Key = %x...", data[:16])
+	}
+
+	var got Key
+	if err := got.UnmarshalCBOR(data); err != nil {
+		t.Fatalf("UnmarshalCBOR: %v", err)
+	}
+	if string(got.KeyID) != "k1" || got.PrivateKey != nil || !got.PublicKey.Equal(k.PublicKey) {
+		t.Errorf("round trip = %+v", got)
+	}
+	if _, err := got.Signer(); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Signer on public key error = %v", err)
+	}
+}
+
+func TestKeyPrivateRoundTrip(t *testing.T) {
+	d := newTestKey(t, 1)
+	data, err := (&Key{PrivateKey: d}).MarshalCBOR()
+	if err != nil {
+		t.Fatalf("MarshalCBOR: %v", err)
+	}
+	seed, err := d.GetSeed()
+	if err != nil {
+		t.Fatalf("GetSeed: %v", err)
+	}
+	if !bytes.HasSuffix(data, append([]byte{0x21, 0x58, 0x20}, seed[:]...)) {
+		t.Fatal("private COSE_Key does not end with the seed under label -2")
+	}
+
+	var got Key
+	if err := got.UnmarshalCBOR(data); err != nil {
+		t.Fatalf("UnmarshalCBOR: %v", err)
+	}
+	if got.PrivateKey == nil || got.PrivateKey.GetSK() != d.GetSK() {
+		t.Fatal("private key changed across round trip")
+	}
+
+	signer, err := got.Signer()
+	if err != nil {
+		t.Fatalf("Signer: %v", err)
+	}
+	verifier, err := got.Verifier()
+	if err != nil {
+		t.Fatalf("Verifier: %v", err)
+	}
+	msg := &Sign1Message{Payload: []byte("x")}
+	if err := msg.Sign(nil, signer, nil); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if err := msg.Verify(verifier, nil); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+}
+
+func TestKeyMarshalErrors(t *testing.T) {
+	d1, d2 := newTestKey(t, 1), newTestKey(t, 2)
+	pk1 := d1.GetPK()
+
+	if _, err := (&Key{}).MarshalCBOR(); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("empty key error = %v", err)
+	}
+	if _, err := (&Key{}).Verifier(); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("empty key Verifier error = %v", err)
+	}
+	if _, err := (&Key{PublicKey: ml_dsa_87.NewCryptoPublicKey(&pk1), PrivateKey: d2}).MarshalCBOR(); !errors.Is(err, ErrKeyMismatch) {
+		t.Errorf("mismatched pair error = %v", err)
+	}
+
+	der, err := ml_dsa_87.MarshalPKCS8PrivateKey(d1, ml_dsa_87.PRIVATE_KEY_EXPANDED)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	seedless, err := ml_dsa_87.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		t.Fatalf("ParsePKCS8PrivateKey: %v", err)
+	}
+	if _, err := (&Key{PrivateKey: seedless}).MarshalCBOR(); !errors.Is(err, cryptoerrors.ErrSeedUnavailable) {
+		t.Errorf("seedless error = %v", err)
+	}
+}
+
+func TestKeyUnmarshalErrors(t *testing.T) {
+	d1, d2 := newTestKey(t, 1), newTestKey(t, 2)
+	pk := d1.GetPK()
+	seed2, err := d2.GetSeed()
+	if err != nil {
+		t.Fatalf("GetSeed: %v", err)
+	}
+	enc := func(m map[any]any) []byte {
+		b, err := cbor.Marshal(m)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		return b
+	}
+	base := func(extra map[any]any) []byte {
+		m := map[any]any{KEY_LABEL_KTY: KEY_TYPE_AKP, KEY_LABEL_ALG: -50, KEY_LABEL_AKP_PUB: pk[:]}
+		for k, v := range extra {
+			m[k] = v
+		}
+		return enc(m)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"not_cbor", []byte{0xa1}, ErrInvalidKey},
+		{"not_map", []byte{0x80}, ErrInvalidKey},
+		{"okp", enc(map[any]any{KEY_LABEL_KTY: 1, KEY_LABEL_ALG: -8, -1: 6}), ErrUnsupportedAlgorithm},
+		{"missing_alg", enc(map[any]any{KEY_LABEL_KTY: KEY_TYPE_AKP, KEY_LABEL_AKP_PUB: pk[:]}), ErrUnsupportedAlgorithm},
+		{"ml_dsa_65", enc(map[any]any{KEY_LABEL_KTY: KEY_TYPE_AKP, KEY_LABEL_ALG: -49, KEY_LABEL_AKP_PUB: pk[:]}), ErrUnsupportedAlgorithm},
+		{"missing_pub", enc(map[any]any{KEY_LABEL_KTY: KEY_TYPE_AKP, KEY_LABEL_ALG: -50}), ErrInvalidKey},
+		{"short_pub", enc(map[any]any{KEY_LABEL_KTY: KEY_TYPE_AKP, KEY_LABEL_ALG: -50, KEY_LABEL_AKP_PUB: pk[:10]}), ErrInvalidKey},
+		{"kid_text", base(map[any]any{KEY_LABEL_KID: "k1"}), ErrInvalidKey},
+		{"priv_text", base(map[any]any{KEY_LABEL_AKP_PRV: "seed"}), ErrInvalidKey},
+		{"short_priv", base(map[any]any{KEY_LABEL_AKP_PRV: seed2[:16]}), ErrInvalidKey},
+		{"wrong_priv", base(map[any]any{KEY_LABEL_AKP_PRV: seed2[:]}), ErrKeyMismatch},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var k Key
+			if err := k.UnmarshalCBOR(tc.data); !errors.Is(err, tc.want) {
+				t.Errorf("error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	// Unknown labels, such as key_ops, are ignored.
+	var k Key
+	if err := k.UnmarshalCBOR(base(map[any]any{4: []any{1}})); err != nil {
+		t.Errorf("extra label error = %v", err)
+	}
+}
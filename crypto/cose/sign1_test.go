@@ -0,0 +1,301 @@
+package cose
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/theQRL/go-qrllib/crypto/cose/cbor"
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+)
+
+func newTestKey(t *testing.T, b byte) *ml_dsa_87.MLDSA87 {
+	t.Helper()
+	var seed [ml_dsa_87.SEED_BYTES]uint8
+	for i := range seed {
+		seed[i] = b
+	}
+	d, err := ml_dsa_87.NewMLDSA87FromSeed(seed)
+	if err != nil {
+		t.Fatalf("NewMLDSA87FromSeed: %v", err)
+	}
+	return d
+}
+
+func testSignerVerifier(t *testing.T, b byte) (Signer, Verifier) {
+	t.Helper()
+	s := ml_dsa_87.NewCryptoSigner(newTestKey(t, b))
+	return NewMLDSA87Signer(s), NewMLDSA87Verifier(s.Public().(*ml_dsa_87.CryptoPublicKey))
+}
+
+// signedMessage signs payload and round-trips the result through its
+// encoding.
+func signedMessage(t *testing.T, signer Signer, m *Sign1Message, aad []byte) []byte {
+	t.Helper()
+	if err := m.Sign(nil, signer, aad); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	data, err := m.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("MarshalCBOR: %v", err)
+	}
+	return data
+}
+
+func TestSign1RoundTrip(t *testing.T) {
+	signer, verifier := testSignerVerifier(t, 1)
+	payload := []byte("This is the content.")
+	aad := []byte("attestation-v1")
+
+	msg := &Sign1Message{
+		Protected:   map[any]any{HEADER_CONTENT_TYPE: "application/cbor"},
+		Unprotected: map[any]any{HEADER_KEY_ID: []byte("device-42")},
+		Payload:     payload,
+	}
+	data := signedMessage(t, signer, msg, aad)
+
+	// Tag 18, four-element array, protected header
+	// {1: -50, 3: "application/cbor"}.
+	wantPrefix := "d2" + "84" + "56" + "a2" + "013831" + "03" + "70" + hex.EncodeToString([]byte("application/cbor"))
+	if got := hex.EncodeToString(data[:len(wantPrefix)/2]); got != wantPrefix {
+		t.Fatalf("prefix = %s, want %s", got, wantPrefix)
+	}
+
+	var got Sign1Message
+	if err := got.UnmarshalCBOR(data); err != nil {
+		t.Fatalf("UnmarshalCBOR: %v", err)
+	}
+	if err := got.Verify(verifier, aad); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !bytes.Equal(got.Payload, payload) || string(got.KeyID()) != "device-42" {
+		t.Errorf("payload %q kid %q", got.Payload, got.KeyID())
+	}
+
+	// The signature is plain ML-DSA-87 with an empty context over the
+	// RFC 9052 Sig_structure.
+	sigStruct, err := cbor.Marshal([]any{"Signature1", got.rawProtected, aad, payload})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var sig [ml_dsa_87.CRYPTO_BYTES]uint8
+	copy(sig[:], got.Signature)
+	pk := newTestKey(t, 1).GetPK()
+	if !ml_dsa_87.Verify(nil, sigStruct, sig, &pk) {
+		t.Error("signature does not verify over the Sig_structure")
+	}
+
+	// Re-marshaling a parsed message reproduces it exactly.
+	again, err := got.MarshalCBOR()
+	if err != nil || !bytes.Equal(again, data) {
+		t.Errorf("re-marshal mismatch (err %v)", err)
+	}
+}
+
+func TestSign1VerifyFailures(t *testing.T) {
+	signer, verifier := testSignerVerifier(t, 1)
+	_, otherVerifier := testSignerVerifier(t, 2)
+	data := signedMessage(t, signer, &Sign1Message{Payload: []byte("payload")}, []byte("aad"))
+
+	parse := func() *Sign1Message {
+		var m Sign1Message
+		if err := m.UnmarshalCBOR(data); err != nil {
+			t.Fatalf("UnmarshalCBOR: %v", err)
+		}
+		return &m
+	}
+
+	if err := parse().Verify(verifier, nil); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("wrong AAD error = %v", err)
+	}
+	if err := parse().Verify(otherVerifier, []byte("aad")); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("wrong key error = %v", err)
+	}
+	m := parse()
+	m.Payload = []byte("PAYLOAD")
+	if err := m.Verify(verifier, []byte("aad")); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("tampered payload error = %v", err)
+	}
+	m = parse()
+	m.Signature = m.Signature[:100]
+	if err := m.Verify(verifier, []byte("aad")); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("short signature error = %v", err)
+	}
+	if err := parse().Verify(nil, nil); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("nil verifier error = %v", err)
+	}
+	if err := (&Sign1Message{Payload: []byte("x")}).Verify(verifier, nil); !errors.Is(err, ErrMalformedMessage) {
+		t.Errorf("unsigned message error = %v", err)
+	}
+}
+
+func TestSign1Detached(t *testing.T) {
+	signer, verifier := testSignerVerifier(t, 1)
+	payload := []byte("firmware image")
+	msg := &Sign1Message{Payload: payload}
+	if err := msg.Sign(nil, signer, nil); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	msg.Payload = nil
+	data, err := msg.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("MarshalCBOR: %v", err)
+	}
+
+	var got Sign1Message
+	if err := got.UnmarshalCBOR(data); err != nil {
+		t.Fatalf("UnmarshalCBOR: %v", err)
+	}
+	if got.Payload != nil {
+		t.Fatal("detached payload decoded as present")
+	}
+	if err := got.Verify(verifier, nil); !errors.Is(err, ErrMalformedMessage) {
+		t.Errorf("missing payload error = %v", err)
+	}
+	got.Payload = payload
+	if err := got.Verify(verifier, nil); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+}
+
+// fakeScheme stands in for a future scheme such as SLH-DSA plugged in
+// through the Signer and Verifier interfaces.
+type fakeScheme struct{ alg Algorithm }
+
+func (f fakeScheme) Algorithm() Algorithm { return f.alg }
+
+func (f fakeScheme) Sign(_ io.Reader, tbs []byte) ([]byte, error) {
+	return append([]byte("sig:"), tbs...), nil
+}
+
+func (f fakeScheme) Verify(tbs, sig []byte) error {
+	if !bytes.Equal(sig, append([]byte("sig:"), tbs...)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func TestSign1OtherScheme(t *testing.T) {
+	const algFake Algorithm = -65535
+	scheme := fakeScheme{alg: algFake}
+	data := signedMessage(t, scheme, &Sign1Message{Payload: []byte("hello")}, nil)
+
+	var m Sign1Message
+	if err := m.UnmarshalCBOR(data); err != nil {
+		t.Fatalf("UnmarshalCBOR: %v", err)
+	}
+	if err := m.Verify(scheme, nil); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	// An ML-DSA-87 verifier refuses the message outright.
+	_, verifier := testSignerVerifier(t, 1)
+	if err := m.Verify(verifier, nil); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("cross-algorithm error = %v", err)
+	}
+	if algFake.String() != "Algorithm(-65535)" || ALG_ML_DSA_87.String() != "ML-DSA-87" {
+		t.Errorf("String() = %q, %q", algFake, ALG_ML_DSA_87)
+	}
+}
+
+func TestSign1SignErrors(t *testing.T) {
+	signer, _ := testSignerVerifier(t, 1)
+	tests := []struct {
+		name   string
+		msg    *Sign1Message
+		signer Signer
+		want   error
+	}{
+		{"nil_signer", &Sign1Message{}, nil, ErrInvalidKey},
+		{"conflicting_alg", &Sign1Message{Protected: map[any]any{HEADER_ALGORITHM: -7}}, signer, ErrUnsupportedAlgorithm},
+		{"label_in_both", &Sign1Message{
+			Protected:   map[any]any{HEADER_CONTENT_TYPE: 60},
+			Unprotected: map[any]any{int64(HEADER_CONTENT_TYPE): 60},
+		}, signer, ErrMalformedMessage},
+		{"alg_unprotected", &Sign1Message{Unprotected: map[any]any{HEADER_ALGORITHM: -50}}, signer, ErrMalformedMessage},
+		{"bad_header_value", &Sign1Message{Protected: map[any]any{HEADER_CONTENT_TYPE: 1.5}}, signer, ErrMalformedMessage},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.msg.Sign(nil, tc.signer, nil); !errors.Is(err, tc.want) {
+				t.Errorf("error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	// A matching alg set by the caller is accepted, whatever its Go
+	// integer type.
+	msg := &Sign1Message{Protected: map[any]any{HEADER_ALGORITHM: int(ALG_ML_DSA_87)}, Payload: []byte("x")}
+	if err := msg.Sign(nil, signer, nil); err != nil {
+		t.Errorf("Sign with explicit alg: %v", err)
+	}
+	if _, err := (&Sign1Message{}).MarshalCBOR(); !errors.Is(err, ErrMalformedMessage) {
+		t.Errorf("marshal unsigned error = %v", err)
+	}
+}
+
+func TestSign1UnmarshalRejects(t *testing.T) {
+	_, verifier := testSignerVerifier(t, 1)
+	sig := make([]byte, ml_dsa_87.CRYPTO_BYTES)
+	algProtected := []byte{0xa1, 0x01, 0x38, 0x31}
+	enc := func(v any) []byte {
+		b, err := cbor.Marshal(v)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		return b
+	}
+	sign1 := func(protected []byte, unprotected map[any]any) []byte {
+		return enc(cbor.Tag{Number: TAG_SIGN1, Content: []any{protected, unprotected, []byte("p"), sig}})
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"not_cbor", []byte{0xff}, ErrMalformedMessage},
+		{"non_deterministic", []byte{0xd2, 0x84, 0x40, 0xa0, 0xf6, 0x58, 0x00}, ErrMalformedMessage},
+		{"wrong_tag", enc(cbor.Tag{Number: 98, Content: []any{[]byte{}, map[any]any{}, nil, sig}}), ErrMalformedMessage},
+		{"three_elements", enc([]any{[]byte{}, map[any]any{}, nil}), ErrMalformedMessage},
+		{"protected_not_bytes", enc([]any{map[any]any{}, map[any]any{}, nil, sig}), ErrMalformedMessage},
+		{"protected_not_map", sign1(enc([]any{}), map[any]any{}), ErrMalformedMessage},
+		{"protected_bad_cbor", sign1([]byte{0xa1, 0x01}, map[any]any{}), ErrMalformedMessage},
+		{"unprotected_not_map", enc([]any{algProtected, []any{}, nil, sig}), ErrMalformedMessage},
+		{"payload_text", enc([]any{algProtected, map[any]any{}, "p", sig}), ErrMalformedMessage},
+		{"signature_nil", enc([]any{algProtected, map[any]any{}, nil, nil}), ErrMalformedMessage},
+		{"label_in_both", sign1(algProtected, map[any]any{HEADER_ALGORITHM: -50}), ErrMalformedMessage},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var m Sign1Message
+			if err := m.UnmarshalCBOR(tc.data); !errors.Is(err, tc.want) {
+				t.Errorf("error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	// Well-formed messages that Verify must refuse before checking the
+	// signature.
+	policy := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"untagged_empty_protected", enc([]any{[]byte{}, map[any]any{HEADER_ALGORITHM: -50}, []byte("p"), sig}), ErrUnsupportedAlgorithm},
+		{"alg_text", sign1(enc(map[any]any{HEADER_ALGORITHM: "ML-DSA-87"}), map[any]any{}), ErrUnsupportedAlgorithm},
+		{"crit", sign1(enc(map[any]any{HEADER_ALGORITHM: -50, HEADER_CRITICAL: []any{-70000}, -70000: true}), map[any]any{}), ErrUnsupportedCritical},
+	}
+	for _, tc := range policy {
+		t.Run(tc.name, func(t *testing.T) {
+			var m Sign1Message
+			if err := m.UnmarshalCBOR(tc.data); err != nil {
+				t.Fatalf("UnmarshalCBOR: %v", err)
+			}
+			if err := m.Verify(verifier, nil); !errors.Is(err, tc.want) {
+				t.Errorf("error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
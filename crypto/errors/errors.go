@@ -3,7 +3,10 @@
 // and internal state to prevent information leakage in production environments.
 package errors
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Seed errors
 var (
@@ -29,11 +32,33 @@ var (
 	ErrSigningFailed        = errors.New("signing failed")
 )
 
+// Signature rejection reasons returned by the VerifyDetailed functions.
+// ErrNonCanonicalHint and ErrSignatureNormExceeded wrap
+// ErrInvalidSignature, so callers that only test for an invalid
+// signature keep matching.
+var (
+	ErrNonCanonicalHint      = fmt.Errorf("%w: non-canonical hint encoding", ErrInvalidSignature)
+	ErrSignatureNormExceeded = fmt.Errorf("%w: response norm out of range", ErrInvalidSignature)
+	ErrDescriptorMismatch    = errors.New("descriptor mismatch")
+)
+
 // Context errors (ML-DSA)
 var (
 	ErrInvalidContext = errors.New("invalid context")
 )
 
+// Streaming errors (ML-DSA)
+var (
+	ErrStreamFinalized = errors.New("stream already finalized")
+)
+
+// Encoding errors (PKIX / PKCS#8)
+var (
+	ErrInvalidEncoding      = errors.New("invalid key encoding")
+	ErrUnsupportedAlgorithm = errors.New("unsupported algorithm")
+	ErrSeedUnavailable      = errors.New("seed unavailable")
+)
+
 // XMSS-specific errors
 var (
 	ErrInvalidHeight           = errors.New("invalid height")
@@ -42,6 +67,7 @@ var (
 	ErrOTSIndexRewind          = errors.New("cannot rewind OTS index")
 	ErrXMSSInternal            = errors.New("internal XMSS error")
 	ErrUnsupportedParameterSet = errors.New("unsupported XMSS parameter set")
+	ErrHeightMismatch          = errors.New("signature height does not match descriptor")
 )
 
 // Hash function errors
@@ -1,5 +1,11 @@
 // Package lattice provides shared mathematical primitives for the
-// ML-DSA-87 (FIPS 204) lattice-based signature scheme.
+// ML-DSA (FIPS 204) lattice-based signature schemes: ML-DSA-44,
+// ML-DSA-65 and ML-DSA-87.
+//
+// All three parameter sets share N, Q, D and the NTT. They differ in
+// the low-order rounding range: ML-DSA-65 and ML-DSA-87 use
+// GAMMA2 = (Q-1)/32 (Decompose, MakeHint, UseHint), ML-DSA-44 uses
+// GAMMA2_88 = (Q-1)/88 (Decompose88, MakeHint88, UseHint88).
 package lattice
 
 const (
@@ -15,8 +21,11 @@ const (
 	// D is the dropped bits from t
 	D = 13
 
-	// GAMMA2 is the low-order rounding range
+	// GAMMA2 is the low-order rounding range for ML-DSA-65 and ML-DSA-87
 	GAMMA2 = (Q - 1) / 32
+
+	// GAMMA2_88 is the low-order rounding range for ML-DSA-44
+	GAMMA2_88 = (Q - 1) / 88
 )
 
 // Zetas contains the NTT twiddle factors
@@ -280,7 +280,98 @@ func TestConstants(t *testing.T) {
 	if GAMMA2 != (Q-1)/32 {
 		t.Errorf("GAMMA2 = %d, want %d", GAMMA2, (Q-1)/32)
 	}
+	if GAMMA2_88 != (Q-1)/88 {
+		t.Errorf("GAMMA2_88 = %d, want %d", GAMMA2_88, (Q-1)/88)
+	}
 	if len(Zetas) != N {
 		t.Errorf("len(Zetas) = %d, want %d", len(Zetas), N)
 	}
 }
+
+// decompose88Ref is FIPS 204 Algorithm 36 (Decompose) for
+// GAMMA2_88, written directly from the specification.
+func decompose88Ref(a int32) (a1, a0 int32) {
+	const alpha = 2 * GAMMA2_88
+	r := ((a % Q) + Q) % Q
+	a0 = r % alpha
+	if a0 > alpha/2 {
+		a0 -= alpha
+	}
+	if r-a0 == Q-1 {
+		return 0, a0 - 1
+	}
+	return (r - a0) / alpha, a0
+}
+
+// TestDecompose88Reference checks Decompose88 against the specification
+// for every a in [0, Q).
+func TestDecompose88Reference(t *testing.T) {
+	for a := int32(0); a < Q; a++ {
+		var a0 int32
+		a1 := Decompose88(&a0, a)
+		wantA1, wantA0 := decompose88Ref(a)
+		if a1 != wantA1 || a0 != wantA0 {
+			t.Fatalf("Decompose88(%d) = (%d, %d), want (%d, %d)", a, a1, a0, wantA1, wantA0)
+		}
+	}
+}
+
+// TestMakeHint88ConstantTimeReference verifies the constant-time
+// implementation against the reference branching implementation.
+func TestMakeHint88ConstantTimeReference(t *testing.T) {
+	makeHintRef := func(a0, a1 int32) uint {
+		if a0 > GAMMA2_88 || a0 < -GAMMA2_88 || (a0 == -GAMMA2_88 && a1 != 0) {
+			return 1
+		}
+		return 0
+	}
+
+	testValues := []int32{
+		-GAMMA2_88 - 100, -GAMMA2_88 - 1, -GAMMA2_88, -GAMMA2_88 + 1, -GAMMA2_88 + 100,
+		-1000, -100, -1, 0, 1, 100, 1000,
+		GAMMA2_88 - 100, GAMMA2_88 - 1, GAMMA2_88, GAMMA2_88 + 1, GAMMA2_88 + 100,
+	}
+	a1Values := []int32{-1000, -1, 0, 1, 43, 1000}
+
+	for _, a0 := range testValues {
+		for _, a1 := range a1Values {
+			got := MakeHint88(a0, a1)
+			expected := makeHintRef(a0, a1)
+			if got != expected {
+				t.Errorf("MakeHint88(%d, %d) = %d, reference = %d", a0, a1, got, expected)
+			}
+		}
+	}
+}
+
+// TestUseHint88ConstantTimeReference verifies the constant-time
+// implementation against the reference branching implementation for
+// every a in [0, Q), including the 0 <-> 43 wrap-around.
+func TestUseHint88ConstantTimeReference(t *testing.T) {
+	useHintRef := func(a int32, hint int) int32 {
+		a1, a0 := decompose88Ref(a)
+		if hint == 0 {
+			return a1
+		}
+		if a0 > 0 {
+			if a1 == 43 {
+				return 0
+			}
+			return a1 + 1
+		}
+		if a1 == 0 {
+			return 43
+		}
+		return a1 - 1
+	}
+
+	for a := int32(0); a < Q; a++ {
+		for hint := 0; hint <= 1; hint++ {
+			got := UseHint88(a, hint)
+			expected := useHintRef(a, hint)
+			if got != expected {
+				t.Fatalf("UseHint88(%d, %d) = %d, reference = %d", a, hint, got, expected)
+			}
+		}
+	}
+}
@@ -80,3 +80,62 @@ func UseHint(a int32, hint int) int32 {
 	// Select result using masks (exactly one mask is all-1s)
 	return (result0 & mask0) | (resultPos & maskPos) | (resultNeg & maskNeg)
 }
+
+// Decompose88 is Decompose for GAMMA2_88 (ML-DSA-44): it computes a1, a0
+// such that a mod Q = a1*2*GAMMA2_88 + a0, with a1 in [0, 43].
+func Decompose88(a0 *int32, a int32) int32 {
+	a1 := (a + 127) >> 7
+	a1 = (a1*11275 + (1 << 23)) >> 24
+	// Map a1 == 44 to 0 without branching.
+	a1 ^= ((43 - a1) >> 31) & a1
+
+	*a0 = a - a1*2*GAMMA2_88
+	*a0 -= (((Q-1)/2 - *a0) >> 31) & Q
+
+	return a1
+}
+
+// MakeHint88 is MakeHint for GAMMA2_88 (ML-DSA-44).
+// This is a constant-time implementation to prevent timing side-channels.
+func MakeHint88(a0, a1 int32) uint {
+	gtGamma2 := uint32(GAMMA2_88-a0) >> 31
+	ltNegGamma2 := uint32(a0+GAMMA2_88) >> 31
+	diff := a0 + GAMMA2_88
+	eqNegGamma2 := 1 - (uint32(diff|(-diff)) >> 31)
+	a1NonZero := uint32(a1|(-a1)) >> 31
+
+	result := gtGamma2 | ltNegGamma2 | (eqNegGamma2 & a1NonZero)
+
+	return uint(result & 1)
+}
+
+// UseHint88 is UseHint for GAMMA2_88 (ML-DSA-44), where the high bits
+// wrap modulo 44 rather than 16.
+// This is a constant-time implementation to prevent timing side-channels.
+func UseHint88(a int32, hint int) int32 {
+	var a0, a1 int32
+	a1 = Decompose88(&a0, a)
+
+	// isZero(x) = 1 - (((x) | -(x)) >> 31) gives 1 for x == 0, 0 otherwise
+	a1Is43 := int32(1 - (uint32((a1-43)|(43-a1)) >> 31))
+	a1Is0 := int32(1 - (uint32(a1|(-a1)) >> 31))
+
+	result0 := a1                   // when hint == 0
+	resultPos := a1 + 1 - 44*a1Is43 // when hint != 0 && a0 > 0 (43 wraps to 0)
+	resultNeg := a1 - 1 + 44*a1Is0  // when hint != 0 && a0 <= 0 (0 wraps to 43)
+
+	hint32 := int32(hint)
+	hintIsZero := int32(1 - ((uint32(hint32|(-hint32)) >> 31) & 1))
+	a0Positive := int32((uint32(-a0) >> 31) & 1)
+
+	hintNonZero := 1 - hintIsZero
+	mask0 := -hintIsZero
+	maskHintNZ := -hintNonZero
+	maskA0Pos := -a0Positive
+	maskA0NotPos := ^maskA0Pos
+
+	maskPos := maskHintNZ & maskA0Pos
+	maskNeg := maskHintNZ & maskA0NotPos
+
+	return (result0 & mask0) | (resultPos & maskPos) | (resultNeg & maskNeg)
+}
@@ -0,0 +1,173 @@
+// Package prehash holds the pre-hash function table shared by
+// HashML-DSA (FIPS 204 §5.4) and HashSLH-DSA (FIPS 205 §10.2.2): the
+// supported functions, their digest sizes and DER-encoded OIDs, and the
+// domain-separated prefix both schemes sign ahead of the digest.
+// crypto/ml_dsa_87 and crypto/slhdsa re-export [Function] as their
+// PreHashFunction.
+package prehash
+
+import (
+	"fmt"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+// Function selects the hash or XOF used to pre-hash the message. The
+// caller computes the digest; the library only needs the function's
+// identity to embed its DER-encoded OID in the signed message
+// representative and to check the digest length.
+//
+// The zero value is deliberately invalid so an unset field cannot
+// silently select a hash function.
+type Function uint8
+
+const (
+	// SHA2_224 — SHA-224 (FIPS 180-4), 28-byte digest.
+	SHA2_224 Function = iota + 1
+	// SHA2_256 — SHA-256 (FIPS 180-4), 32-byte digest.
+	SHA2_256
+	// SHA2_384 — SHA-384 (FIPS 180-4), 48-byte digest.
+	SHA2_384
+	// SHA2_512 — SHA-512 (FIPS 180-4), 64-byte digest.
+	SHA2_512
+	// SHA2_512_224 — SHA-512/224 (FIPS 180-4), 28-byte digest.
+	SHA2_512_224
+	// SHA2_512_256 — SHA-512/256 (FIPS 180-4), 32-byte digest.
+	SHA2_512_256
+	// SHA3_224 — SHA3-224 (FIPS 202), 28-byte digest.
+	SHA3_224
+	// SHA3_256 — SHA3-256 (FIPS 202), 32-byte digest.
+	SHA3_256
+	// SHA3_384 — SHA3-384 (FIPS 202), 48-byte digest.
+	SHA3_384
+	// SHA3_512 — SHA3-512 (FIPS 202), 64-byte digest.
+	SHA3_512
+	// SHAKE_128 — SHAKE128 (FIPS 202) with a 256-bit output, as fixed
+	// by FIPS 204 §5.4 and FIPS 205 §10.2.2.
+	SHAKE_128
+	// SHAKE_256 — SHAKE256 (FIPS 202) with a 512-bit output, as fixed
+	// by FIPS 204 §5.4 and FIPS 205 §10.2.2.
+	SHAKE_256
+)
+
+// oidPrefix is the DER encoding of the NIST hash-algorithm arc
+// 2.16.840.1.101.3.4.2 (tag 0x06, length 0x09). The final byte of the
+// OID identifies the individual function.
+var oidPrefix = [...]uint8{0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02}
+
+// OID_BYTES is the length of the DER-encoded OID placed between the
+// context and the digest.
+const OID_BYTES = len(oidPrefix) + 1
+
+// IsValid reports whether f is one of the supported pre-hash
+// functions. The zero value and values past [SHAKE_256] are not.
+func (f Function) IsValid() bool {
+	return f >= SHA2_224 && f <= SHAKE_256
+}
+
+// DigestSize returns the number of digest bytes expected for f, or 0 if
+// f is not a supported function.
+func (f Function) DigestSize() int {
+	switch f {
+	case SHA2_224, SHA2_512_224, SHA3_224:
+		return 28
+	case SHA2_256, SHA2_512_256, SHA3_256, SHAKE_128:
+		return 32
+	case SHA2_384, SHA3_384:
+		return 48
+	case SHA2_512, SHA3_512, SHAKE_256:
+		return 64
+	default:
+		return 0
+	}
+}
+
+func (f Function) String() string {
+	switch f {
+	case SHA2_224:
+		return "SHA2_224"
+	case SHA2_256:
+		return "SHA2_256"
+	case SHA2_384:
+		return "SHA2_384"
+	case SHA2_512:
+		return "SHA2_512"
+	case SHA2_512_224:
+		return "SHA2_512_224"
+	case SHA2_512_256:
+		return "SHA2_512_256"
+	case SHA3_224:
+		return "SHA3_224"
+	case SHA3_256:
+		return "SHA3_256"
+	case SHA3_384:
+		return "SHA3_384"
+	case SHA3_512:
+		return "SHA3_512"
+	case SHAKE_128:
+		return "SHAKE_128"
+	case SHAKE_256:
+		return "SHAKE_256"
+	default:
+		return fmt.Sprintf("UnknownPreHashFunction(%d)", f)
+	}
+}
+
+// OID returns the DER-encoded OID of f. The last arc follows the NIST
+// registry order, which differs from the declaration order above.
+func (f Function) OID() [OID_BYTES]uint8 {
+	var arc uint8
+	switch f {
+	case SHA2_256:
+		arc = 0x01
+	case SHA2_384:
+		arc = 0x02
+	case SHA2_512:
+		arc = 0x03
+	case SHA2_224:
+		arc = 0x04
+	case SHA2_512_224:
+		arc = 0x05
+	case SHA2_512_256:
+		arc = 0x06
+	case SHA3_224:
+		arc = 0x07
+	case SHA3_256:
+		arc = 0x08
+	case SHA3_384:
+		arc = 0x09
+	case SHA3_512:
+		arc = 0x0a
+	case SHAKE_128:
+		arc = 0x0b
+	case SHAKE_256:
+		arc = 0x0c
+	}
+	var out [OID_BYTES]uint8
+	copy(out[:], oidPrefix[:])
+	out[len(oidPrefix)] = arc
+	return out
+}
+
+// Prefix builds the prefix `0x01 || len(ctx) || ctx || OID(f)` that
+// precedes the digest in the signed message (FIPS 204 Algorithm 4,
+// line 23; FIPS 205 Algorithm 23). It checks ctx, f and the digest
+// length first.
+func Prefix(ctx []uint8, f Function, digest []uint8) ([]uint8, error) {
+	if len(ctx) > 255 {
+		return nil, cryptoerrors.ErrInvalidContext
+	}
+	if !f.IsValid() {
+		return nil, cryptoerrors.ErrInvalidHashFunction
+	}
+	if len(digest) != f.DigestSize() {
+		return nil, cryptoerrors.ErrInvalidLength
+	}
+	oid := f.OID()
+	pre := make([]uint8, 2+len(ctx)+OID_BYTES)
+	pre[0] = 1
+	pre[1] = uint8(len(ctx))
+	copy(pre[2:], ctx)
+	copy(pre[2+len(ctx):], oid[:])
+	return pre, nil
+}
@@ -0,0 +1,73 @@
+package prehash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+var allFunctions = []Function{
+	SHA2_224, SHA2_256, SHA2_384, SHA2_512, SHA2_512_224, SHA2_512_256,
+	SHA3_224, SHA3_256, SHA3_384, SHA3_512, SHAKE_128, SHAKE_256,
+}
+
+func TestOIDsAreDistinct(t *testing.T) {
+	seen := make(map[[OID_BYTES]uint8]Function)
+	for _, f := range allFunctions {
+		oid := f.OID()
+		if !bytes.Equal(oid[:len(oidPrefix)], oidPrefix[:]) {
+			t.Errorf("%v OID prefix = %x", f, oid)
+		}
+		if prev, ok := seen[oid]; ok {
+			t.Errorf("%v and %v share OID %x", f, prev, oid)
+		}
+		seen[oid] = f
+	}
+	// SHA-256 is 2.16.840.1.101.3.4.2.1 (FIPS 204 §5.4.1 example).
+	if oid := SHA2_256.OID(); hex.EncodeToString(oid[:]) != "0609608648016503040201" {
+		t.Errorf("SHA2_256 OID = %x", oid)
+	}
+}
+
+func TestString(t *testing.T) {
+	if got := SHAKE_128.String(); got != "SHAKE_128" {
+		t.Errorf("SHAKE_128.String() = %q", got)
+	}
+	if got := Function(0).String(); got != "UnknownPreHashFunction(0)" {
+		t.Errorf("Function(0).String() = %q", got)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	digest := make([]uint8, 32)
+	pre, err := Prefix([]uint8("ctx"), SHA2_256, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "0103637478" + "0609608648016503040201"; hex.EncodeToString(pre) != want {
+		t.Errorf("Prefix = %x, want %s", pre, want)
+	}
+
+	tests := []struct {
+		name   string
+		ctx    []uint8
+		f      Function
+		digest []uint8
+		want   error
+	}{
+		{"context too long", make([]uint8, 256), SHA2_256, digest, cryptoerrors.ErrInvalidContext},
+		{"zero function", nil, 0, digest, cryptoerrors.ErrInvalidHashFunction},
+		{"unknown function", nil, SHAKE_256 + 1, digest, cryptoerrors.ErrInvalidHashFunction},
+		{"wrong digest length", nil, SHA2_512, digest, cryptoerrors.ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Prefix(tt.ctx, tt.f, tt.digest); !errors.Is(err, tt.want) {
+				t.Errorf("Prefix error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
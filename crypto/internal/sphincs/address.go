@@ -1,9 +1,7 @@
-package sphincsplus_256s
+package sphincs
 
 import (
 	"encoding/binary"
-
-	"github.com/theQRL/go-qrllib/crypto/sphincsplus_256s/params"
 )
 
 const (
@@ -16,15 +14,35 @@ const (
 	SPX_ADDR_TYPE_FORSPRF  = 6
 )
 
-func init() {
-	//coverage:ignore
-	//rationale: compile-time constant assertion, never fails with valid params
-	//noinspection GoBoolExpressions
-	if (params.SPX_TREE_HEIGHT * (params.SPX_D - 1)) > 64 {
-		//coverage:ignore
-		//rationale: panic branch is dead code with current params (assertion never triggers)
-		panic("Subtree addressing is limited to at most 2^64 trees")
-	}
+// Byte offsets of the address fields. Every parameter set uses this
+// 32-byte layout; the SHA2 instantiations hash its 22-byte compressed
+// form (see compressAddr).
+const (
+	SPX_OFFSET_LAYER      = 3
+	SPX_OFFSET_TREE       = 8
+	SPX_OFFSET_TYPE       = 19
+	SPX_OFFSET_KP_ADDR    = 20
+	SPX_OFFSET_CHAIN_ADDR = 27
+	SPX_OFFSET_HASH_ADDR  = 31
+	SPX_OFFSET_TREE_HGT   = 27
+	SPX_OFFSET_TREE_INDEX = 28
+)
+
+// SPX_ADDR_COMPRESSED_BYTES is the length of the compressed address
+// ADRSc of FIPS 205 §11.2.
+const SPX_ADDR_COMPRESSED_BYTES = 22
+
+// compressAddr returns ADRSc: the low byte of the layer address, the
+// low 8 bytes of the tree address, the low byte of the type and the
+// final 12 bytes of addr.
+func compressAddr(addr *[8]uint32) [SPX_ADDR_COMPRESSED_BYTES]byte {
+	b := addrToBytes(addr)
+	var out [SPX_ADDR_COMPRESSED_BYTES]byte
+	out[0] = b[SPX_OFFSET_LAYER]
+	copy(out[1:9], b[SPX_OFFSET_TREE:SPX_OFFSET_TREE+8])
+	out[9] = b[SPX_OFFSET_TYPE]
+	copy(out[10:], b[SPX_OFFSET_KP_ADDR:])
+	return out
 }
 
 // addrToBytes converts [8]uint32 to [32]byte using big-endian encoding.
@@ -55,29 +73,29 @@ func updateAddrByte(addr *[8]uint32, offset int, value byte) {
 }
 
 func setLayerAddr(addr *[8]uint32, layer uint32) {
-	updateAddrByte(addr, params.SPX_OFFSET_LAYER, byte(layer))
+	updateAddrByte(addr, SPX_OFFSET_LAYER, byte(layer))
 }
 
 func setTreeAddr(addr *[8]uint32, tree uint64) {
 	bytes := addrToBytes(addr)
-	binary.BigEndian.PutUint64(bytes[params.SPX_OFFSET_TREE:], tree)
+	binary.BigEndian.PutUint64(bytes[SPX_OFFSET_TREE:], tree)
 	*addr = bytesToAddr(bytes[:])
 }
 
 func setType(addr *[8]uint32, typ uint32) {
-	updateAddrByte(addr, params.SPX_OFFSET_TYPE, byte(typ))
+	updateAddrByte(addr, SPX_OFFSET_TYPE, byte(typ))
 }
 
 func copySubtreeAddr(out, in *[8]uint32) {
 	inBytes := addrToBytes(in)
 	outBytes := addrToBytes(out)
-	copy(outBytes[:params.SPX_OFFSET_TREE+8], inBytes[:params.SPX_OFFSET_TREE+8])
+	copy(outBytes[:SPX_OFFSET_TREE+8], inBytes[:SPX_OFFSET_TREE+8])
 	*out = bytesToAddr(outBytes[:])
 }
 
 func setKeypairAddr(addr *[8]uint32, keypair uint32) {
 	bytes := addrToBytes(addr)
-	binary.BigEndian.PutUint32(bytes[params.SPX_OFFSET_KP_ADDR:], keypair)
+	binary.BigEndian.PutUint32(bytes[SPX_OFFSET_KP_ADDR:], keypair)
 	*addr = bytesToAddr(bytes[:])
 }
 
@@ -91,28 +109,28 @@ func copyKeypairAddr(out, in *[8]uint32) {
 	outBytes := addrToBytes(out)
 
 	// Copy first (SPX_OFFSET_TREE + 8) bytes
-	copy(outBytes[:params.SPX_OFFSET_TREE+8], inBytes[:params.SPX_OFFSET_TREE+8])
+	copy(outBytes[:SPX_OFFSET_TREE+8], inBytes[:SPX_OFFSET_TREE+8])
 
 	// Copy 4 bytes at SPX_OFFSET_KP_ADDR (typically offset 28)
-	copy(outBytes[params.SPX_OFFSET_KP_ADDR:params.SPX_OFFSET_KP_ADDR+4], inBytes[params.SPX_OFFSET_KP_ADDR:params.SPX_OFFSET_KP_ADDR+4])
+	copy(outBytes[SPX_OFFSET_KP_ADDR:SPX_OFFSET_KP_ADDR+4], inBytes[SPX_OFFSET_KP_ADDR:SPX_OFFSET_KP_ADDR+4])
 
 	*out = bytesToAddr(outBytes[:])
 }
 
 func setChainAddr(addr *[8]uint32, chain uint32) {
-	updateAddrByte(addr, params.SPX_OFFSET_CHAIN_ADDR, byte(chain))
+	updateAddrByte(addr, SPX_OFFSET_CHAIN_ADDR, byte(chain))
 }
 
 func setHashAddr(addr *[8]uint32, hash uint32) {
-	updateAddrByte(addr, params.SPX_OFFSET_HASH_ADDR, byte(hash))
+	updateAddrByte(addr, SPX_OFFSET_HASH_ADDR, byte(hash))
 }
 
 func setTreeHeight(addr *[8]uint32, treeHeight uint32) {
-	updateAddrByte(addr, params.SPX_OFFSET_TREE_HGT, byte(treeHeight))
+	updateAddrByte(addr, SPX_OFFSET_TREE_HGT, byte(treeHeight))
 }
 
 func setTreeIndex(addr *[8]uint32, treeIndex uint32) {
 	bytes := addrToBytes(addr)
-	binary.BigEndian.PutUint32(bytes[params.SPX_OFFSET_TREE_INDEX:], treeIndex)
+	binary.BigEndian.PutUint32(bytes[SPX_OFFSET_TREE_INDEX:], treeIndex)
 	*addr = bytesToAddr(bytes[:])
 }
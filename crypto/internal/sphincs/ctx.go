@@ -0,0 +1,53 @@
+// Package sphincs is the hash-based core shared by the SPHINCS+
+// submission in crypto/sphincsplus_256s and FIPS 205 SLH-DSA in
+// crypto/slhdsa. One implementation serves every parameter set; a
+// [Params] value selects the sizes and the hash family.
+//
+// The two schemes run the same WOTS+, FORS and hypertree code. They
+// differ in the tweakable hash: the round-3 robust construction masks
+// its input with a SHAKE256-derived bitmask, FIPS 205 hashes it
+// directly (the "simple" construction). [Ctx.Simple] selects between
+// them; the robust construction is only implemented for SHAKE. Message
+// framing (FIPS 205 context strings, pre-hashing) is left to the
+// callers: [Sign] and [Verify] are slh_sign_internal and
+// slh_verify_internal.
+package sphincs
+
+import "hash"
+
+// Ctx carries the parameter set, the seeds every hash call is keyed
+// with and the tweakable-hash variant. Only the first Params.N bytes
+// of each seed are used.
+type Ctx struct {
+	PubSeed [SPX_MAX_N]byte
+	SkSeed  [SPX_MAX_N]byte
+
+	// Simple selects the FIPS 205 tweakable hash
+	// SHAKE256(PK.seed || ADRS || M). The zero value selects the
+	// round-3 robust construction.
+	Simple bool
+
+	// Workers is the number of goroutines a treehash may use to
+	// generate leaves. Values below 2 select the serial path; the
+	// output is the same either way.
+	Workers int
+
+	// Params is the parameter set. nil selects SHAKE_256s, so the zero
+	// Ctx is round-3 SPHINCS+ SHAKE-256s-robust.
+	Params *Params
+
+	// sha256Seeded and sha512Seeded hold SHA-256 and SHA-512 states
+	// that have absorbed PK.seed and its padding to a full block, as in
+	// the SHA2 reference implementation. They are set up lazily by
+	// initializeHashFunction.
+	sha256Seeded hash.Cloner
+	sha512Seeded hash.Cloner
+}
+
+// params returns the parameter set of ctx.
+func (ctx *Ctx) params() *Params {
+	if ctx.Params == nil {
+		return SHAKE_256s
+	}
+	return ctx.Params
+}
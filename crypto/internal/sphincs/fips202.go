@@ -0,0 +1,20 @@
+package sphincs
+
+import (
+	"crypto/sha3"
+)
+
+// shake256 fills output with SHAKE256(input).
+func shake256(output, input []byte) {
+	shake := sha3.NewSHAKE256()
+	if _, err := shake.Write(input); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		panic("shake256: write failed: " + err.Error())
+	}
+	if _, err := shake.Read(output); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Read never returns an error for XOF
+		panic("shake256: read failed: " + err.Error())
+	}
+}
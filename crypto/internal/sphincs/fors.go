@@ -1,14 +1,10 @@
-package sphincsplus_256s
+package sphincs
 
-import (
-	"github.com/theQRL/go-qrllib/crypto/sphincsplus_256s/params"
-)
-
-func forsGenSK(sk []byte, ctx *SPXCtx, forsLeafAddr *[8]uint32) {
+func forsGenSK(sk []byte, ctx *Ctx, forsLeafAddr *[8]uint32) {
 	prfAddr(sk, ctx, forsLeafAddr)
 }
 
-func forsSKToLeaf(leaf []byte, sk []byte, ctx *SPXCtx, forsLeafAddr *[8]uint32) {
+func forsSKToLeaf(leaf []byte, sk []byte, ctx *Ctx, forsLeafAddr *[8]uint32) {
 	tHash(leaf, sk, 1, ctx, forsLeafAddr)
 }
 
@@ -16,7 +12,12 @@ type forsGenLeafInfo struct {
 	LeafAddrX [8]uint32
 }
 
-func forsGenLeafX1(leaf []byte, ctx *SPXCtx, addrIdx uint32, info any) {
+func (info *forsGenLeafInfo) clone() any {
+	c := *info
+	return &c
+}
+
+func forsGenLeafX1(leaf []byte, ctx *Ctx, addrIdx uint32, info any) {
 	forsInfo := info.(*forsGenLeafInfo)
 	forsLeafAddr := &forsInfo.LeafAddrX
 
@@ -30,25 +31,26 @@ func forsGenLeafX1(leaf []byte, ctx *SPXCtx, addrIdx uint32, info any) {
 	forsSKToLeaf(leaf, leaf, ctx, forsLeafAddr)
 }
 
-func messageToIndices(indices []uint32, m []byte) {
+func messageToIndices(indices []uint32, m []byte, p *Params) {
 	offset := uint(0)
 
-	for i := 0; i < params.SPX_FORS_TREES; i++ {
+	for i := 0; i < p.ForsTrees; i++ {
 		indices[i] = 0
-		for j := 0; j < params.SPX_FORS_HEIGHT; j++ {
+		for j := 0; j < p.ForsHeight; j++ {
 			byteIdx := int(offset >> 3)
 			bitOffset := ^offset & 0x7
 			bit := (m[byteIdx] >> bitOffset) & 1
-			shift := uint(params.SPX_FORS_HEIGHT - 1 - j)
+			shift := uint(p.ForsHeight - 1 - j)
 			indices[i] ^= uint32(bit) << shift
 			offset++
 		}
 	}
 }
 
-func forsSign(sig []byte, pk []byte, m []byte, ctx *SPXCtx, forsAddr *[8]uint32) {
-	var indices [params.SPX_FORS_TREES]uint32
-	roots := make([]byte, params.SPX_FORS_TREES*params.SPX_N)
+func forsSign(sig []byte, pk []byte, m []byte, ctx *Ctx, forsAddr *[8]uint32) {
+	p := ctx.params()
+	indices := make([]uint32, p.ForsTrees)
+	roots := make([]byte, p.ForsTrees*p.N)
 
 	var forsTreeAddr [8]uint32
 	var forsLeafInfo forsGenLeafInfo
@@ -61,11 +63,11 @@ func forsSign(sig []byte, pk []byte, m []byte, ctx *SPXCtx, forsAddr *[8]uint32)
 	copyKeypairAddr(&forsPkAddr, forsAddr)
 	setType(&forsPkAddr, SPX_ADDR_TYPE_FORSPK)
 
-	messageToIndices(indices[:], m)
+	messageToIndices(indices, m, p)
 
 	sigOffset := 0
-	for i := 0; i < params.SPX_FORS_TREES; i++ {
-		idxOffset := uint32(i) * (1 << params.SPX_FORS_HEIGHT)
+	for i := 0; i < p.ForsTrees; i++ {
+		idxOffset := uint32(i) * (1 << p.ForsHeight)
 
 		setTreeHeight(&forsTreeAddr, 0)
 		setTreeIndex(&forsTreeAddr, indices[i]+idxOffset)
@@ -73,39 +75,40 @@ func forsSign(sig []byte, pk []byte, m []byte, ctx *SPXCtx, forsAddr *[8]uint32)
 
 		// Generate secret key for this leaf
 		forsGenSK(sig[sigOffset:], ctx, &forsTreeAddr)
-		sigOffset += params.SPX_N
+		sigOffset += p.N
 
 		// Compute auth path and root
 		setType(&forsTreeAddr, SPX_ADDR_TYPE_FORSTREE)
 		treeHashX1(
-			roots[i*params.SPX_N:(i+1)*params.SPX_N],
+			roots[i*p.N:(i+1)*p.N],
 			sig[sigOffset:],
 			ctx,
 			indices[i],
 			idxOffset,
-			params.SPX_FORS_HEIGHT,
+			uint32(p.ForsHeight),
 			forsGenLeafX1,
 			&forsTreeAddr,
 			&forsLeafInfo,
 		)
 
-		sigOffset += params.SPX_N * params.SPX_FORS_HEIGHT
+		sigOffset += p.N * p.ForsHeight
 	}
 
 	// Compute public key from all FORS roots
-	tHash(pk, roots, params.SPX_FORS_TREES, ctx, &forsPkAddr)
+	tHash(pk, roots, uint(p.ForsTrees), ctx, &forsPkAddr)
 }
 
 func forsPKFromSig(
 	pk []byte,
 	sig []byte,
 	m []byte,
-	ctx *SPXCtx,
+	ctx *Ctx,
 	forsAddr *[8]uint32,
 ) {
-	var indices [params.SPX_FORS_TREES]uint32
-	roots := make([]byte, params.SPX_FORS_TREES*params.SPX_N)
-	leaf := make([]byte, params.SPX_N)
+	p := ctx.params()
+	indices := make([]uint32, p.ForsTrees)
+	roots := make([]byte, p.ForsTrees*p.N)
+	leaf := make([]byte, p.N)
 
 	var forsTreeAddr [8]uint32
 	var forsPkAddr [8]uint32
@@ -116,33 +119,33 @@ func forsPKFromSig(
 	setType(&forsTreeAddr, SPX_ADDR_TYPE_FORSTREE)
 	setType(&forsPkAddr, SPX_ADDR_TYPE_FORSPK)
 
-	messageToIndices(indices[:], m)
+	messageToIndices(indices, m, p)
 
 	sigOffset := 0
-	for i := 0; i < params.SPX_FORS_TREES; i++ {
-		idxOffset := uint32(i) * (1 << params.SPX_FORS_HEIGHT)
+	for i := 0; i < p.ForsTrees; i++ {
+		idxOffset := uint32(i) * (1 << p.ForsHeight)
 
 		setTreeHeight(&forsTreeAddr, 0)
 		setTreeIndex(&forsTreeAddr, indices[i]+idxOffset)
 
 		// Derive the leaf from the included secret key part
 		forsSKToLeaf(leaf, sig[sigOffset:], ctx, &forsTreeAddr)
-		sigOffset += params.SPX_N
+		sigOffset += p.N
 
 		// Derive the root of this FORS tree
 		computeRoot(
-			roots[i*params.SPX_N:(i+1)*params.SPX_N],
+			roots[i*p.N:(i+1)*p.N],
 			leaf,
 			indices[i],
 			idxOffset,
 			sig[sigOffset:],
-			params.SPX_FORS_HEIGHT,
+			uint32(p.ForsHeight),
 			ctx,
 			&forsTreeAddr,
 		)
-		sigOffset += params.SPX_N * params.SPX_FORS_HEIGHT
+		sigOffset += p.N * p.ForsHeight
 	}
 
 	// Hash horizontally across all tree roots to derive the FORS public key
-	tHash(pk, roots, params.SPX_FORS_TREES, ctx, &forsPkAddr)
+	tHash(pk, roots, uint(p.ForsTrees), ctx, &forsPkAddr)
 }
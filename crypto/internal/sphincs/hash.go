@@ -0,0 +1,64 @@
+package sphincs
+
+// initializeHashFunction prepares the per-key hash state: a no-op for
+// SHAKE256, the seeded SHA-256/SHA-512 states for SHA2.
+func initializeHashFunction(ctx *Ctx) {
+	if ctx.params().SHA2 {
+		seedStateSHA2(ctx)
+	}
+}
+
+// prfAddr computes PRF(PK.seed, SK.seed, ADRS) into out[:n].
+func prfAddr(out []byte, ctx *Ctx, addr *[8]uint32) {
+	if ctx.params().SHA2 {
+		prfAddrSHA2(out, ctx, addr)
+		return
+	}
+	prfAddrShake(out, ctx, addr)
+}
+
+// genMessageRandom computes R = PRF_msg(SK.prf, opt_rand, pre || m).
+func genMessageRandom(R, skPrf, optRand, pre, m []byte, ctx *Ctx) {
+	if ctx.params().SHA2 {
+		genMessageRandomSHA2(R, skPrf, optRand, pre, m, ctx)
+		return
+	}
+	genMessageRandomShake(R, skPrf, optRand, pre, m, ctx)
+}
+
+// hashMessage produces the message digest H_msg(R, PK.seed, PK.root,
+// pre || m) and parses the FORS message and the tree and leaf indices
+// from it
+func hashMessage(digest []byte, tree *uint64, leafIdx *uint32,
+	R, pk, pre, m []byte, ctx *Ctx) {
+
+	p := ctx.params()
+	buf := make([]byte, p.DgstBytes)
+	if p.SHA2 {
+		hashMessageSHA2(buf, R, pk, pre, m, ctx)
+	} else {
+		hashMessageShake(buf, R, pk, pre, m, ctx)
+	}
+
+	offset := 0
+	copy(digest, buf[offset:offset+p.ForsMsgBytes])
+	offset += p.ForsMsgBytes
+
+	// A shift by 64 yields 0, so a single-layer hypertree (no tree
+	// bits) gets tree 0 without a special case.
+	*tree = bytesToULL(buf[offset : offset+p.TreeBytes])
+	*tree &= ^uint64(0) >> (64 - p.TreeBits)
+	offset += p.TreeBytes
+
+	*leafIdx = uint32(bytesToULL(buf[offset : offset+p.LeafBytes]))
+	*leafIdx &= ^uint32(0) >> (32 - p.LeafBits)
+}
+
+// bytesToULL converts a big-endian byte slice to a uint64
+func bytesToULL(b []byte) uint64 {
+	var val uint64
+	for i := 0; i < len(b); i++ {
+		val = (val << 8) | uint64(b[i])
+	}
+	return val
+}
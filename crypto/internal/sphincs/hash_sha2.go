@@ -0,0 +1,125 @@
+package sphincs
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"hash"
+)
+
+// The SHA2 instantiation of FIPS 205 §11.2. Security category 1 sets
+// (n = 16) use SHA-256 throughout. The others use SHA-256 for F and
+// PRF and SHA-512 for H, T_l, PRF_msg and H_msg.
+
+// seedStateSHA2 absorbs PK.seed, zero-padded to a full block, into
+// fresh SHA-256 and SHA-512 states. Every F, H, T_l and PRF call
+// starts from a clone of one of them.
+func seedStateSHA2(ctx *Ctx) {
+	n := ctx.params().N
+	block := make([]byte, sha512.BlockSize)
+	copy(block, ctx.PubSeed[:n])
+
+	h256 := sha256.New()
+	_, _ = h256.Write(block[:sha256.BlockSize])
+	ctx.sha256Seeded = h256.(hash.Cloner)
+
+	h512 := sha512.New()
+	_, _ = h512.Write(block)
+	ctx.sha512Seeded = h512.(hash.Cloner)
+}
+
+// seededSHA2 returns a copy of the seeded SHA-256 state, or of the
+// SHA-512 state if wide is set and the set is above category 1.
+func seededSHA2(ctx *Ctx, wide bool) hash.Hash {
+	if ctx.sha256Seeded == nil {
+		seedStateSHA2(ctx)
+	}
+	state := ctx.sha256Seeded
+	if wide && ctx.params().N > 16 {
+		state = ctx.sha512Seeded
+	}
+	h, err := state.Clone()
+	if err != nil {
+		//coverage:ignore
+		//rationale: the standard library SHA-2 states always clone
+		panic("sphincs: SHA-2 clone failed: " + err.Error())
+	}
+	return h
+}
+
+// newWideSHA2 returns the SHA-2 function used for PRF_msg and H_msg.
+func newWideSHA2(ctx *Ctx) func() hash.Hash {
+	if ctx.params().N > 16 {
+		return sha512.New
+	}
+	return sha256.New
+}
+
+// prfAddrSHA2 computes PRF = Trunc_n(SHA-256(PK.seed || pad || ADRSc
+// || SK.seed)).
+func prfAddrSHA2(out []byte, ctx *Ctx, addr *[8]uint32) {
+	n := ctx.params().N
+	h := seededSHA2(ctx, false)
+	a := compressAddr(addr)
+	_, _ = h.Write(a[:])
+	_, _ = h.Write(ctx.SkSeed[:n])
+	var sum [sha256.Size]byte
+	copy(out[:n], h.Sum(sum[:0]))
+}
+
+// tHashSHA2 computes Trunc_n(SHA-X(PK.seed || pad || ADRSc || in)),
+// with SHA-256 for F (one block) and the wide function for H and T_l.
+func tHashSHA2(out, in []byte, inBlocks uint, ctx *Ctx, addr *[8]uint32) {
+	n := ctx.params().N
+	h := seededSHA2(ctx, inBlocks > 1)
+	a := compressAddr(addr)
+	_, _ = h.Write(a[:])
+	_, _ = h.Write(in[:int(inBlocks)*n])
+	var sum [sha512.Size]byte
+	copy(out[:n], h.Sum(sum[:0]))
+}
+
+// genMessageRandomSHA2 computes R = Trunc_n(HMAC-SHA-X(skPrf, optRand
+// || pre || m)).
+func genMessageRandomSHA2(R, skPrf, optRand, pre, m []byte, ctx *Ctx) {
+	n := ctx.params().N
+	mac := hmac.New(newWideSHA2(ctx), skPrf[:n])
+	_, _ = mac.Write(optRand[:n])
+	_, _ = mac.Write(pre)
+	_, _ = mac.Write(m)
+	var sum [sha512.Size]byte
+	copy(R[:n], mac.Sum(sum[:0]))
+}
+
+// hashMessageSHA2 fills buf with MGF1-SHA-X(R || PK.seed ||
+// SHA-X(R || PK.seed || PK.root || pre || m)).
+func hashMessageSHA2(buf, R, pk, pre, m []byte, ctx *Ctx) {
+	n := ctx.params().N
+	newHash := newWideSHA2(ctx)
+
+	h := newHash()
+	_, _ = h.Write(R[:n])
+	_, _ = h.Write(pk[:2*n])
+	_, _ = h.Write(pre)
+	_, _ = h.Write(m)
+
+	seed := make([]byte, 0, 2*n+sha512.Size)
+	seed = append(seed, R[:n]...)
+	seed = append(seed, pk[:n]...)
+	seed = h.Sum(seed)
+	mgf1(buf, seed, newHash)
+}
+
+// mgf1 fills out with MGF1 (RFC 8017 §B.2.1) of seed under newHash.
+func mgf1(out, seed []byte, newHash func() hash.Hash) {
+	var counter [4]byte
+	var sum [sha512.Size]byte
+	for i := uint32(0); len(out) > 0; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		h := newHash()
+		_, _ = h.Write(seed)
+		_, _ = h.Write(counter[:])
+		out = out[copy(out, h.Sum(sum[:0])):]
+	}
+}
@@ -0,0 +1,80 @@
+package sphincs
+
+import (
+	"crypto/sha3"
+)
+
+// prfAddrShake computes PRF(pub_seed, addr, sk_seed) using SHAKE256
+func prfAddrShake(out []byte, ctx *Ctx, addr *[8]uint32) {
+	n := ctx.params().N
+	var block [2*SPX_MAX_N + SPX_ADDR_BYTES]byte
+	buf := block[:2*n+SPX_ADDR_BYTES]
+	copy(buf[:n], ctx.PubSeed[:n])
+	memcpy(buf[n:], addr)
+	copy(buf[n+SPX_ADDR_BYTES:], ctx.SkSeed[:n])
+
+	shake256(out[:n], buf)
+	zeroBytes(buf)
+}
+
+// genMessageRandomShake computes R = SHAKE256(skPrf || optRand || pre || m)
+func genMessageRandomShake(R, skPrf, optRand, pre, m []byte, ctx *Ctx) {
+	n := ctx.params().N
+	shake := sha3.NewSHAKE256()
+	if _, err := shake.Write(skPrf[:n]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		panic("shake.Write(skPrf) failed: " + err.Error())
+	}
+	if _, err := shake.Write(optRand[:n]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		panic("shake.Write(optRand) failed: " + err.Error())
+	}
+	if _, err := shake.Write(pre); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		panic("shake.Write(pre) failed: " + err.Error())
+	}
+	if _, err := shake.Write(m); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		panic("shake.Write(m) failed: " + err.Error())
+	}
+	if _, err := shake.Read(R[:n]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Read never returns an error for XOF
+		panic("shake.Read(R) failed: " + err.Error())
+	}
+}
+
+// hashMessageShake fills buf with SHAKE256(R || pk || pre || m)
+func hashMessageShake(buf, R, pk, pre, m []byte, ctx *Ctx) {
+	n := ctx.params().N
+	shake := sha3.NewSHAKE256()
+	if _, err := shake.Write(R[:n]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		panic("SHAKE256 write error on R: " + err.Error())
+	}
+	if _, err := shake.Write(pk[:2*n]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		panic("SHAKE256 write error on pk: " + err.Error())
+	}
+	if _, err := shake.Write(pre); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		panic("SHAKE256 write error on prefix: " + err.Error())
+	}
+	if _, err := shake.Write(m); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		panic("SHAKE256 write error on message: " + err.Error())
+	}
+	if _, err := shake.Read(buf); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Read never returns an error for XOF
+		panic("SHAKE256 read error: " + err.Error())
+	}
+}
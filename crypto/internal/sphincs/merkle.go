@@ -0,0 +1,39 @@
+package sphincs
+
+func MerkleSign(sig []byte, root []byte, ctx *Ctx, wotsAddr, treeAddr *[8]uint32, idxLeaf uint32) {
+	p := ctx.params()
+	authPath := sig[p.WotsBytes:]
+
+	var info LeafInfoX1
+	steps := make([]uint8, p.WotsLen)
+
+	info.WotsSig = sig
+	chainLengths(steps, root, p)
+	info.WotsSteps = steps
+
+	setType(treeAddr, SPX_ADDR_TYPE_HASHTREE)
+	setType(&info.PkAddr, SPX_ADDR_TYPE_WOTSPK)
+	copySubtreeAddr(&info.LeafAddr, wotsAddr)
+	copySubtreeAddr(&info.PkAddr, wotsAddr)
+
+	info.WotsSignLeaf = idxLeaf
+	treeHashX1(root, authPath, ctx,
+		idxLeaf, 0,
+		uint32(p.TreeHeight),
+		WotsGenLeafX1,
+		treeAddr, &info)
+}
+
+func MerkleGenRoot(root []byte, ctx *Ctx) {
+	p := ctx.params()
+	authPath := make([]byte, p.TreeHeight*p.N+p.WotsBytes)
+	var topTreeAddr [8]uint32
+	var wotsAddr [8]uint32
+
+	setLayerAddr(&topTreeAddr, uint32(p.D-1))
+	setLayerAddr(&wotsAddr, uint32(p.D-1))
+
+	MerkleSign(authPath, root, ctx,
+		&wotsAddr, &topTreeAddr,
+		^uint32(0)) // ~0 in C is 0xFFFFFFFF
+}
@@ -0,0 +1,85 @@
+package sphincs
+
+import "math/bits"
+
+const (
+	// SPX_WOTS_W and SPX_WOTS_LOGW are the Winternitz parameter and its
+	// base-2 logarithm, the same (lg w = 4) in every FIPS 205 set.
+	SPX_WOTS_W    = 16
+	SPX_WOTS_LOGW = 4
+
+	// SPX_ADDR_BYTES is the length of an uncompressed address (ADRS).
+	SPX_ADDR_BYTES = 32
+
+	// SPX_MAX_N is the largest security parameter n of any set, and
+	// the size of the seed arrays in Ctx.
+	SPX_MAX_N = 32
+)
+
+// Params describes one SPHINCS+/SLH-DSA parameter set. The first
+// fields are the FIPS 205 Table 2 inputs; the rest are derived from
+// them by newParams.
+type Params struct {
+	N          int  // n, bytes per hash output
+	FullHeight int  // h, hypertree height
+	D          int  // d, hypertree layers
+	ForsHeight int  // a, FORS tree height
+	ForsTrees  int  // k, FORS trees
+	SHA2       bool // SHA2 instantiation (FIPS 205 §11.2) rather than SHAKE
+
+	TreeHeight   int // h' = h / d, XMSS tree height
+	WotsLen1     int
+	WotsLen2     int
+	WotsLen      int
+	WotsBytes    int
+	ForsMsgBytes int
+	ForsBytes    int
+	TreeBits     int // bits of the digest selecting the bottom tree
+	TreeBytes    int
+	LeafBits     int // bits of the digest selecting the bottom leaf
+	LeafBytes    int
+	DgstBytes    int // m, length of H_msg output
+	Bytes        int // signature length
+	PKBytes      int
+	SKBytes      int
+	SeedBytes    int // SK.seed || SK.prf || PK.seed
+}
+
+func newParams(n, h, d, a, k int, sha2 bool) *Params {
+	p := &Params{N: n, FullHeight: h, D: d, ForsHeight: a, ForsTrees: k, SHA2: sha2}
+	p.TreeHeight = h / d
+	p.WotsLen1 = 8 * n / SPX_WOTS_LOGW
+	// len2 = floor(log2(len1 * (w - 1)) / lg w) + 1 (FIPS 205 Eq. 5.3)
+	p.WotsLen2 = (bits.Len(uint(p.WotsLen1*(SPX_WOTS_W-1)))-1)/SPX_WOTS_LOGW + 1
+	p.WotsLen = p.WotsLen1 + p.WotsLen2
+	p.WotsBytes = p.WotsLen * n
+	p.ForsMsgBytes = (a*k + 7) / 8
+	p.ForsBytes = (a + 1) * k * n
+	p.TreeBits = p.TreeHeight * (d - 1)
+	p.TreeBytes = (p.TreeBits + 7) / 8
+	p.LeafBits = p.TreeHeight
+	p.LeafBytes = (p.LeafBits + 7) / 8
+	p.DgstBytes = p.ForsMsgBytes + p.TreeBytes + p.LeafBytes
+	p.Bytes = n + p.ForsBytes + d*p.WotsBytes + h*n
+	p.PKBytes = 2 * n
+	p.SKBytes = 2*n + p.PKBytes
+	p.SeedBytes = 3 * n
+	return p
+}
+
+// The twelve FIPS 205 parameter sets (Table 2). SHAKE_256s is also the
+// parameter set of the round-3 SPHINCS+ SHAKE-256s submission.
+var (
+	SHA2_128s  = newParams(16, 63, 7, 12, 14, true)
+	SHAKE_128s = newParams(16, 63, 7, 12, 14, false)
+	SHA2_128f  = newParams(16, 66, 22, 6, 33, true)
+	SHAKE_128f = newParams(16, 66, 22, 6, 33, false)
+	SHA2_192s  = newParams(24, 63, 7, 14, 17, true)
+	SHAKE_192s = newParams(24, 63, 7, 14, 17, false)
+	SHA2_192f  = newParams(24, 66, 22, 8, 33, true)
+	SHAKE_192f = newParams(24, 66, 22, 8, 33, false)
+	SHA2_256s  = newParams(32, 64, 8, 14, 22, true)
+	SHAKE_256s = newParams(32, 64, 8, 14, 22, false)
+	SHA2_256f  = newParams(32, 68, 17, 9, 35, true)
+	SHAKE_256f = newParams(32, 68, 17, 9, 35, false)
+)
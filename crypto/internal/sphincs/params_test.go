@@ -0,0 +1,62 @@
+package sphincs
+
+import "testing"
+
+// TestParamsTable2 checks the derived sizes against FIPS 205 Table 2.
+func TestParamsTable2(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        *Params
+		m        int
+		pk, sig  int
+		len2     int
+		treeBits int
+	}{
+		{"SHA2-128s", SHA2_128s, 30, 32, 7856, 3, 54},
+		{"SHAKE-128s", SHAKE_128s, 30, 32, 7856, 3, 54},
+		{"SHA2-128f", SHA2_128f, 34, 32, 17088, 3, 63},
+		{"SHAKE-128f", SHAKE_128f, 34, 32, 17088, 3, 63},
+		{"SHA2-192s", SHA2_192s, 39, 48, 16224, 3, 54},
+		{"SHAKE-192s", SHAKE_192s, 39, 48, 16224, 3, 54},
+		{"SHA2-192f", SHA2_192f, 42, 48, 35664, 3, 63},
+		{"SHAKE-192f", SHAKE_192f, 42, 48, 35664, 3, 63},
+		{"SHA2-256s", SHA2_256s, 47, 64, 29792, 3, 56},
+		{"SHAKE-256s", SHAKE_256s, 47, 64, 29792, 3, 56},
+		{"SHA2-256f", SHA2_256f, 49, 64, 49856, 3, 64},
+		{"SHAKE-256f", SHAKE_256f, 49, 64, 49856, 3, 64},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := tc.p
+			if p.DgstBytes != tc.m || p.PKBytes != tc.pk || p.Bytes != tc.sig || p.WotsLen2 != tc.len2 || p.TreeBits != tc.treeBits {
+				t.Errorf("m/pk/sig/len2/treeBits = %d/%d/%d/%d/%d, want %d/%d/%d/%d/%d",
+					p.DgstBytes, p.PKBytes, p.Bytes, p.WotsLen2, p.TreeBits,
+					tc.m, tc.pk, tc.sig, tc.len2, tc.treeBits)
+			}
+			if p.SKBytes != 2*p.PKBytes || p.SeedBytes != 3*p.N {
+				t.Errorf("sk/seed = %d/%d", p.SKBytes, p.SeedBytes)
+			}
+		})
+	}
+}
+
+// TestCompressAddr checks the 22-byte ADRSc layout of FIPS 205 §11.2.
+func TestCompressAddr(t *testing.T) {
+	var addr [8]uint32
+	setLayerAddr(&addr, 7)
+	setTreeAddr(&addr, 0x0102030405060708)
+	setType(&addr, SPX_ADDR_TYPE_FORSTREE)
+	setKeypairAddr(&addr, 0x0a0b0c0d)
+	setTreeHeight(&addr, 0x11)
+	setTreeIndex(&addr, 0x21222324)
+
+	full := Uint32SliceToBytes(addr[:])
+	got := compressAddr(&addr)
+	want := append(append(append([]byte{full[3]}, full[8:16]...), full[19]), full[20:32]...)
+	if string(got[:]) != string(want) {
+		t.Errorf("compressAddr = %x, want %x", got, want)
+	}
+	if got[0] != 7 || got[9] != SPX_ADDR_TYPE_FORSTREE {
+		t.Errorf("layer/type = %d/%d", got[0], got[9])
+	}
+}
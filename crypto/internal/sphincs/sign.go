@@ -0,0 +1,157 @@
+package sphincs
+
+import (
+	"crypto/subtle"
+	"runtime"
+)
+
+// wipeSkSeed zeroizes the secret seed copy in ctx.
+func wipeSkSeed(ctx *Ctx) {
+	for i := range ctx.SkSeed {
+		ctx.SkSeed[i] = 0
+	}
+	runtime.KeepAlive(&ctx.SkSeed)
+}
+
+// zeroBytes overwrites b with zeros. runtime.KeepAlive prevents the compiler
+// from eliding the writes as a dead store.
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+	runtime.KeepAlive(&b)
+}
+
+// SeedKeypair derives a keypair for p from seed (FIPS 205 Algorithm
+// 18, slh_keygen_internal). sk receives SK.seed || SK.prf || PK.seed ||
+// PK.root and pk receives PK.seed || PK.root. workers is Ctx.Workers.
+func SeedKeypair(p *Params, pk, sk, seed []byte, simple bool, workers int) {
+	n := p.N
+	copy(sk[:p.SeedBytes], seed[:p.SeedBytes])
+
+	copy(pk, sk[2*n:3*n]) // PUB_SEED
+
+	ctx := &Ctx{Simple: simple, Workers: workers, Params: p}
+	copy(ctx.PubSeed[:], pk[:n])
+	copy(ctx.SkSeed[:], sk[:n])
+	defer wipeSkSeed(ctx)
+
+	initializeHashFunction(ctx)
+
+	MerkleGenRoot(sk[3*n:], ctx)
+	copy(pk[n:], sk[3*n:])
+}
+
+// Sign writes the p.Bytes signature of pre || m under sk to sig
+// (FIPS 205 Algorithm 19, slh_sign_internal). optRand is the n-byte
+// addrnd value: fresh randomness for hedged signing, PK.seed for
+// deterministic signing. workers is Ctx.Workers.
+func Sign(p *Params, sig, pre, m, sk, optRand []byte, simple bool, workers int) {
+	n := p.N
+	ctx := Ctx{Simple: simple, Workers: workers, Params: p}
+
+	skPrf := sk[n : 2*n]
+	pk := sk[2*n : 4*n]
+
+	mHash := make([]byte, p.ForsMsgBytes)
+	root := make([]byte, n)
+	var tree uint64
+	var idxLeaf uint32
+	var wotsAddr [8]uint32
+	var treeAddr [8]uint32
+
+	copy(ctx.SkSeed[:], sk[:n])
+	copy(ctx.PubSeed[:], pk[:n])
+
+	// Zeroize the secret seed copy when signing completes.
+	defer wipeSkSeed(&ctx)
+
+	initializeHashFunction(&ctx)
+
+	setType(&wotsAddr, SPX_ADDR_TYPE_WOTS)
+	setType(&treeAddr, SPX_ADDR_TYPE_HASHTREE)
+
+	genMessageRandom(sig[:n], skPrf, optRand, pre, m, &ctx)
+
+	// Derive the message digest and tree/leaf index
+	hashMessage(mHash, &tree, &idxLeaf, sig[:n], pk, pre, m, &ctx)
+	sigOffset := n
+	setTreeAddr(&wotsAddr, tree)
+	setKeypairAddr(&wotsAddr, idxLeaf)
+
+	forsSign(sig[sigOffset:], root, mHash, &ctx, &wotsAddr)
+	sigOffset += p.ForsBytes
+
+	for i := uint32(0); i < uint32(p.D); i++ {
+		setLayerAddr(&treeAddr, i)
+		setTreeAddr(&treeAddr, tree)
+
+		copySubtreeAddr(&wotsAddr, &treeAddr)
+		setKeypairAddr(&wotsAddr, idxLeaf)
+		MerkleSign(sig[sigOffset:], root, &ctx, &wotsAddr, &treeAddr, idxLeaf)
+		sigOffset += p.WotsBytes + p.TreeHeight*n
+
+		idxLeaf = uint32(tree & ((1 << p.TreeHeight) - 1))
+		tree >>= p.TreeHeight
+	}
+}
+
+// Verify reports whether sig is a valid signature of pre || m under
+// pk (FIPS 205 Algorithm 20, slh_verify_internal).
+func Verify(p *Params, sig, pre, m, pk []byte, simple bool) bool {
+	if len(sig) != p.Bytes || len(pk) != p.PKBytes {
+		return false
+	}
+	n := p.N
+	pubRoot := pk[n:]
+	mHash := make([]byte, p.ForsMsgBytes)
+	wotsPK := make([]byte, p.WotsBytes)
+	root := make([]byte, n)
+	leaf := make([]byte, n)
+
+	var tree uint64
+	var idxLeaf uint32
+	var wotsAddr [8]uint32
+	var treeAddr [8]uint32
+	var wotsPKAddr [8]uint32
+	ctx := &Ctx{Simple: simple, Params: p}
+	copy(ctx.PubSeed[:n], pk[:n])
+
+	initializeHashFunction(ctx)
+
+	setType(&wotsAddr, SPX_ADDR_TYPE_WOTS)
+	setType(&treeAddr, SPX_ADDR_TYPE_HASHTREE)
+	setType(&wotsPKAddr, SPX_ADDR_TYPE_WOTSPK)
+
+	hashMessage(mHash, &tree, &idxLeaf, sig, pk, pre, m, ctx)
+	sig = sig[n:]
+
+	setTreeAddr(&wotsAddr, tree)
+	setKeypairAddr(&wotsAddr, idxLeaf)
+
+	forsPKFromSig(root, sig, mHash, ctx, &wotsAddr)
+	sig = sig[p.ForsBytes:]
+
+	for i := uint32(0); i < uint32(p.D); i++ {
+		setLayerAddr(&treeAddr, i)
+		setTreeAddr(&treeAddr, tree)
+
+		copySubtreeAddr(&wotsAddr, &treeAddr)
+		setKeypairAddr(&wotsAddr, idxLeaf)
+
+		copyKeypairAddr(&wotsPKAddr, &wotsAddr)
+
+		WotsPKFromSig(wotsPK, sig, root, ctx, &wotsAddr)
+		sig = sig[p.WotsBytes:]
+
+		tHash(leaf, wotsPK, uint(p.WotsLen), ctx, &wotsPKAddr)
+
+		computeRoot(root, leaf, idxLeaf, 0, sig, uint32(p.TreeHeight), ctx, &treeAddr)
+		sig = sig[p.TreeHeight*n:]
+
+		idxLeaf = uint32(tree & ((1 << p.TreeHeight) - 1))
+		tree = tree >> p.TreeHeight
+	}
+
+	return subtle.ConstantTimeCompare(root, pubRoot) == 1
+}
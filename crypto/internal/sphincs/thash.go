@@ -0,0 +1,15 @@
+package sphincs
+
+// tHash is the tweakable hash T_l of FIPS 205 §4.1 over inBlocks
+// n-byte blocks, in the variant ctx selects. The SHA2 sets only have
+// the simple variant.
+func tHash(out, in []byte, inBlocks uint, ctx *Ctx, addr *[8]uint32) {
+	switch {
+	case ctx.params().SHA2:
+		tHashSHA2(out, in, inBlocks, ctx, addr)
+	case ctx.Simple:
+		tHashSimple(out, in, inBlocks, ctx, addr)
+	default:
+		tHashRobust(out, in, inBlocks, ctx, addr)
+	}
+}
@@ -0,0 +1,36 @@
+package sphincs
+
+// tHashRobust is the round-3 SPHINCS+ robust tweakable hash:
+// SHAKE256(pub_seed || addr || (in XOR SHAKE256(pub_seed || addr))).
+func tHashRobust(out, in []byte, inBlocks uint, ctx *Ctx, addr *[8]uint32) {
+	n := ctx.params().N
+	bufLen := n + SPX_ADDR_BYTES + int(inBlocks)*n
+
+	// F and H inputs fit on the stack; only T_l needs the heap.
+	var block [SPX_ADDR_BYTES + 3*SPX_MAX_N]byte
+	var mask [2 * SPX_MAX_N]byte
+	buf, bitmask := block[:], mask[:]
+	if bufLen > len(block) {
+		buf = make([]byte, bufLen)
+		bitmask = make([]byte, int(inBlocks)*n)
+	}
+	buf = buf[:bufLen]
+	bitmask = bitmask[:int(inBlocks)*n]
+
+	// Copy pub_seed to buf
+	copy(buf[:n], ctx.PubSeed[:n])
+
+	// Copy addr as bytes into buf[n:n+SPX_ADDR_BYTES]
+	memcpy(buf[n:n+SPX_ADDR_BYTES], addr)
+
+	// Compute bitmask using SHAKE256(pub_seed || addr)
+	shake256(bitmask, buf[:n+SPX_ADDR_BYTES])
+
+	// XOR input with bitmask and place it into buf
+	for i := 0; i < len(bitmask); i++ {
+		buf[n+SPX_ADDR_BYTES+i] = in[i] ^ bitmask[i]
+	}
+
+	// Final SHAKE256 to get output
+	shake256(out[:n], buf)
+}
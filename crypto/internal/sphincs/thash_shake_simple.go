@@ -0,0 +1,22 @@
+package sphincs
+
+// tHashSimple computes SHAKE256(pub_seed || addr || in), the SHAKE
+// instantiation of T_l, F and H in FIPS 205 §11.1.
+func tHashSimple(out, in []byte, inBlocks uint, ctx *Ctx, addr *[8]uint32) {
+	n := ctx.params().N
+	bufLen := n + SPX_ADDR_BYTES + int(inBlocks)*n
+
+	// F and H inputs fit on the stack; only T_l needs the heap.
+	var block [SPX_ADDR_BYTES + 3*SPX_MAX_N]byte
+	buf := block[:]
+	if bufLen > len(block) {
+		buf = make([]byte, bufLen)
+	}
+	buf = buf[:bufLen]
+
+	copy(buf[:n], ctx.PubSeed[:n])
+	memcpy(buf[n:n+SPX_ADDR_BYTES], addr)
+	copy(buf[n+SPX_ADDR_BYTES:], in[:int(inBlocks)*n])
+
+	shake256(out[:n], buf)
+}
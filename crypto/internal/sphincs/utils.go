@@ -1,6 +1,4 @@
-package sphincsplus_256s
-
-import "github.com/theQRL/go-qrllib/crypto/sphincsplus_256s/params"
+package sphincs
 
 func UllToBytes(out []byte, outLen int, in uint64) {
 	for i := outLen - 1; i >= 0; i-- {
@@ -38,18 +36,19 @@ func Uint32SliceToBytes(in []uint32) []byte {
 
 func computeRoot(root, leaf []byte, leafIdx, idxOffset uint32,
 	authPath []byte, treeHeight uint32,
-	ctx *SPXCtx, addr *[8]uint32) {
+	ctx *Ctx, addr *[8]uint32) {
 
-	buffer := make([]byte, 2*params.SPX_N)
+	n := ctx.params().N
+	buffer := make([]byte, 2*n)
 
 	if leafIdx&1 == 1 {
-		copy(buffer[params.SPX_N:], leaf)
-		copy(buffer, authPath[:params.SPX_N])
+		copy(buffer[n:], leaf)
+		copy(buffer, authPath[:n])
 	} else {
 		copy(buffer, leaf)
-		copy(buffer[params.SPX_N:], authPath[:params.SPX_N])
+		copy(buffer[n:], authPath[:n])
 	}
-	authPath = authPath[params.SPX_N:]
+	authPath = authPath[n:]
 
 	for i := uint32(0); i < treeHeight-1; i++ {
 		leafIdx >>= 1
@@ -59,13 +58,13 @@ func computeRoot(root, leaf []byte, leafIdx, idxOffset uint32,
 		setTreeIndex(addr, leafIdx+idxOffset)
 
 		if leafIdx&1 == 1 {
-			tHash(buffer[params.SPX_N:], buffer, 2, ctx, addr)
-			copy(buffer, authPath[:params.SPX_N])
+			tHash(buffer[n:], buffer, 2, ctx, addr)
+			copy(buffer, authPath[:n])
 		} else {
 			tHash(buffer, buffer, 2, ctx, addr)
-			copy(buffer[params.SPX_N:], authPath[:params.SPX_N])
+			copy(buffer[n:], authPath[:n])
 		}
-		authPath = authPath[params.SPX_N:]
+		authPath = authPath[n:]
 	}
 
 	leafIdx >>= 1
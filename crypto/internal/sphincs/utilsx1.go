@@ -0,0 +1,153 @@
+package sphincs
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// leafInfo is implemented by the genLeaf state types. clone returns a
+// copy a second goroutine can generate leaves with.
+type leafInfo interface {
+	clone() any
+}
+
+// treeHashX1 computes the root of the tree of height treeHeight whose
+// leaves genLeaf generates, and the authentication path of leafIdx.
+// If ctx.Workers is above one the leaves are generated in parallel by
+// treeHashParallel; the result is identical.
+func treeHashX1(
+	root []byte,
+	authPath []byte,
+	ctx *Ctx,
+	leafIdx uint32,
+	idxOffset uint32,
+	treeHeight uint32,
+	genLeaf func(dest []byte, ctx *Ctx, idx uint32, info any),
+	treeAddr *[8]uint32,
+	info any,
+) {
+	if ctx.Workers > 1 && treeHeight > 0 {
+		if li, ok := info.(leafInfo); ok {
+			treeHashParallel(root, authPath, ctx, leafIdx, idxOffset, treeHeight, genLeaf, treeAddr, li)
+			return
+		}
+	}
+
+	n := uint32(ctx.params().N)
+	stack := make([]byte, treeHeight*n)
+	maxIdx := (uint32(1) << treeHeight) - 1
+
+	current := make([]byte, 2*n)
+
+	for idx := uint32(0); ; idx++ {
+
+		// Generate leaf
+		genLeaf(current[n:], ctx, idx+idxOffset, info)
+
+		internalIdxOffset := idxOffset
+		internalIdx := idx
+		internalLeaf := leafIdx
+		var h uint32
+
+		for {
+			if h == treeHeight {
+				copy(root, current[n:])
+				return
+			}
+
+			if (internalIdx ^ internalLeaf) == 1 {
+				copy(authPath[h*n:], current[n:])
+			}
+
+			if (internalIdx&1) == 0 && idx < maxIdx {
+				break
+			}
+
+			internalIdxOffset >>= 1
+			setTreeHeight(treeAddr, h+1)
+			setTreeIndex(treeAddr, internalIdx/2+internalIdxOffset)
+
+			left := stack[h*n : (h+1)*n]
+			copy(current[0:], left)
+
+			tHash(current[n:], current, 2, ctx, treeAddr)
+
+			h++
+			internalIdx >>= 1
+			internalLeaf >>= 1
+		}
+		copy(stack[h*n:], current[n:])
+	}
+}
+
+// treeHashParallel is treeHashX1 split into 2^t subtrees, where 2^t is
+// the smallest power of two covering ctx.Workers (at most the number
+// of leaves). At most ctx.Workers goroutines each run the serial
+// treehash on a subtree with their own copy of treeAddr and info; the
+// subtree roots are then combined over the top t levels. Every node
+// is hashed under the same address as in the serial path, so root and
+// authPath are byte-identical.
+func treeHashParallel(
+	root []byte,
+	authPath []byte,
+	ctx *Ctx,
+	leafIdx uint32,
+	idxOffset uint32,
+	treeHeight uint32,
+	genLeaf func(dest []byte, ctx *Ctx, idx uint32, info any),
+	treeAddr *[8]uint32,
+	info leafInfo,
+) {
+	n := uint32(ctx.params().N)
+	t := min(uint32(bits.Len(uint(ctx.Workers-1))), treeHeight)
+	subHeight := treeHeight - t
+	subtrees := uint32(1) << t
+
+	// Seed the SHA-2 states before the goroutines share them, and run
+	// the subtrees serially so they do not split again.
+	if ctx.params().SHA2 && ctx.sha256Seeded == nil {
+		seedStateSHA2(ctx)
+	}
+	subCtx := *ctx
+	subCtx.Workers = 1
+
+	nodes := make([]byte, subtrees*n)
+	jobs := make(chan uint32, subtrees)
+	for j := uint32(0); j < subtrees; j++ {
+		jobs <- j
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	for range min(uint32(ctx.Workers), subtrees) {
+		wg.Go(func() {
+			for j := range jobs {
+				addr := *treeAddr
+				// Only the subtree holding leafIdx writes to authPath;
+				// the others get an index no leaf matches.
+				subLeaf := ^uint32(0)
+				if leafIdx>>subHeight == j {
+					subLeaf = leafIdx & (uint32(1)<<subHeight - 1)
+				}
+				treeHashX1(nodes[j*n:(j+1)*n], authPath, &subCtx,
+					subLeaf, idxOffset+j<<subHeight, subHeight,
+					genLeaf, &addr, info.clone())
+			}
+		})
+	}
+	wg.Wait()
+
+	// Combine the subtree roots, level by level, up to the root.
+	for h := subHeight; h < treeHeight; h++ {
+		count := uint32(1) << (treeHeight - h)
+		if sibling := (leafIdx >> h) ^ 1; sibling < count {
+			copy(authPath[h*n:(h+1)*n], nodes[sibling*n:(sibling+1)*n])
+		}
+		setTreeHeight(treeAddr, h+1)
+		for i := uint32(0); i < count/2; i++ {
+			setTreeIndex(treeAddr, i+(idxOffset>>(h+1)))
+			tHash(nodes[i*n:(i+1)*n], nodes[2*i*n:(2*i+2)*n], 2, ctx, treeAddr)
+		}
+	}
+	copy(root, nodes[:n])
+}
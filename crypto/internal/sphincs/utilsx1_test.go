@@ -0,0 +1,43 @@
+package sphincs
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestTreeHashParallel checks that parallel signing is byte-identical
+// to serial signing. The "f" sets keep it fast; their small trees also
+// exercise worker counts above the number of leaves.
+func TestTreeHashParallel(t *testing.T) {
+	for _, p := range []*Params{SHA2_128f, SHAKE_192f, SHA2_256f} {
+		seed := make([]byte, p.SeedBytes)
+		for i := range seed {
+			seed[i] = byte(i)
+		}
+		optRand := bytes.Repeat([]byte{0x5a}, p.N)
+		m := []byte("parallel treehash")
+
+		pk := make([]byte, p.PKBytes)
+		sk := make([]byte, p.SKBytes)
+		SeedKeypair(p, pk, sk, seed, true, 0)
+		want := make([]byte, p.Bytes)
+		Sign(p, want, nil, m, sk, optRand, true, 0)
+
+		for _, workers := range []int{2, 3, 64} {
+			gotPK := make([]byte, p.PKBytes)
+			gotSK := make([]byte, p.SKBytes)
+			SeedKeypair(p, gotPK, gotSK, seed, true, workers)
+			if !bytes.Equal(gotPK, pk) {
+				t.Errorf("N=%d workers=%d: public key differs", p.N, workers)
+			}
+			got := make([]byte, p.Bytes)
+			Sign(p, got, nil, m, sk, optRand, true, workers)
+			if !bytes.Equal(got, want) {
+				t.Errorf("N=%d workers=%d: signature differs", p.N, workers)
+			}
+		}
+		if !Verify(p, want, nil, m, pk, true) {
+			t.Errorf("N=%d: signature does not verify", p.N)
+		}
+	}
+}
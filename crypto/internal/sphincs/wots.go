@@ -0,0 +1,61 @@
+package sphincs
+
+// genChain performs the hash chain operation as in WOTS+
+func GenChain(out, in []byte, start, steps uint, ctx *Ctx, addr *[8]uint32) {
+	copy(out, in[:ctx.params().N])
+	for i := start; i < start+steps && i < SPX_WOTS_W; i++ {
+		setHashAddr(addr, uint32(i))
+		tHash(out, out, 1, ctx, addr)
+	}
+}
+
+// baseW interprets a byte array as integers in base w
+func BaseW(output []uint8, outLen int, input []byte) {
+	in := 0
+	out := 0
+	var total byte
+	bits := 0
+
+	for consumed := 0; consumed < outLen; consumed++ {
+		if bits == 0 {
+			total = input[in]
+			in++
+			bits += 8
+		}
+		bits -= SPX_WOTS_LOGW
+		output[out] = uint8((total >> bits) & (SPX_WOTS_W - 1))
+		out++
+	}
+}
+
+// WotsChecksum computes the WOTS+ checksum over the len1 base_w
+// digits of msgBaseW and writes its len2 digits to csumBaseW
+func WotsChecksum(csumBaseW []uint8, msgBaseW []uint8, p *Params) {
+	csum := uint(0)
+	csumBytes := make([]byte, (p.WotsLen2*SPX_WOTS_LOGW+7)/8)
+
+	for i := 0; i < p.WotsLen1; i++ {
+		csum += SPX_WOTS_W - 1 - uint(msgBaseW[i])
+	}
+	csum = csum << ((8 - ((p.WotsLen2 * SPX_WOTS_LOGW) % 8)) % 8)
+	UllToBytes(csumBytes, len(csumBytes), uint64(csum))
+	BaseW(csumBaseW, p.WotsLen2, csumBytes)
+}
+
+// chainLengths derives WOTS+ chain lengths from a message
+func chainLengths(lengths []uint8, msg []byte, p *Params) {
+	BaseW(lengths[:p.WotsLen1], p.WotsLen1, msg)
+	WotsChecksum(lengths[p.WotsLen1:], lengths[:p.WotsLen1], p)
+}
+
+// WotsPKFromSig computes the WOTS public key from a signature and message
+func WotsPKFromSig(pk, sig, msg []byte, ctx *Ctx, addr *[8]uint32) {
+	p := ctx.params()
+	lengths := make([]uint8, p.WotsLen)
+	chainLengths(lengths, msg, p)
+
+	for i := 0; i < p.WotsLen; i++ {
+		setChainAddr(addr, uint32(i))
+		GenChain(pk[i*p.N:], sig[i*p.N:], uint(lengths[i]), uint(SPX_WOTS_W-1-lengths[i]), ctx, addr)
+	}
+}
@@ -1,8 +1,4 @@
-package sphincsplus_256s
-
-import (
-	"github.com/theQRL/go-qrllib/crypto/sphincsplus_256s/params"
-)
+package sphincs
 
 type LeafInfoX1 struct {
 	WotsSig      []byte    // Corresponds to unsigned char*
@@ -12,6 +8,11 @@ type LeafInfoX1 struct {
 	PkAddr       [8]uint32 // Fixed-size array of 8 uint32 values
 }
 
+func (info *LeafInfoX1) clone() any {
+	c := *info
+	return &c
+}
+
 func InitializeLeafInfoX1(info *LeafInfoX1, addr *[8]uint32, stepBuffer []uint8) {
 	info.WotsSig = nil
 	info.WotsSignLeaf = ^uint32(0) // Equivalent to ~0u in C
@@ -21,13 +22,13 @@ func InitializeLeafInfoX1(info *LeafInfoX1, addr *[8]uint32, stepBuffer []uint8)
 	copy(info.PkAddr[:], addr[:])
 }
 
-func WotsGenLeafX1(dest []byte, ctx *SPXCtx, leafIdx uint32, vInfo any) {
+func WotsGenLeafX1(dest []byte, ctx *Ctx, leafIdx uint32, vInfo any) {
 	info := vInfo.(*LeafInfoX1)
 	leafAddr := &info.LeafAddr
 	pkAddr := &info.PkAddr
 
-	var pkBuffer [params.SPX_WOTS_BYTES]byte
-	buffer := pkBuffer[:]
+	p := ctx.params()
+	buffer := make([]byte, p.WotsBytes)
 
 	var wotsKMask uint8
 	if leafIdx == info.WotsSignLeaf {
@@ -39,9 +40,9 @@ func WotsGenLeafX1(dest []byte, ctx *SPXCtx, leafIdx uint32, vInfo any) {
 	setKeypairAddr(leafAddr, leafIdx)
 	setKeypairAddr(pkAddr, leafIdx)
 
-	for i := 0; i < params.SPX_WOTS_LEN; i++ {
-		offset := i * params.SPX_N
-		buf := buffer[offset : offset+params.SPX_N]
+	for i := 0; i < p.WotsLen; i++ {
+		offset := i * p.N
+		buf := buffer[offset : offset+p.N]
 
 		wotsK := info.WotsSteps[i] | wotsKMask
 
@@ -58,7 +59,7 @@ func WotsGenLeafX1(dest []byte, ctx *SPXCtx, leafIdx uint32, vInfo any) {
 			if k == wotsK {
 				copy(info.WotsSig[offset:], buf)
 			}
-			if k == params.SPX_WOTS_W-1 {
+			if k == SPX_WOTS_W-1 {
 				break
 			}
 
@@ -67,5 +68,5 @@ func WotsGenLeafX1(dest []byte, ctx *SPXCtx, leafIdx uint32, vInfo any) {
 		}
 	}
 
-	tHash(dest, pkBuffer[:], params.SPX_WOTS_LEN, ctx, pkAddr)
+	tHash(dest, buffer, uint(p.WotsLen), ctx, pkAddr)
 }
@@ -0,0 +1,104 @@
+package ml_dsa_44
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+// One keyGen and one deterministic sigGen vector for ML-DSA-44, taken
+// from the NIST ACVP-Server files ML-DSA-keyGen-FIPS204 (vsId 42,
+// tgId 1, tcId 1) and ML-DSA-sigGen-FIPS204 (vsId 42, tgId 1,
+// tcId 4), so that the default test run checks published answers
+// without the "acvp" build tag. Expected outputs are stored as their
+// SHA-256. This sigGen vector set predates the external/internal
+// interface split and signs the message with ML-DSA.Sign_internal, so
+// no context prefix is applied.
+const (
+	acvpKeyGenSeed  = "93ef2e6ef1fb08999d142abe0295482370d3f43bdb254a78e2b0d5168eca065f"
+	acvpKeyGenPKSum = "6995b20ecd5cde41719035028a712ccf35b1adf53b913030423d9d6fa188d673"
+	acvpKeyGenSKSum = "16a35d4b59f932aeada987dc689b075add0df57b4815bb103be7443ee3c1c561"
+
+	acvpSigGenSigSum = "f4a70eab1e2ccd66a16d3563865d72e7f19def51c0b4487e7bcb2acddd9aa132"
+)
+
+const acvpSigGenSK = "abcec4a46e695fc6ebe64a191389f0d0ae180f911d5b824f4ed9111728ff4f9493ef3a7512dacf766d576898d33c4c8f4001b777ee5ec2e2dc1a8e3e181b4341" +
+	"8af45100b92a3835d02b9892e609b2aa8c6af7661ce0bc8362ae0da172a79e84ff4cdad8607e4924ff41db6ec28dccd09b8d1f5657ba17c848babc71bb242a50" +
+	"c44069a0200101914d130822c114288436508b0422d83004e23848032532ca902509a0009a848440004cc3304d04c0601cc84889340950b68918c81103410d63" +
+	"1870db444ae4224d8b96491b0224c2802904365290222d424626e0060613b12de1187203b18442228144027159186c22092c012751083160dc48061045089ac4" +
+	"44601609240224da006623945149c200182232ca462a0ba16d22b2215028700ac108d1828c00b24014083221b5810c4501240225e44291984622c90826532650" +
+	"dba8495b226294b049603050242382dc0429d2260254262cc3a868001546089168cc067184b86c518285da221110402494149044b8102220299ab40190824d52" +
+	"3050229668101072913411ccc625d2880d1b1951c3888c222509da20322003209106028c188000b02d92421111b92da10890a228700b4464a1b01153966800b9" +
+	"25423860884688630800c3c2910ba9511a1902c2b229532052d9906dd9c080192400249589e004914180600022440908012435624c48864bb46123c900588869" +
+	"c1001251248960b64844322513c5318b909118a8691a850449b800a3b02598a2052293455342701b3722590860911248939221d9024e20426014394109373183" +
+	"46851a836d192751c93686111749a0b4649bb285ccc049c0c4611a066203c304a032248cb861a34408cb4852484866244610a1068909280c01810c0285312321" +
+	"02e4442c0a988c19c54192187223b168d844862295405824408c322222c27110170a910668d438529b025212086458185208078dd388240c1092c2c60d549640" +
+	"031989093530988425d104524c146e62a06c5aa004cc126ac3284490268a43468021454460b249a390681a4745d2965188220e6032249aa6280a034a9cb6888b" +
+	"180cc1021012b70d0340480cb3444c0010011549e2b23094064504378441b2401aa3884aa885112229d2402550b0048c2621180872912851481842499020c912" +
+	"6d1a1289a4404c4a82710301228920449c48624006920813819ca670d0c808d938721a118a1bb589111150c3c6695386851a0204d2426d19116419a38152c861" +
+	"403a93cb8575520d2a3a7317cae1963e2705b7596c8e5dbf0daeaf8755df38a5df16297cfc84097b480d9729e4cc62170739a1a8a2057ea7fefb06275344adb6" +
+	"934e1c2da7e7f3e831fa35e6a4b8d8def435235ce957df5fa1d842962711443beade91070833c84264b45e2380b094202e079a0a7c6058a54e6f552f20276023" +
+	"0f6d95f5ea873709be4d7603ac010cfbadafe229cada1f2bc717f877856d8b930d0e215c4ba2212d66e21a2d1f09b1f1a9bc8c298cfd65b318fe91847279f204" +
+	"201203e0922e82bd298d9bf18b8fbcf72070f7c7c51d5480e60674341cf263fd179862f37d5665fe35ed0b2a86b7115c90093f5785309cd56c48bbc50570a0c2" +
+	"d066bd0eccd3c86e2a6c8b098afd9c0e235ceb920d58f0b913bfb633bfe21bb1668d9c45638f5ce9650caaa83db2d9b4b24b1f518b19226abda06239698a90f3" +
+	"0a50af69ae1d20fa00e3d88ff6f2c2466e45a39a1c946fb695888383cb6a59a7c8395082134a82dea3da7fe6d9e6f76c7e86a50ca04990c70dd5f9af062ed14c" +
+	"c661f453bf309da08056e19f2f7b34a15235230c15ec6859d7dcf0ed892ddff4e5096b36b406a10cb35aa81f72827c5982e3c5bfbb989e062cb4a7f0f76b008a" +
+	"b8ca5ec1cebdabcaa1e97809b44c5f49281415337978184811adb8131d2dfa2477d27532e92409493d46c597a6886250593fd58d305d0760ce8f772337d42b0f" +
+	"7dbfc483a941e8cdf32ce3e97309c3c404b6e4101678f123438853fc8a71c835d1ad0c7712460dbe83c1abc6bb0834c0271a6627e7dcb93efc25f78417bbc801" +
+	"488e5a051455343757f6bfaf923867c45ed5bf37304b11e012ee63a3b8d84dce7a15d5ab940d87fe1181eaba3c97bca702f5de4df74848a99d2b1f34fe2b0363" +
+	"3d6aac900a09c278556172df5d9cde361a8acd779465cbaf50de5c8f4ca0d15df3f74347c6ddf7d9b3e3e5e197bfe0aa170949fb42b78364a72b1b106156ec09" +
+	"a6e4ec72f3f814781d3ce7b7aa2b02e49caa25ac36daade0fd570a61589553a0cae582ba2894c82c0380a713b0b74924e006a6b341f21ae2aaec2016d2687f1a" +
+	"b696337f5a268b3b6f3730f507d6122dc92cab36107e864bb3edeea6fc1c5309a9b51582cdfcc1a899929ad7cdbecbcbf9d38121d58c3b3e6d9be001b117e4a7" +
+	"f762816174b761efcf291a1cc5da354029962eb8b0f6166a9c9eaf26921d1777e621c50c41614300605b1ee2a0cc41bc666ce90a15733c69a82451fd41f23efa" +
+	"a73a2482c4e3d476ccbbfb59b25140ffa0c1ceceabd3b036f2611c83aa834e6cfe03963941bdad4aefb11d01ec43293bcd22ecb8784ef5ce2a6042f200f1b9d6" +
+	"c595ef920c3cacd1c1c3cb61b6b46454a3b28a472ae0203038a5602cb9b001620c98bc09bc2db5621c8db085d88561058aea691afd199c6d4bb1511137ed2800" +
+	"722a81670b44fac51dfa6683675bf34c52f6eb7eba35d22c907a207ae5ce6c3c40ab0a26b88dae777e10b4fc33ab38c308ca2532032a7f306e9eca723b58119c" +
+	"3be662817a1eab6069fa05c3b0ed31060d5794121a83fbb152c7fc05bb753c9d29bc329e745d7c7d493372c26c0336aab37b884fb41741b344ee4d247d6b5d04" +
+	"9e5e322cc97ec6647ec7551824c6aa9cd249f49fe1652ecfd01c3e7eb026fdce7320d21ec9e4d460e50d6440c7364a15ae3c107ce8ee1e8a33ebc9d2b5585b8f" +
+	"69771f687eb6940c21f45750079d68d3dd6ce1cd7ca8d91a64d093a25a96628169b675cda9f14fada4af3d11b6524465b89dd4ef93a9a159f8df2a134fea3011" +
+	"10eb77e1eca51166d26cb036bf92f1655167bd32d12be04a91ff0b3c52c69de376856fab9b4e14524e5858717ecbd0865719bf1ddeddf8cc141396f9f0b4ed38" +
+	"b0caada08b64451ad8bd38557660cfef46ec0059b4aea6a7534a3db767c537e60210a1af84ec939413fd7ebbf14fe96d6ee82a0c632edc63715c0c6654aa4fe2" +
+	"98f43ed5b47af7350c32c8d7f696f9a96b81e9832f486a66d9b304a6531139561fe5a967061bdffa4793ea986c3a2693c21dad4428fe98f168eb928fcbeb8fe0" +
+	"a611049c1f430ccd80f9181d276afedaea40261fe1b038f5677ad507eb48b9768964baec928197ac26acb1a89cddaf51c4336b6f49985c13926e76aa7d69f5f0" +
+	"844f23e7b2b977565587718903b39173f7f18af84264370bd61020f2a76ed281687419e334443159bf6a21533f41e030654f6876aabb21025b6d2312304ff8be" +
+	"ffb7ad2e225bf79f1b6f8c33aa90d9dc18b369846fa06548e72efb2ec4fd6bd833f1872df9659f62af040345cb5b8399a4836f7f5a9f920f0484009c1f6871d2"
+
+const acvpSigGenMessage = "22aa98c685e1552b525b4302c943037f668279c224b6270dcaf2b06c4f4ab1254c48de253829fe6dffa9cb6bb294f054711bae3fbacfb900cfd1f0844e55d51e" +
+	"c6f697b998759b14c13392ddb6f7deba77ffc22468781ce402"
+
+func TestACVPKeyGenVector(t *testing.T) {
+	var seed [SEED_BYTES]uint8
+	if _, err := hex.Decode(seed[:], []byte(acvpKeyGenSeed)); err != nil {
+		t.Fatal(err)
+	}
+	var pk [CRYPTO_PUBLIC_KEY_BYTES]uint8
+	var sk [CRYPTO_SECRET_KEY_BYTES]uint8
+	if _, err := cryptoSignKeypair(&seed, &pk, &sk); err != nil {
+		t.Fatal(err)
+	}
+	if sum := sha256.Sum256(pk[:]); hex.EncodeToString(sum[:]) != acvpKeyGenPKSum {
+		t.Errorf("SHA-256(pk) = %x, want %s", sum, acvpKeyGenPKSum)
+	}
+	if sum := sha256.Sum256(sk[:]); hex.EncodeToString(sum[:]) != acvpKeyGenSKSum {
+		t.Errorf("SHA-256(sk) = %x, want %s", sum, acvpKeyGenSKSum)
+	}
+}
+
+func TestACVPSigGenVector(t *testing.T) {
+	var sk [CRYPTO_SECRET_KEY_BYTES]uint8
+	if _, err := hex.Decode(sk[:], []byte(acvpSigGenSK)); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := hex.DecodeString(acvpSigGenMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rnd [RND_BYTES]uint8 // deterministic variant
+	sig := make([]uint8, CRYPTO_BYTES)
+	if err := cryptoSignSignatureInternal(sig, msg, nil, rnd, &sk); err != nil {
+		t.Fatal(err)
+	}
+	if sum := sha256.Sum256(sig); hex.EncodeToString(sum[:]) != acvpSigGenSigSum {
+		t.Errorf("SHA-256(signature) = %x, want %s", sum, acvpSigGenSigSum)
+	}
+}
@@ -0,0 +1,48 @@
+package ml_dsa_44
+
+import "testing"
+
+func BenchmarkKeyGeneration(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := New(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	d, err := New()
+	if err != nil {
+		b.Fatal(err)
+	}
+	msg := []byte("benchmark message for signing")
+	ctx := []byte("ZOND")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := d.Sign(ctx, msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	d, err := New()
+	if err != nil {
+		b.Fatal(err)
+	}
+	msg := []byte("benchmark message for signing")
+	ctx := []byte("ZOND")
+	sig, err := d.Sign(ctx, msg)
+	if err != nil {
+		b.Fatal(err)
+	}
+	pk := d.GetPK()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !Verify(ctx, msg, sig, &pk) {
+			b.Fatal("verification failed")
+		}
+	}
+}
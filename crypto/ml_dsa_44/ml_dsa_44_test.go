@@ -0,0 +1,254 @@
+package ml_dsa_44
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+func TestSizes(t *testing.T) {
+	// FIPS 204 Table 2.
+	if CRYPTO_PUBLIC_KEY_BYTES != 1312 {
+		t.Errorf("CRYPTO_PUBLIC_KEY_BYTES = %d, want 1312", CRYPTO_PUBLIC_KEY_BYTES)
+	}
+	if CRYPTO_SECRET_KEY_BYTES != 2560 {
+		t.Errorf("CRYPTO_SECRET_KEY_BYTES = %d, want 2560", CRYPTO_SECRET_KEY_BYTES)
+	}
+	if CRYPTO_BYTES != 2420 {
+		t.Errorf("CRYPTO_BYTES = %d, want 2420", CRYPTO_BYTES)
+	}
+}
+
+// TestKATSignDeterministic pins the public key and a deterministic
+// (rnd = 0) signature for a fixed seed through the public API. The
+// expected values match Go 1.27's crypto/mldsa (NewPrivateKey with the
+// same seed, then SignDeterministic with Options{Context: "ZOND"});
+// published ACVP answers are checked in acvp_vector_test.go.
+func TestKATSignDeterministic(t *testing.T) {
+	var seed [SEED_BYTES]uint8
+	for i := range seed {
+		seed[i] = uint8(i)
+	}
+	d, err := NewMLDSA44FromSeed(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+
+	pk := d.GetPK()
+	pkSum := sha256.Sum256(pk[:])
+	if got := hex.EncodeToString(pkSum[:]); got != "9f107644c1084526af3bc8098680b05499a2325a644e388fb4f970e058d19d46" {
+		t.Errorf("SHA-256(pk) = %s", got)
+	}
+
+	sig, err := d.SignDeterministic([]byte("ZOND"), []byte("ML-DSA known answer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigSum := sha256.Sum256(sig[:])
+	if got := hex.EncodeToString(sigSum[:]); got != "5887e83c34695c643aab514752479bfef51bd9403d5c48354f8616532e36d8b4" {
+		t.Errorf("SHA-256(signature) = %s", got)
+	}
+	if !Verify([]byte("ZOND"), []byte("ML-DSA known answer"), sig, &pk) {
+		t.Error("Verify rejected the KAT signature")
+	}
+}
+
+func TestNewMLDSA44FromHexSeed(t *testing.T) {
+	hexSeed := "c3317c917c365869a32ee99b46ea1587c5883ad4f38af9367a1bf676dddfb62f"
+	d1, err := NewMLDSA44FromHexSeed(hexSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d2, err := NewMLDSA44FromHexSeed("0x" + hexSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d1.GetPK() != d2.GetPK() || d1.GetSK() != d2.GetSK() {
+		t.Error("0x-prefixed hex seed produced a different keypair")
+	}
+	if d1.GetHexSeed() != "0x"+hexSeed {
+		t.Errorf("GetHexSeed = %s", d1.GetHexSeed())
+	}
+	if _, err := NewMLDSA44FromHexSeed("abcd"); !errors.Is(err, cryptoerrors.ErrInvalidSeed) {
+		t.Errorf("short hex seed error = %v, want ErrInvalidSeed", err)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+	ctx := []byte("off-chain")
+	msg := []byte("message")
+
+	sig1, err := d.Sign(ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig2, err := d.Sign(ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig1 == sig2 {
+		t.Error("Sign should be hedged; got identical signatures")
+	}
+	for _, sig := range [][CRYPTO_BYTES]uint8{sig1, sig2} {
+		if !Verify(ctx, msg, sig, &pk) {
+			t.Error("Verify rejected a valid signature")
+		}
+	}
+
+	if Verify([]byte("other"), msg, sig1, &pk) {
+		t.Error("Verify accepted the wrong context")
+	}
+	if Verify(ctx, []byte("other"), sig1, &pk) {
+		t.Error("Verify accepted the wrong message")
+	}
+	tampered := sig1
+	tampered[0] ^= 1
+	if Verify(ctx, msg, tampered, &pk) {
+		t.Error("Verify accepted a tampered signature")
+	}
+	if Verify(ctx, msg, sig1, nil) {
+		t.Error("Verify returned true for nil pk")
+	}
+	if _, err := d.Sign(make([]byte, 256), msg); !errors.Is(err, cryptoerrors.ErrInvalidContext) {
+		t.Errorf("Sign(256-byte ctx) error = %v, want ErrInvalidContext", err)
+	}
+}
+
+func TestSignDeterministicIsDeterministic(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	sig1, err := d.SignDeterministic(nil, []byte("msg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig2, err := d.SignDeterministic(nil, []byte("msg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig1 != sig2 {
+		t.Error("SignDeterministic produced different signatures")
+	}
+}
+
+func TestSignAttachedOpen(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+	ctx := []byte("randomContext")
+	msg := []byte{0, 1, 2, 4, 6, 9, 1}
+
+	sm, err := d.SignAttached(ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sm) != CRYPTO_BYTES+len(msg) {
+		t.Fatalf("len(SignAttached) = %d, want %d", len(sm), CRYPTO_BYTES+len(msg))
+	}
+	if !bytes.Equal(ExtractMessage(sm), msg) {
+		t.Error("ExtractMessage mismatch")
+	}
+	if !bytes.Equal(ExtractSignature(sm), sm[:CRYPTO_BYTES]) {
+		t.Error("ExtractSignature mismatch")
+	}
+
+	opened, err := Open(ctx, sm, &pk)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !bytes.Equal(opened, msg) {
+		t.Error("Open returned the wrong message")
+	}
+
+	if _, err := Open(ctx, sm, nil); !errors.Is(err, cryptoerrors.ErrPublicKeyNil) {
+		t.Errorf("Open(nil pk) error = %v, want ErrPublicKeyNil", err)
+	}
+	if _, err := Open(ctx, sm[:CRYPTO_BYTES-1], &pk); !errors.Is(err, cryptoerrors.ErrInvalidSignatureSize) {
+		t.Errorf("Open(short) error = %v, want ErrInvalidSignatureSize", err)
+	}
+	if _, err := Open([]byte("other"), sm, &pk); !errors.Is(err, cryptoerrors.ErrInvalidSignature) {
+		t.Errorf("Open(wrong ctx) error = %v, want ErrInvalidSignature", err)
+	}
+	if ExtractMessage(sm[:CRYPTO_BYTES-1]) != nil || ExtractSignature(sm[:CRYPTO_BYTES-1]) != nil {
+		t.Error("Extract* should return nil for short input")
+	}
+}
+
+func TestVerifyDetailed(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk := d.GetPK()
+	ctx := []uint8("verify-detailed")
+	msg := []uint8("message")
+	sig, err := d.Sign(ctx, msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	// A hint count above OMEGA is not a canonical hint encoding.
+	badHint := sig
+	badHint[CRYPTO_BYTES-1] = OMEGA + 1
+	// A packed z coefficient of 0 unpacks to GAMMA1, outside the bound.
+	badNorm := sig
+	badNorm[C_TILDE_BYTES] = 0
+	badNorm[C_TILDE_BYTES+1] = 0
+	badNorm[C_TILDE_BYTES+2] &^= 0x0f
+
+	tests := []struct {
+		name string
+		ctx  []uint8
+		msg  []uint8
+		sig  [CRYPTO_BYTES]uint8
+		pk   *[CRYPTO_PUBLIC_KEY_BYTES]uint8
+		want error
+	}{
+		{"valid", ctx, msg, sig, &pk, nil},
+		{"nil_pk", ctx, msg, sig, nil, cryptoerrors.ErrPublicKeyNil},
+		{"long_ctx", make([]uint8, 256), msg, sig, &pk, cryptoerrors.ErrInvalidContext},
+		{"non_canonical_hint", ctx, msg, badHint, &pk, cryptoerrors.ErrNonCanonicalHint},
+		{"norm_exceeded", ctx, msg, badNorm, &pk, cryptoerrors.ErrSignatureNormExceeded},
+		{"wrong_message", ctx, []uint8("other"), sig, &pk, cryptoerrors.ErrInvalidSignature},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := VerifyDetailed(tc.ctx, tc.msg, tc.sig, tc.pk)
+			if !errors.Is(err, tc.want) || (tc.want == nil) != (err == nil) {
+				t.Errorf("VerifyDetailed error = %v, want %v", err, tc.want)
+			}
+			if got := Verify(tc.ctx, tc.msg, tc.sig, tc.pk); got != (tc.want == nil) {
+				t.Errorf("Verify = %v, want %v", got, tc.want == nil)
+			}
+		})
+	}
+}
+
+func TestZeroize(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Zeroize()
+	var zeroSK [CRYPTO_SECRET_KEY_BYTES]uint8
+	var zeroSeed [SEED_BYTES]uint8
+	if d.GetSK() != zeroSK || d.GetSeed() != zeroSeed {
+		t.Error("Zeroize left secret material behind")
+	}
+}
@@ -0,0 +1,11 @@
+package ml_dsa_44
+
+import "github.com/theQRL/go-qrllib/crypto/internal/lattice"
+
+func ntt(a *[N]int32) {
+	lattice.NTT(a)
+}
+
+func invNTTToMont(a *[N]int32) {
+	lattice.InvNTTToMont(a)
+}
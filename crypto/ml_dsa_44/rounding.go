@@ -0,0 +1,22 @@
+package ml_dsa_44
+
+import "github.com/theQRL/go-qrllib/crypto/internal/lattice"
+
+// ML-DSA-44 uses GAMMA2 = (Q-1)/88, so the rounding helpers route to
+// the lattice package's GAMMA2_88 variants.
+
+func power2Round(a0 *int32, a int32) int32 {
+	return lattice.Power2Round(a0, a)
+}
+
+func decompose(a0 *int32, a int32) int32 {
+	return lattice.Decompose88(a0, a)
+}
+
+func makeHint(a0, a1 int32) uint {
+	return lattice.MakeHint88(a0, a1)
+}
+
+func useHint(a int32, hint int) int32 {
+	return lattice.UseHint88(a, hint)
+}
@@ -0,0 +1,107 @@
+package ml_dsa_44
+
+import (
+	"crypto"
+	"crypto/subtle"
+	"errors"
+	"io"
+)
+
+var errUnsupportedSignerOpts = errors.New("ml_dsa_44: opts.HashFunc() must be 0; pre-hashed signing is not supported")
+
+// SignerOpts carries the FIPS 204 context for use with crypto.Signer.
+type SignerOpts struct {
+	Context []byte
+}
+
+func (o *SignerOpts) HashFunc() crypto.Hash { return 0 }
+
+// CryptoPublicKey wraps the ML-DSA-44 public key for crypto.PublicKey compatibility.
+type CryptoPublicKey struct {
+	key [CRYPTO_PUBLIC_KEY_BYTES]uint8
+}
+
+func (pk *CryptoPublicKey) Equal(x crypto.PublicKey) bool {
+	other, ok := x.(*CryptoPublicKey)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare(pk.key[:], other.key[:]) == 1
+}
+
+// Bytes returns a copy of the raw public key bytes.
+func (pk *CryptoPublicKey) Bytes() [CRYPTO_PUBLIC_KEY_BYTES]uint8 {
+	return pk.key
+}
+
+// CryptoSigner wraps an MLDSA44 instance to implement crypto.Signer and
+// crypto.MessageSigner.
+type CryptoSigner struct {
+	d *MLDSA44
+}
+
+// NewCryptoSigner returns a crypto.MessageSigner backed by the given MLDSA44 instance.
+func NewCryptoSigner(d *MLDSA44) *CryptoSigner {
+	return &CryptoSigner{d: d}
+}
+
+func (s *CryptoSigner) Public() crypto.PublicKey {
+	pk := s.d.GetPK()
+	return &CryptoPublicKey{key: pk}
+}
+
+// Sign implements crypto.Signer. ML-DSA signs whole messages, so
+// digest is the message itself, not a hash of it; Sign behaves exactly
+// like [CryptoSigner.SignMessage], which generic code should prefer via
+// [crypto.SignMessage].
+func (s *CryptoSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.SignMessage(rand, digest, opts)
+}
+
+// SignMessage implements crypto.MessageSigner, signing msg in full. The
+// opts parameter may be *SignerOpts to provide the FIPS 204 context;
+// nil or any other SignerOpts whose HashFunc is 0 (such as the
+// crypto.Hash(0) passed by crypto.SignMessage) selects the empty
+// context. A non-zero HashFunc returns an error.
+//
+// The rand parameter, when non-nil, is honoured as the source of the
+// per-signature RND_BYTES (FIPS 204 §3.5 hedged signing); when nil,
+// crypto/rand is used. Either way signing is hedged (TOB-QRLLIB-6).
+func (s *CryptoSigner) SignMessage(rand io.Reader, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	var ctx []byte
+	switch o := opts.(type) {
+	case *SignerOpts:
+		if o != nil {
+			ctx = o.Context
+		}
+	case nil:
+		// empty context
+	default:
+		if o.HashFunc() != 0 {
+			return nil, errUnsupportedSignerOpts
+		}
+		// empty context
+	}
+
+	// nil rand → standard hedged path (crypto/rand under the hood).
+	if rand == nil {
+		sig, err := s.d.Sign(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+		return sig[:], nil
+	}
+
+	// Non-nil rand → caller-supplied entropy. Read RND_BYTES from it
+	// and route through cryptoSignSignatureWithRnd so the caller's
+	// io.Reader is what feeds the per-signature randomness.
+	var rnd [RND_BYTES]uint8
+	if _, err := io.ReadFull(rand, rnd[:]); err != nil {
+		return nil, err
+	}
+	var sigBuf [CRYPTO_BYTES]uint8
+	if err := s.d.signSignatureWithRnd(sigBuf[:], msg, ctx, rnd); err != nil {
+		return nil, err
+	}
+	return sigBuf[:], nil
+}
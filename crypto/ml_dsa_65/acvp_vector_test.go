@@ -0,0 +1,129 @@
+package ml_dsa_65
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+// One keyGen and one deterministic sigGen vector for ML-DSA-65, taken
+// from the NIST ACVP-Server files ML-DSA-keyGen-FIPS204 (vsId 42,
+// tgId 2, tcId 26) and ML-DSA-sigGen-FIPS204 (vsId 42, tgId 3,
+// tcId 30), so that the default test run checks published answers
+// without the "acvp" build tag. Expected outputs are stored as their
+// SHA-256. This sigGen vector set predates the external/internal
+// interface split and signs the message with ML-DSA.Sign_internal, so
+// no context prefix is applied.
+const (
+	acvpKeyGenSeed  = "70cefb9aed5b68e018b079da8284b9d5cad5499ed9c265ff73588005d85c225c"
+	acvpKeyGenPKSum = "646b26b8d09dbc9e865b6a006c693a3127b065e62fab5fbe8b159c416462feb6"
+	acvpKeyGenSKSum = "3894dc56a4553781d68ff0d1b6fcf1b4876085ea602fb6f8738def50ed7d4c75"
+
+	acvpSigGenSigSum = "4bc8d83f15e8526c22af5068606895865c7032a81eea6dc3a97df548a7df8a8f"
+)
+
+const acvpSigGenSK = "7d9baca9c8d5e302bf5ce4c85b7685388cecd82d72ec259976f4cb65c360d74b7aa8b23ca3c9d786fa3d949a9dfc1600c821b808f0beb38f815d7688928159d7" +
+	"d9e9c1f80fbeaeb0718b4a27e5ae16af325f2362539164d1b0f282131684089e9c61442c8c6ef03818e4fab383498a91f1a6ba3d1138fa2e6863e7bfc37c768b" +
+	"80881102106834535885567854243344502211757522267523162088466534441253602763327188226627581385652565414151784380762722456634142628" +
+	"23145873880865382432876525678754466587180653182224817171564461785225330632367605347356526817658683221336505284642007331144752043" +
+	"04708867884365028568687647543047586857730641261240866022706146700662641605033036288088004283711243311586237730241500402584663542" +
+	"42631322182837265206682145238541377758026231302173751556168744863422724538416543335044260014140700080223635662746130854042515087" +
+	"00268510286128541048652532663735318425328222801507847504111721284487814182503387315075183180578217035146506807167120747863440148" +
+	"42553263506331854633511805607375816771642731483376206505063187288176255813114745871434742006311757673068367004546731652014464405" +
+	"52351405570370765020236227650155735776370575127458246010022264663462424423213765310260018843165528661703717074062347113507035301" +
+	"54537386510517150862485488734641510123018205333430484418024531120555687826721022168170767113056311122784034616344534675638174051" +
+	"74625677442110028602882046132565410145225616056507381115106524861130032362847058281875201654207101412652107723233573401651164452" +
+	"10432428531411538578270768734622774714255868725625185866516828826288225225382481362346231732171642643803148671271817471106245053" +
+	"75518304510241724270134083138535868676103175248815581128517478430144678381460345274640088110430151534032341122060618738284811353" +
+	"12732386821421641766847731604286408117708360210088167222627355421774250240116567347422202081776543276518382377311156762050784458" +
+	"37425763855715746545685101718301140056042410640767435220144420781045324711834710612775235483708256718366036056834856355047187872" +
+	"86625172661285410112734105240306431131524442812878436515720541478533784865072814477233213737784102623171278478707270820744053464" +
+	"23520157384005625138054855832350124057555347376478876176870566132365288234136876220358301613276664328267030084624034202467880672" +
+	"76833355848330825352627051271045102466531806664112101702762667023185287441052104507353315241367611074381635451018347836601447556" +
+	"27350160460270601354526427542756170213376422272152083832655553877167880875476461265225538467276306883342464276047545834813221416" +
+	"60416712611843234426172266446735801251233806085020072022137144844388250361526164488822288532385034366513467374262261374541165218" +
+	"06224384547167800842813436447366152212332813016632406320458758564571656076278257144143508780111883350040472187651326241722885506" +
+	"06884361438440771783782323333457760358313860715775360718160324884402152551861038253600243662870766083048213550832752323452141305" +
+	"81376402673650804638803752677217146446725168183856185355428424386367534174126167384668763535222486667001206233511787217204644828" +
+	"20874272415685137628481655455861246778640761403677012083646508583110082074453101204426410178432288412156182720465184485112240375" +
+	"bf8365fee3a6c811138e1cf73bf1d0ea525f2bdd4988cc157a976b949022ffbca93b2664028f9d0ade28005c84ca7cc39ebabea9c92075734d9f83990353af6d" +
+	"72693cbe38659c9305c804b8a75c8bb1c5ffb6c5a681155a2a65f85c8dd4a14c6b61c45d6d2be9411c00a29dafdcf3d9f1ec1e2ef75214043ac120dca4f22aa7" +
+	"354ac2b6443fed11a086b22630c1b45b2a372ff2f1d0660c41087f5cf818fec1ff96f18cd710fa09f66589010708cf8d299eeac86e1e989c2ddd60a2f68dedb6" +
+	"2b0e6421d4df285aa2a32f442e35688826e0a9ef9fc32c32f09a28419f37de7747df9c86b5a54a558d374fc1c083a46321e7237bf37491214f54973ce3ba8d72" +
+	"29370dfb27dc68f33ee68bbee33ddb63b3ac4e4346d6d96a6a300f49adaef370c98ab1a44a4d5e98c1b19ed433ac13c5a099894ca3481a36820e5548a04c632b" +
+	"0995ba976a359da6f029de8443d7ab197037aaacfcf2f61d33b604dd3bb09b26523b103b0aad0d2163f09294a48042e8d23599df7095e009f393c4cf5f295c3f" +
+	"5e49f2aed1f29926653c48fcd8d93dc39422405c38ea9e28ff5abba4fb699d158db7f70e30716dbc1627a27e6a93f594f6241869332d4f3cf41731b84c17df19" +
+	"5b7c7ca7e4563ac9ec1cdab2d1e52e5c6d38b4c63c3901bd860c038a34284e71834247d1d3854aef49e2bf6f67ffe4256869a41bd44b0fa2e0cfe0345eb3886b" +
+	"c4356aee11a26838d4f2279ac13d6caa53f110acbb1f6764bbe47bf3c4e1f0b9b99b00b6b9e0c50053fb32174be392321a3e0a69bdc874d3f3a42aeb79c4bab2" +
+	"753713a5dece419e2bdbcfcac6cb741425a64cc7d3180e097b5285e0b7bcbf9b7d97de81df3227c2ddaa369c87bbf3f876ad8b81e9208c2eae363c3a0434bad9" +
+	"2f49ee7b6b805bca9cd6c0a2c3e21e5d5dd9ac4f427f62efc96c13f6ee1ec761cab24e61dd6f509d08e8e5ad4edaeaef0679340677882bcdfcb6928ae7c2052d" +
+	"e7116cdbbac3551ce0dad8ae909d795393cb8a6292c7fb2c16ee62b8b5f05cfd5553a7303919d563b7612e65ee69c97ae80d3e407a448c88d508191b98821f03" +
+	"f3823da8793054fb0d051d9bd12d090e2cfcca4db20c6ad515e5e8658233c095781d5bb1d40bc47d3bde5a6379cf03511b2a3c06a2c315730a0349f83512778a" +
+	"5123f7ca9fafc44f73b1259090f8746f1ae868df6d158636eed34b8ff85ce3c07f7378ed5a3e7577a6fae7cd3ea0de0ee3576c48008e2e4f8c417f3606bb1e9d" +
+	"f468e40fe74a3c69a672b758f1c3ac570e44e9a08936788b44a769b28dcabbd3266a82cda0cb6d2014b73beacb33801007c40bf92b647fae6631648f7e91da02" +
+	"2993a9198153b7b3c31b487c863a639ed7ad4b235ff3d44e3ad573036902e3c41a53d34704237304d2e4d72a3f41db6befce9da8945cf5a9aa2217a2b36dd295" +
+	"6c3f4c811b3d2a95e1fa88ff2ed95a0950cdb1a14dfc89ed6e996c854c1ff056a73d7bfd1e8f08eba003e3e834c71598e42430b15bc0f1fff6791d639bd40923" +
+	"a28fc4b72636aff01ef4f01c4894bc78a3ac69bc595760bee8a227dc88ad1e633401b4ea43bfb070aba4dde38fe0ee6bc4958f1e6481bf478a9aea4ec2ae5699" +
+	"3cfc33975c06b571450da0026de58b99b6d44f2afede582920436230a6e81bf051bb54a41ae6e6c34bfaf1c7f03a44b85acdde1b44deaab8299905fb5ad961ff" +
+	"224c717e96dfedec50d5cea646d2ffc5f7b6017ec540924d1c9fdb8013f2957f271c85027985fd4184f22e790f91cc7c44c1890ae83932483af25fbc0a9c740b" +
+	"ae467e2ca24aa7e86c41a9250260b3a4f805d6421b8772691f172fb63939606d50d03f7c4aa09ebcc2e5289bf4b9ec64a1307869cb4ebab5c0aba222c86e21d6" +
+	"ef32b82b7409b10c67f7a7d54c850c277728c15230c56e3b0aa375440ac0b55deffaede52c2f84ba5fd49a5aada5af6c9eadc0e413006f4149081ea8b06a3590" +
+	"e55d55051aee474e416c7c76f0c41c372b593a5a22cf9d520c59f98942b2870408fdbfa063fd14b6615ebda85acd3020382e696b84b4e6e6e28b1f6e279ea4c5" +
+	"ac3444e670f5b1f01adc3331b4e13b1387a082251fe63e391e3af0ffc8cd5a77d2b77ddc75066e04f9ba89b65e62f3f74b5eb53068449a3a59a3cb8351b5f801" +
+	"2fdc5b3f7acb10af933dd9fb5c094bf085fef0b27d9b01afdc95b3b96633c5082369f03befa13d53ffe31836d1e02adeffec0caa084050cd5cf34e1362d4bc42" +
+	"2418536a4f5048639a0f168743459733cc11ee616d810dc92b266bcb0b78f32269ba68f264b669302e8fc2e35f998e2b0438efa2fca0b14f0b337701a4fd5057" +
+	"ae3f1105526eb726381c54d605d21e9a1887da4f8aa09c3d4cd0e617bc072f4050e125763812d4621f1ad8001aa027546e472f8317e9f37c27bfd4380d4a5a8c" +
+	"77fb426c3f13e462e06b6d7c59c8dae7ec747e4c08822569421c70eb62db7c3f1ee9caf7ce384ed0b58a122a2c0c669dc843cd21f63e01ceed8cf7c63918cc7c" +
+	"d04b80556561582a4ca24732956700d5ad7e65e1f7d770ec84c6a8b7f7737f4b597e305199c8de6f73f3ceb730cbf6e87e53114d931024dcc1f3efc56bac8bcb" +
+	"374c92e2d687e735da892726b2e7b30135d1ccdffa0f81b8986a9e646b9d875487d960fe87360c625557cd5ed7fd45d18cfda97b410ca62baeee3c90c9ec0252" +
+	"5d74606ec7ec1169b250e8ff4b94de08aa95150e52715c18dd6a03f2a399956f3308b8ce72a96d2f41e2cd901d4d507f336354ac24da68cf8f83b69f12e54bac" +
+	"40f9a7bcb1d1165e456dde5c60f5bda4f8c435550b492b14642a329c90732e6618cc73b5a32c71cf306bd89b074c8174912556c3fb05dbd4216b246239b096cb" +
+	"8928162e3544fc40eaf652cc91004686fde0a3fdfd80bf2770dd4fb79a7029b0da6b01c8db069414b26a05c180ccdffe049401a348990e0247b542c7cfcde012" +
+	"f3d6840f406f7be782dbcf4f6080bafc7bbb201ef6087d38c211727c15eca30f003ad52cd540d8bc4db81375f44e0e092a06f9e9891f6aaa178fc39b3e286828" +
+	"4330b3d2508b486836d9f1f5af0464ae1dd2d696ac0efaa4d9def2e5cd28a5e1d42e813d3f16db85bf565edeae869a88d082ef43a33f936533d151e0bd064571" +
+	"35fa0450aac65438c729aac8598399fe8ac0042e3220e936f88295e45baf653f405b12b2fa9d386bbce3b46137253e7c64bce7e998dcb4916390ff7e2f074fb1" +
+	"754b60c3418dc6c5728e2fa364acb2c8bf63525849f2cec268dab24ca73c429ec350da2900583d9c8b2bd0aa7f5458b00de9e44209946f70bef1c7eca26cf08d" +
+	"ef56cdbd6c1816d52a373f822321468770a6c5d8be130e6c752d63a72688b786168480af505d914c13eae57905ae47e10cfbd62c2097a7482432cfe5500b804e" +
+	"3715411aab787fdcee2f644f38ee9a0784e88dec94fb9599a68a0105577162a6fd575edbfab3d6da5d2ca2f086a8d55a325f8ebda84e79d96d794097e62413ca"
+
+const acvpSigGenMessage = "5870bb288aa6130708f7bbad9fbdd6d41e249d620495acfe90c61737b57dba890213d4741718545ccd8b3fffc2db33c39ad631d5b5cc902de4d340df03e09248" +
+	"f67e89d28071aa50fa532e94c391d2d1a61b1847c6b1088be555e5c2694eb0fc1f029095acd9deb21ef886be577682ca96aa2eb3dcb24b871336ac5f23c84880" +
+	"11860b455b687bd4cef5fa11381bc292b4098bb2cfc1822b48ecfd28aeada71809bfda190836d3215cfe755fdd9374115e5a0ccae15240eba0147c2f89d8d244" +
+	"54d7a5ac2d20ecc0d46c040fad233fc51c870080f1fcefae6c073af5f7a78d610e23831d5990985fdbfdc6d101acf3db0a74d71739e0"
+
+func TestACVPKeyGenVector(t *testing.T) {
+	var seed [SEED_BYTES]uint8
+	if _, err := hex.Decode(seed[:], []byte(acvpKeyGenSeed)); err != nil {
+		t.Fatal(err)
+	}
+	var pk [CRYPTO_PUBLIC_KEY_BYTES]uint8
+	var sk [CRYPTO_SECRET_KEY_BYTES]uint8
+	if _, err := cryptoSignKeypair(&seed, &pk, &sk); err != nil {
+		t.Fatal(err)
+	}
+	if sum := sha256.Sum256(pk[:]); hex.EncodeToString(sum[:]) != acvpKeyGenPKSum {
+		t.Errorf("SHA-256(pk) = %x, want %s", sum, acvpKeyGenPKSum)
+	}
+	if sum := sha256.Sum256(sk[:]); hex.EncodeToString(sum[:]) != acvpKeyGenSKSum {
+		t.Errorf("SHA-256(sk) = %x, want %s", sum, acvpKeyGenSKSum)
+	}
+}
+
+func TestACVPSigGenVector(t *testing.T) {
+	var sk [CRYPTO_SECRET_KEY_BYTES]uint8
+	if _, err := hex.Decode(sk[:], []byte(acvpSigGenSK)); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := hex.DecodeString(acvpSigGenMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rnd [RND_BYTES]uint8 // deterministic variant
+	sig := make([]uint8, CRYPTO_BYTES)
+	if err := cryptoSignSignatureInternal(sig, msg, nil, rnd, &sk); err != nil {
+		t.Fatal(err)
+	}
+	if sum := sha256.Sum256(sig); hex.EncodeToString(sum[:]) != acvpSigGenSigSum {
+		t.Errorf("SHA-256(signature) = %x, want %s", sum, acvpSigGenSigSum)
+	}
+}
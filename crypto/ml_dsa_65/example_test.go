@@ -0,0 +1,36 @@
+package ml_dsa_65_test
+
+import (
+	"fmt"
+
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_65"
+)
+
+// Example demonstrates basic ML-DSA-65 signature operations.
+func Example() {
+	// Create a new ML-DSA-65 instance with random seed
+	m, err := ml_dsa_65.New()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer m.Zeroize() // Clear sensitive key material when done
+
+	// Sign a message with context (FIPS 204 requirement)
+	ctx := []byte("my-application")
+	message := []byte("Hello, FIPS 204!")
+	signature, err := m.Sign(ctx, message)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	// Verify the signature
+	pk := m.GetPK()
+	valid := ml_dsa_65.Verify(ctx, message, signature, &pk)
+	fmt.Println("Signature valid:", valid)
+	fmt.Println("Signature size:", len(signature))
+	// Output:
+	// Signature valid: true
+	// Signature size: 3309
+}
@@ -0,0 +1,562 @@
+package ml_dsa_65
+
+import (
+	"crypto/rand"
+	"crypto/sha3"
+	"crypto/subtle"
+	"runtime"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+// zeroBytes overwrites b with zeros. runtime.KeepAlive prevents the compiler
+// from eliding the writes as a dead store.
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+	runtime.KeepAlive(&b)
+}
+
+func zeroPoly(p *poly) {
+	for i := range p.coeffs {
+		p.coeffs[i] = 0
+	}
+	runtime.KeepAlive(p)
+}
+
+func zeroPolyVecL(v *polyVecL) {
+	for i := range v.vec {
+		zeroPoly(&v.vec[i])
+	}
+}
+
+func zeroPolyVecK(v *polyVecK) {
+	for i := range v.vec {
+		zeroPoly(&v.vec[i])
+	}
+}
+
+// Take a random seed, and compute sk/pk pair.
+func cryptoSignKeypair(seed *[SEED_BYTES]uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8, sk *[CRYPTO_SECRET_KEY_BYTES]uint8) (*[SEED_BYTES]uint8, error) {
+	var tr [TR_BYTES]uint8
+	var rho, key [SEED_BYTES]uint8
+	var rhoPrime [CRH_BYTES]uint8
+
+	var mat [K]polyVecL
+	var s1, s1hat polyVecL
+	var s2, t1, t0 polyVecK
+
+	// Zeroize secret intermediates when key generation completes.
+	// Mirrors the cleanup pattern in cryptoSignSignatureInternal so both
+	// paths handle their unpacked secret material consistently. Go's GC
+	// may copy values before zeroization executes, so this is a
+	// best-effort reduction of the in-memory exposure window rather than
+	// a guarantee — see SECURITY.md and MLDSA65.Zeroize for the exact
+	// boundary. (TOB-QRLLIB-10)
+	defer func() {
+		zeroBytes(key[:])
+		zeroBytes(rhoPrime[:])
+		zeroPolyVecL(&s1)
+		zeroPolyVecL(&s1hat)
+		zeroPolyVecK(&s2)
+		zeroPolyVecK(&t0)
+	}()
+
+	if seed == nil {
+		//coverage:ignore
+		//rationale: all public API callers (New, NewMLDSA65FromSeed) always provide a seed
+		seed = new([SEED_BYTES]uint8)
+		_, err := rand.Read(seed[:])
+		if err != nil {
+			//coverage:ignore
+			//rationale: crypto/rand.Read only fails if system entropy source is broken
+			return nil, cryptoerrors.ErrSeedGeneration
+		}
+	}
+	/* Expand 32 bytes of randomness into rho, rhoprime and key */
+	state := getShake256()
+	defer putShake256(state)
+	if _, err := state.Write(seed[:]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		return nil, err
+	}
+	extraData := []byte{K, L}
+	if _, err := state.Write(extraData); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		return nil, err
+	}
+	if _, err := state.Read(rho[:]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Read never returns an error for XOF
+		return nil, err
+	}
+	if _, err := state.Read(rhoPrime[:]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Read never returns an error for XOF
+		return nil, err
+	}
+	if _, err := state.Read(key[:]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Read never returns an error for XOF
+		return nil, err
+	}
+
+	/* Expand matrix */
+	if err := polyVecMatrixExpand(&mat, &rho); err != nil {
+		//coverage:ignore
+		//rationale: polyVecMatrixExpand's sha3 operations never return errors
+		return nil, err
+	}
+
+	/* Sample short vectors s1 and s2 */
+	if err := polyVecLUniformETA(&s1, &rhoPrime, 0); err != nil {
+		//coverage:ignore
+		//rationale: polyVecLUniformETA's sha3 operations never return errors
+		return nil, err
+	}
+	if err := polyVecKUniformETA(&s2, &rhoPrime, L); err != nil {
+		//coverage:ignore
+		//rationale: polyVecKUniformETA's sha3 operations never return errors
+		return nil, err
+	}
+
+	/* Matrix-vector multiplication */
+	s1hat = s1
+	polyVecLNTT(&s1hat)
+	polyVecMatrixPointWiseMontgomery(&t1, &mat, &s1hat)
+	polyVecKReduce(&t1)
+	polyVecKInvNTTToMont(&t1)
+
+	/* Add noise vector s2 */
+	polyVecKAdd(&t1, &t1, &s2)
+
+	/* Extract t1 and write public key */
+	polyVecKCAddQ(&t1)
+	polyVecKPower2Round(&t1, &t0, &t1)
+	packPk(pk, rho, &t1)
+
+	/* Compute tr = CRH(rho, t1) and write secret key */
+	copy(tr[:], sha3.SumSHAKE256(pk[:], TR_BYTES))
+	packSk(sk, rho, tr, key, &t0, &s1, &s2)
+
+	return seed, nil
+}
+
+// computeMu computes the FIPS 204 message representative
+// mu = CRH(tr || pre || m), where pre is the pure (0x00) or pre-hash
+// (0x01) domain prefix including the context.
+func computeMu(mu *[CRH_BYTES]uint8, tr []uint8, pre, m []uint8) {
+	state := getShake256()
+	defer putShake256(state)
+	_, _ = state.Write(tr)
+	_, _ = state.Write(pre)
+	_, _ = state.Write(m)
+	_, _ = state.Read(mu[:]) // ShakeHash.Read never returns an error
+}
+
+func cryptoSignSignatureInternal(sig, m []uint8, pre []uint8, rnd [RND_BYTES]uint8, sk *[CRYPTO_SECRET_KEY_BYTES]uint8) error {
+	var mu [CRH_BYTES]uint8
+
+	/* Compute mu = CRH(tr, 0, ctxlen, ctx, msg); tr sits after rho and key in sk */
+	computeMu(&mu, sk[2*SEED_BYTES:2*SEED_BYTES+TR_BYTES], pre, m)
+
+	return cryptoSignSignatureMu(sig, &mu, rnd, sk)
+}
+
+// expandedSK holds the secret-key-derived state that signing
+// recomputes on every call: A in NTT form, s1, s2 and t0 in NTT form,
+// plus key and tr. It contains secret material and must be wiped with
+// zeroExpandedSK once no longer needed.
+type expandedSK struct {
+	key [SEED_BYTES]uint8
+	tr  [TR_BYTES]uint8
+	mat [K]polyVecL
+	s1  polyVecL
+	s2  polyVecK
+	t0  polyVecK
+}
+
+// expandSK fills esk from the packed secret key sk.
+func expandSK(esk *expandedSK, sk *[CRYPTO_SECRET_KEY_BYTES]uint8) error {
+	var rho [SEED_BYTES]uint8
+
+	unpackSk(&rho, &esk.tr, &esk.key, &esk.t0, &esk.s1, &esk.s2, sk)
+
+	/* Expand matrix and transform vectors */
+	if err := polyVecMatrixExpand(&esk.mat, &rho); err != nil {
+		//coverage:ignore
+		//rationale: polyVecMatrixExpand's sha3 operations never return errors
+		return err
+	}
+	polyVecLNTT(&esk.s1)
+	polyVecKNTT(&esk.s2)
+	polyVecKNTT(&esk.t0)
+	return nil
+}
+
+func zeroExpandedSK(esk *expandedSK) {
+	zeroBytes(esk.key[:])
+	zeroPolyVecL(&esk.s1)
+	zeroPolyVecK(&esk.s2)
+	zeroPolyVecK(&esk.t0)
+}
+
+// cryptoSignSignatureMu signs a precomputed message representative mu
+// (FIPS 204 Algorithm 7 from line 7 onward, i.e. the external-mu
+// entry point).
+func cryptoSignSignatureMu(sig []uint8, mu *[CRH_BYTES]uint8, rnd [RND_BYTES]uint8, sk *[CRYPTO_SECRET_KEY_BYTES]uint8) error {
+	var esk expandedSK
+
+	// Zeroize secret temporaries when signing completes.
+	// Go's GC may copy values before zeroization, but this still reduces
+	// the window for secrets persisting in freed memory.
+	defer zeroExpandedSK(&esk)
+
+	if err := expandSK(&esk, sk); err != nil {
+		//coverage:ignore
+		//rationale: expandSK's sha3 operations never return errors
+		return err
+	}
+	return cryptoSignSignatureMuExpanded(sig, mu, rnd, &esk)
+}
+
+// cryptoSignSignatureMuExpanded is [cryptoSignSignatureMu] against an
+// already expanded secret key. esk is only read.
+func cryptoSignSignatureMuExpanded(sig []uint8, mu *[CRH_BYTES]uint8, rnd [RND_BYTES]uint8, esk *expandedSK) error {
+	var rhoPrime [CRH_BYTES]uint8
+	var y, z polyVecL
+	var w1, h, w0 polyVecK
+	var cp poly
+	var nonce uint16
+
+	defer zeroBytes(rhoPrime[:])
+
+	/* Compute rhoprime = CRH(key, rnd, mu) */
+	state := getShake256()
+	defer putShake256(state)
+	_, _ = state.Write(esk.key[:])
+	_, _ = state.Write(rnd[:])
+	_, _ = state.Write(mu[:])
+	_, _ = state.Read(rhoPrime[:]) // ShakeHash.Read never returns an error
+
+rej:
+
+	/* Sample intermediate vector y */
+	polyVecLUniformGamma1(&y, rhoPrime, nonce)
+	nonce++
+
+	/* Matrix-vector multiplication */
+	z = y
+	polyVecLNTT(&z)
+	polyVecMatrixPointWiseMontgomery(&w1, &esk.mat, &z)
+	polyVecKReduce(&w1)
+	polyVecKInvNTTToMont(&w1)
+
+	/* Decompose w and call the random oracle */
+	polyVecKCAddQ(&w1)
+	polyVecKDecompose(&w1, &w0, &w1)
+	if err := polyVecKPackW1(sig[:K*POLY_W1_PACKED_BYTES], &w1); err != nil {
+		//coverage:ignore
+		//rationale: sig buffer is always correctly sized for K*POLY_W1_PACKED_BYTES
+		return err
+	}
+
+	state.Reset() // Reuse pooled hasher
+	if _, err := state.Write(mu[:]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		return err
+	}
+	if _, err := state.Write(sig[:K*POLY_W1_PACKED_BYTES]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		return err
+	}
+	if _, err := state.Read(sig[:C_TILDE_BYTES]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Read never returns an error for XOF
+		return err
+	}
+	if err := polyChallenge(&cp, sig[:C_TILDE_BYTES]); err != nil {
+		//coverage:ignore
+		//rationale: polyChallenge's sha3 operations never return errors
+		return err
+	}
+	polyNTT(&cp)
+
+	/* Compute z, reject if it reveals secret */
+	polyVecLPointWisePolyMontgomery(&z, &cp, &esk.s1)
+	polyVecLInvNTTToMont(&z)
+	polyVecLAdd(&z, &z, &y)
+	polyVecLReduce(&z)
+	if polyVecLChkNorm(&z, GAMMA1-BETA) != 0 {
+		goto rej
+	}
+
+	/* Check that subtracting cs2 does not change high bits of w and low bits
+	 * do not reveal secret information */
+	polyVecKPointWisePolyMontgomery(&h, &cp, &esk.s2)
+	polyVecKInvNTTToMont(&h)
+	polyVecKSub(&w0, &w0, &h)
+	polyVecKReduce(&w0)
+	if polyVecKChkNorm(&w0, GAMMA2-BETA) != 0 {
+		goto rej
+	}
+
+	/* Compute hints for w1 */
+	polyVecKPointWisePolyMontgomery(&h, &cp, &esk.t0)
+	polyVecKInvNTTToMont(&h)
+	polyVecKReduce(&h)
+	if polyVecKChkNorm(&h, GAMMA2) != 0 {
+		//coverage:ignore
+		//rationale: rejection condition rarely triggers; signature typically succeeds on first attempt
+		goto rej
+	}
+
+	polyVecKAdd(&w0, &w0, &h)
+	n := polyVecKMakeHint(&h, &w0, &w1)
+	if n > OMEGA {
+		//coverage:ignore
+		//rationale: rejection condition rarely triggers; signature typically succeeds on first attempt
+		goto rej
+	}
+	var c [C_TILDE_BYTES]uint8
+	copy(c[:], sig[:C_TILDE_BYTES])
+	if err := packSig(sig[:CRYPTO_BYTES], c, &z, &h); err != nil {
+		//coverage:ignore
+		//rationale: packSig only fails for invalid buffer size, but sig is always correctly sized
+		return err
+	}
+	return nil
+}
+
+// messagePrefix returns the pure ML-DSA domain prefix
+// `0x00 || len(ctx) || ctx` (FIPS 204 Algorithm 2, line 10).
+func messagePrefix(ctx []uint8) ([]uint8, error) {
+	if len(ctx) > 255 {
+		return nil, cryptoerrors.ErrInvalidContext
+	}
+	pre := make([]uint8, len(ctx)+2)
+	pre[0] = 0
+	pre[1] = uint8(len(ctx))
+	copy(pre[2:], ctx)
+	return pre, nil
+}
+
+// signSignature is the standard hedged-signing entry point. It
+// reads RND_BYTES from crypto/rand and calls
+// [MLDSA65.signSignatureWithRnd]. Per FIPS 204 §3.4, hedged (randomised)
+// signing reduces side-channel and fault-injection leverage relative
+// to the deterministic variant; all public ML-DSA-65 signing in this
+// library uses this path. (TOB-QRLLIB-6.)
+//
+// Callers needing an explicit rnd value (the crypto.Signer.Sign
+// caller-supplied io.Reader path; ACVP / KAT determinism tests with
+// rnd=zero) call [cryptoSignSignatureWithRnd] directly.
+func (d *MLDSA65) signSignature(sig, m []uint8, ctx []uint8) error {
+	var rnd [RND_BYTES]uint8
+	if _, err := rand.Read(rnd[:]); err != nil {
+		//coverage:ignore
+		//rationale: crypto/rand.Read only fails if system entropy source is broken
+		return cryptoerrors.ErrSeedGeneration
+	}
+	return d.signSignatureWithRnd(sig, m, ctx, rnd)
+}
+
+// signSignatureWithRnd is [cryptoSignSignatureWithRnd] for d's key.
+func (d *MLDSA65) signSignatureWithRnd(sig, m []uint8, ctx []uint8, rnd [RND_BYTES]uint8) error {
+	pre, err := messagePrefix(ctx)
+	if err != nil {
+		return err
+	}
+	return cryptoSignSignatureInternal(sig, m, pre, rnd, &d.sk)
+}
+
+// cryptoSignSignatureWithRnd signs m using the explicit rnd value
+// (FIPS 204 §3.5; rnd is mixed into the deterministic signing nonce).
+// Pass an all-zero rnd for FIPS-204-deterministic signing (used by
+// ACVP / KAT vectors); pass entropy from crypto/rand or an
+// authenticated source for hedged signing. The crypto.Signer wrapper
+// uses this path (via [MLDSA65.signSignatureWithRnd]) when the caller
+// supplies an io.Reader.
+func cryptoSignSignatureWithRnd(sig, m []uint8, ctx []uint8, sk *[CRYPTO_SECRET_KEY_BYTES]uint8, rnd [RND_BYTES]uint8) error {
+	pre, err := messagePrefix(ctx)
+	if err != nil {
+		return err
+	}
+	return cryptoSignSignatureInternal(sig, m, pre, rnd, sk)
+}
+
+// signAttached is the attached-signature wrapper: it returns
+// `signature || msg`.
+func (d *MLDSA65) signAttached(msg []uint8, ctx []uint8) ([]uint8, error) {
+	sm := make([]uint8, CRYPTO_BYTES+len(msg))
+	copy(sm[CRYPTO_BYTES:], msg)
+	err := d.signSignature(sm[:CRYPTO_BYTES], sm[CRYPTO_BYTES:], ctx)
+	if err != nil {
+		for i := range sm {
+			sm[i] = 0
+		}
+		return nil, err
+	}
+	return sm, nil
+}
+
+func cryptoSignVerifyInternal(sig [CRYPTO_BYTES]uint8, m []uint8, pre []uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) error {
+	var mu [CRH_BYTES]uint8
+
+	/* Compute CRH(H(rho, t1), pre, msg) */
+	computeMu(&mu, sha3.SumSHAKE256(pk[:CRYPTO_PUBLIC_KEY_BYTES], TR_BYTES), pre, m)
+
+	return cryptoSignVerifyMu(sig, &mu, pk)
+}
+
+// expandedPK holds the public-key-derived state that verification
+// recomputes on every call: A in NTT form (ExpandA samples directly in
+// the NTT domain), NTT(t1 * 2^d) and tr = H(pk). It is read-only once
+// filled in, so one expandedPK may be shared by concurrent verifiers.
+type expandedPK struct {
+	mat [K]polyVecL
+	t1  polyVecK
+	tr  [TR_BYTES]uint8
+}
+
+// expandPK fills epk from the packed public key pk.
+func expandPK(epk *expandedPK, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) error {
+	var rho [SEED_BYTES]uint8
+
+	unpackPk(&rho, &epk.t1, pk)
+	if err := polyVecMatrixExpand(&epk.mat, &rho); err != nil {
+		//coverage:ignore
+		//rationale: polyVecMatrixExpand's sha3 operations never return errors
+		return err
+	}
+	polyVecKShiftL(&epk.t1)
+	polyVecKNTT(&epk.t1)
+	copy(epk.tr[:], sha3.SumSHAKE256(pk[:], TR_BYTES))
+	return nil
+}
+
+// cryptoSignVerifyMu verifies sig against a precomputed message
+// representative mu (FIPS 204 Algorithm 8 from line 7 onward). It
+// returns nil for a valid signature and the reason otherwise; see
+// [cryptoSignVerifyMuExpanded].
+func cryptoSignVerifyMu(sig [CRYPTO_BYTES]uint8, mu *[CRH_BYTES]uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) error {
+	var epk expandedPK
+	if err := expandPK(&epk, pk); err != nil {
+		//coverage:ignore
+		//rationale: expandPK's sha3 operations never return errors
+		return err
+	}
+	return cryptoSignVerifyMuExpanded(sig, mu, &epk)
+}
+
+// cryptoSignVerifyMuExpanded is [cryptoSignVerifyMu] against an
+// already expanded public key. epk is only read. A rejected signature
+// yields ErrNonCanonicalHint, ErrSignatureNormExceeded or, when the
+// recomputed challenge differs, ErrInvalidSignature.
+func cryptoSignVerifyMuExpanded(sig [CRYPTO_BYTES]uint8, mu *[CRH_BYTES]uint8, epk *expandedPK) error {
+	var buf [K * POLY_W1_PACKED_BYTES]uint8
+	var c, c2 [C_TILDE_BYTES]uint8
+	var cp poly
+	var z polyVecL
+	var ct1, w1, h polyVecK
+
+	if unpackSig(&c, &z, &h, sig) != 0 {
+		return cryptoerrors.ErrNonCanonicalHint
+	}
+	if polyVecLChkNorm(&z, GAMMA1-BETA) != 0 {
+		return cryptoerrors.ErrSignatureNormExceeded
+	}
+
+	/* Matrix-vector multiplication; compute Az - c2^dt1 */
+	if err := polyChallenge(&cp, c[:]); err != nil {
+		//coverage:ignore
+		//rationale: polyChallenge's sha3 operations never return errors
+		return err
+	}
+
+	polyVecLNTT(&z)
+	polyVecMatrixPointWiseMontgomery(&w1, &epk.mat, &z)
+
+	polyNTT(&cp)
+	polyVecKPointWisePolyMontgomery(&ct1, &cp, &epk.t1)
+
+	polyVecKSub(&w1, &w1, &ct1)
+	polyVecKReduce(&w1)
+	polyVecKInvNTTToMont(&w1)
+
+	/* Reconstruct w1 */
+	polyVecKCAddQ(&w1)
+	polyVecKUseHint(&w1, &w1, &h)
+	if err := polyVecKPackW1(buf[:], &w1); err != nil {
+		//coverage:ignore
+		//rationale: buf is always correctly sized for K*POLY_W1_PACKED_BYTES
+		return err
+	}
+
+	/* Call random oracle and verify challenge */
+	state := getShake256()
+	defer putShake256(state)
+	if _, err := state.Write(mu[:CRH_BYTES]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		return err
+	}
+	if _, err := state.Write(buf[:K*POLY_W1_PACKED_BYTES]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
+		return err
+	}
+	if _, err := state.Read(c2[:C_TILDE_BYTES]); err != nil {
+		//coverage:ignore
+		//rationale: sha3.ShakeHash.Read never returns an error for XOF
+		return err
+	}
+
+	// Use constant-time comparison to prevent timing side-channel attacks
+	if subtle.ConstantTimeCompare(c[:], c2[:]) != 1 {
+		return cryptoerrors.ErrInvalidSignature
+	}
+	return nil
+}
+
+func cryptoSignVerify(sig [CRYPTO_BYTES]uint8, m []uint8, ctx []uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) error {
+	// Defense-in-depth nil-check (TOB-QRLLIB-11). The public Verify/Open
+	// wrappers also check, but this internal entry point is reachable
+	// from crypto.Signer (via cryptoSign etc.) and any future caller.
+	if pk == nil {
+		return cryptoerrors.ErrPublicKeyNil
+	}
+	pre, err := messagePrefix(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cryptoSignVerifyInternal(sig, m, pre, pk)
+}
+
+func cryptoSignOpen(sm []uint8, ctx []uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) ([]uint8, error) {
+	// Defense-in-depth nil-check (TOB-QRLLIB-11); see cryptoSignVerify.
+	if pk == nil {
+		return nil, cryptoerrors.ErrPublicKeyNil
+	}
+	if len(sm) < CRYPTO_BYTES {
+		return nil, cryptoerrors.ErrInvalidSignatureSize
+	}
+
+	var sig [CRYPTO_BYTES]uint8
+	msg := make([]uint8, len(sm)-CRYPTO_BYTES)
+
+	copy(sig[:], sm)
+	copy(msg, sm[CRYPTO_BYTES:])
+
+	if err := cryptoSignVerify(sig, msg, ctx, pk); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
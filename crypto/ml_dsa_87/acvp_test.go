@@ -41,9 +41,26 @@ type acvpSigGenVector struct {
 	SK        string `json:"sk"`
 	Message   string `json:"message"`
 	Context   string `json:"context"`
+	HashAlg   string `json:"hashAlg,omitempty"`
 	Signature string `json:"signature"`
 }
 
+// acvpHashAlgs maps ACVP hashAlg names to PreHashFunction values.
+var acvpHashAlgs = map[string]PreHashFunction{
+	"SHA2-224":     SHA2_224,
+	"SHA2-256":     SHA2_256,
+	"SHA2-384":     SHA2_384,
+	"SHA2-512":     SHA2_512,
+	"SHA2-512/224": SHA2_512_224,
+	"SHA2-512/256": SHA2_512_256,
+	"SHA3-224":     SHA3_224,
+	"SHA3-256":     SHA3_256,
+	"SHA3-384":     SHA3_384,
+	"SHA3-512":     SHA3_512,
+	"SHAKE-128":    SHAKE_128,
+	"SHAKE-256":    SHAKE_256,
+}
+
 // TestACVPKeyGen verifies that key generation from seed produces byte-exact
 // matches against NIST ACVP expected public and secret keys.
 func TestACVPKeyGen(t *testing.T) {
@@ -218,3 +235,77 @@ func TestACVPSigGen(t *testing.T) {
 		})
 	}
 }
+
+// TestACVPSigGenPrehash verifies that deterministic HashML-DSA signature
+// generation (FIPS 204 §5.4) produces byte-exact matches against NIST
+// ACVP expected signatures, and that [VerifyPrehash] accepts them.
+//
+// The ACVP prompt carries the full message; the test computes PH(M)
+// itself, as a [MLDSA87.SignPrehash] caller would.
+func TestACVPSigGenPrehash(t *testing.T) {
+	dir := acvpVectorsDir(t)
+
+	data, err := os.ReadFile(filepath.Join(dir, "siggen_prehash.json"))
+	if err != nil {
+		t.Fatalf("Failed to read siggen_prehash.json: %v", err)
+	}
+
+	var vectors []acvpSigGenVector
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatalf("Failed to parse siggen_prehash.json: %v", err)
+	}
+
+	if len(vectors) == 0 {
+		t.Fatal("No pre-hash siggen test vectors found")
+	}
+
+	t.Logf("Running %d ACVP pre-hash siggen test vectors", len(vectors))
+
+	for _, vec := range vectors {
+		t.Run(fmt.Sprintf("tc%d", vec.TcID), func(t *testing.T) {
+			ph, ok := acvpHashAlgs[vec.HashAlg]
+			if !ok {
+				t.Fatalf("Unsupported hashAlg %q", vec.HashAlg)
+			}
+
+			skBytes, err := hex.DecodeString(vec.SK)
+			if err != nil {
+				t.Fatalf("Invalid sk hex: %v", err)
+			}
+			if len(skBytes) != CRYPTO_SECRET_KEY_BYTES {
+				t.Fatalf("SK length %d, expected %d", len(skBytes), CRYPTO_SECRET_KEY_BYTES)
+			}
+
+			msg, err := hex.DecodeString(vec.Message)
+			if err != nil {
+				t.Fatalf("Invalid message hex: %v", err)
+			}
+
+			ctx, err := hex.DecodeString(vec.Context)
+			if err != nil {
+				t.Fatalf("Invalid context hex: %v", err)
+			}
+
+			expectedSig, err := hex.DecodeString(vec.Signature)
+			if err != nil {
+				t.Fatalf("Invalid signature hex: %v", err)
+			}
+
+			var sk [CRYPTO_SECRET_KEY_BYTES]uint8
+			copy(sk[:], skBytes)
+
+			digest := preHashDigest(t, ph, msg)
+
+			var rnd [RND_BYTES]uint8 // zero — FIPS 204 deterministic mode
+			sig := make([]uint8, CRYPTO_BYTES)
+			if err := cryptoSignPrehashWithRnd(sig, digest, ctx, ph, &sk, rnd); err != nil {
+				t.Fatalf("cryptoSignPrehashWithRnd failed: %v", err)
+			}
+
+			if !bytes.Equal(sig, expectedSig) {
+				t.Errorf("Signature mismatch (%s)\n  got:  %s...\n  want: %s...",
+					vec.HashAlg, hex.EncodeToString(sig[:32]), hex.EncodeToString(expectedSig[:32]))
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package ml_dsa_87
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+// One keyGen and one deterministic sigGen vector for ML-DSA-87, taken
+// from the NIST ACVP-Server files ML-DSA-keyGen-FIPS204 (vsId 42,
+// tgId 3, tcId 51) and ML-DSA-sigGen-FIPS204 (vsId 42, tgId 5,
+// tcId 50), so that the default test run checks published answers
+// without the "acvp" build tag. Expected outputs are stored as their
+// SHA-256. This sigGen vector set predates the external/internal
+// interface split and signs the message with ML-DSA.Sign_internal, so
+// no context prefix is applied.
+const (
+	acvpKeyGenSeed  = "38359fbcd79582cffe609e137ee2efe8a8dbcbad18ba92bb433ab4f09b49299d"
+	acvpKeyGenPKSum = "ea374a09356e5f89be784f28f4ef938e8976cb5c4db00fbacb257663491748d4"
+	acvpKeyGenSKSum = "a0cc3d4f703057c09b9261336ba45563d2c781d173f7fc634910698e95eee375"
+
+	acvpSigGenSigSum = "86cc4e26c35e1029183411f2c3ba65d49b172686eb5e053d65edc49b054071f5"
+)
+
+const acvpSigGenSK = "7377d2ce98a125d2293896ea97285838df426ef6d3e06d3edbba7c6bf034fe0c3da0a5ccb79ed5176dc24abce7ee76e7c1cd259cc05a4a784c8e7de70fe1f4c1" +
+	"cdb96cbc97a40cae2d0f29cbc084e65111808fc3bf9faf728738346768c481b8dd506b9845f3a22b533a384d394fa268f6b8c863112aeb94d469da66c7aec367" +
+	"03035149c02d0b124cc89825a2a644d4a089010549dba0885b82898b042094064d209988da0432d2a80d8ca08922955013a79064222401202d9c144422b16892" +
+	"820d0b821180b66c62284ea2b40c08a35122c760dc20510cb77104446194006523b68058820449064199920d18170c02106e12146d40024d5bc40113056aa310" +
+	"284ac0302180292226455a384e8c040a08980812436ed838491318295a90651b094909b7248cb04909b28c18c34d044989420645a2922191a42019409198246c" +
+	"1b877103b40102048201b14801212d14130c1a204d0b176ccb046a03485112316e0b4572e2325013192c8aa640d98064022001c9320209100e134849429030a1" +
+	"14120392445cc84ce24212c00844e0b62918a00514200241080e04456858b0101c1930113320111280028689d84684121168a3a0211009718ba42c0ba1450c27" +
+	"9214972d01354e0b230c88c0418320492047660a908889064ec2b8449b206911b20413c32c40322e53162011096611072ce2420ce0106d1947720285254b264e" +
+	"58a4815848861b068e12126453865013338d8298500892285120111019708024214234080c1306cbb46cd808000cb3659042124c82612219296102411a944109" +
+	"80490a2580431450daa684403249c18008a1067101c38514102ee1b60c99244459006863201104134a0b4104242352929845941872892249d038494c84902001" +
+	"64dcc864d43440a428060bc009e482104c82411ba088192649c4b64822208911986cc9c240a03442d340710492708200651a032c0cc4118cb42cc03205193828" +
+	"0aa30d63a04d24c03012864da4088559380659b868013852c8c4044c900c4a026582283101a7091402449ab8691c85701c414113148e0b4084a012120bc88559" +
+	"c82c22876912a10122950d64b06150120a23344da1a64151c28408c20dc3825120a96420b325e0829118c94423924c03256ea28444a0000c0a4712a226621b43" +
+	"311c17464ba6458a340e228830c3368518106e09038e01322c01866010c4100ac4204c2282c8c07120b848d1200200426c081200e4a8040c444063a4718a304c" +
+	"c8b421e406521c92509b4889842082cc406108155020b171c2184a4a246c218421a3b004d2b608a1880d63b02c02b4455ac881143606c8c410da86615b346620" +
+	"3131d9240d20b530021206e0264151040eda048e4b2822a444319b008ada822023086d1090888ab411cac44c980282a016002128928c44325c262159182d1015" +
+	"71cb406840c22dcb10669b30024ac00023b84c138724a4b030241764e1120604961161068084000104840d204461c8a610a00469a20285cc1488088648100262" +
+	"0895240840401b421120a328d30401e4381051901091948459c8299b40895498681c3712c4a0905a180d04312003b5800ba6699b28244486509b3242930680e0" +
+	"4251e2320a99420dd4a248cc929004b9310c366c09185200238600b14c24b7014a887108a1511b850023a328c846529c40848a8045d4c6715344290ac8650b22" +
+	"304c28268b462922008d8844691b230110c0480b492064148024223104466e21448a09430a22056601896989228c24866d23337062288e5082708cc031130341" +
+	"c1022d23007188247141102e09a684133266024661c9b491c0b08403b90cd0821012312249b00c11356a83a6444bb64562a84199444c63a645dab42dc3860160" +
+	"9680441206dab840233170c338925b1271c8a660a04402a026068a305283a0458a14825b268c43445222272510332e8cc6414bb025521025a014294048652298" +
+	"2811194e14267102336420a80043124c6128241b3952e2c8459c086c90b6050b3452c1c06024210921a06053c68013360202802c80486808a54160488e1b2784" +
+	"518420834244c8c88c443690cb04860a42269ca62d9132101aa9515a320800a5845a8831a2b8641434605a146414152c00c90c02b510d3a229021728e40826e4" +
+	"82014b280690b6851b428d10c225d38409c8b029e110882024200c280512454da807b179be146e96ec60914e74b78099dc2bb667ed709c1dc39dae07760fae0f" +
+	"bb086016f3be0fd574560a68a9dcac7a44629362330ae6293a88276f4b82beaa2a42482d9c708ec75e60dc52de3b70ef0f8ebaa0f591197273af0dead7ca2be5" +
+	"f6b7f67c99aae59a016938f035daf644ed94b5e9b64e153eb0dc49efec8f61bdfce44b28532fae0faa09f430f4dcbdf34cab952fd7e7c61c8ff1c36d9cb8330b" +
+	"556bac79c4286331d7bc0023b643325c4e23b6e544d62f8d1e3b8b5f1241be69a9aac2f124debbda3127093f4ea42e9df7c7ba388e44197fb95fa17dcd6e6562" +
+	"d22c933c32a73f0d3fb9081de04e513c9047f4dbb0f1a085ccbdf80bc0b6bcb652c302400f2d4c0c67b3698c23fc888d4bf06ccacfc202830d84ecd416189d01" +
+	"07b2f27b173d7541335004aae5dffc0dc60854298b1fd961d96bb8672a679e0d360150ba1e510b7151a440ad4bce9a997b5d330df5eeb6449264bdd4aee6a86b" +
+	"8b00e0173838f2a645c9d8c4673908f6dbfd634034d840b378b185b21c92bbdccca0804ed6286fbc375473c46aec46415b468caeb97797fd03c374e422461f08" +
+	"07aa53d4c6cae6fb5af4c5ea616d295c5dc7d6886e5816fe47313a90be1a7b8d528b96b351f1f0379f7f4301d7c669c0d27813efa58827c26f04a09b4d9ff4b6" +
+	"007ff8bccd3cb91e7ced0cbc1d0cdac5f9205e6c9f3a1cd17fdf88cbd0c2554d162bd6bac9af0390a80745c6221b1ccac44c6fd5f68de32a9613ac4d4f77640a" +
+	"04141ca967061228f4e2d7c514c9ffa349004c0251e631c10b45be25f148d37b05e14c3df976b20ea5c26925818058584df8428a8adba8377f74658834b3a72b" +
+	"938dc6c9ff8f923b22e99990730ca9723f531a5bae5d619725cdeba78fef75acb0c9d3bcd9c5baad600282f4145bf3e3beb2a1ba7ae035659cb10f70d11d7f0a" +
+	"5df5671466cf6554766c024af1b9914f87bd74719deb89014a9fd6247d089063d1578471b5beda5907825cd0a024716c21b186f3147f3c1309968782d8af9cf4" +
+	"0024bfc067111a68e27ff2e93d640657f422fc45537d9efd2383b770e3702e2dce1be4530d17e4fc4c3755d47963b6e0184d277adab8037117ded146924db13a" +
+	"05aca3d7694cedf95a0603f7b833abaf05eefbfc2585fd1e332070f63b486d93fa9d5457a09d9d27f84e80d49db6548326d5f82a56b259271ad9ea4e90875d38" +
+	"718b2ec45e97f556fbb48ffdeae2fa95a2a8fe1979dd2f48047685a3362c5f08b4c119305364293a498b4871cb7f5db4e6b62e909960fc7495aa997ee6b885d5" +
+	"dd0bdafc89be1b4fffe06789f6aa25497bf225b9aeb737f3c21be2c7fdaf84f495e8edabecccde3b0d60ab7e5958aaf5d0c5c062ed8775dbfc07e7a54ef47c8c" +
+	"eb59004fa347f1799481607497cb029c0a3981e564d4290c61bde180cfc82f5ed40f6c89ab93635aad175d488c1bf1c9a787dd3586ee49c028d65bff792842d7" +
+	"6f20e643e4e14312b1a52958dcca1d9f7e0aeecaaa07b8be1612ab2d5076a7f079f3872d8cdb5b128835436d14323732fa806b82014022f68e04862315fe6f16" +
+	"ee9254789db98420bba3f0dcc51159cfb7ea79e248ca2d21879e262ddbde7f9c10757164a7096f5343afa7ed777b8e2f0d13dd0a03eca6f064ebb01e2ff84da3" +
+	"542e1dcf62e7f911ce8cf632dec6e376690c5d05cddb42f7b0abb6101d164d2a7ce931a12bcaf8e6bfb3d80e6e4cfd5acab85d4807054c406b7a93fa29f3589d" +
+	"5693ca4294834542884bb92bc1c88bc27aeedd69e3d836130dd467f5cdd6cb82c2529b1e82837864188f6bea25ecd031a55cf035a9f8523c30d30f93d2ab7bbc" +
+	"53e3e632b8f432bca0d45f85fcd007cdad638749dd09f7ec85c8c6b6fc7a4a3d87347515c73f64900c9b788b9e27c73469823c9fb6daa6760d95626e74f18ede" +
+	"6cf3e5888afbe5d4ce686df584ae67b5c300e8352288bfd55e5b8337a4ccb872bb999e86aac9efbc559437b10dc290d9a745692795d178b9134592232a696c5f" +
+	"0fdd653cd10edabdeddb746082ae54a800b43235dfd791bf7aa582155794d67204f87d9ccc52e51df8abfd24a4769c423c70b256c2e150844659f68e974b2778" +
+	"40e98a6879333966f79b7a41aceb1110e7e8b9deb3d09c18285be31a833af62923e81b2499ac91f6273916b8e067892fc407074d2a99f287e78212194cb3862a" +
+	"c1f48d4b520b592d3bab72d0101fe8faf11564c88dde8856fda56aebefea67b7f0bc4836190a8e6433f3698c0837f049f04affa2313fcca95d22744c2c6fe08f" +
+	"d296e884e4d8bf1c05c0a7792f077900647b7d496ce3e2fc2690f2eb4402e853de1bc21bbed13bc4930f1f3672702d9e676efcfc6dbe120c398d6b335cb7f0c2" +
+	"483e1334ff4d526d59e5db66e2b6bd865cafd3a7eae254536b07b67f7d883b92e0a0f59fb17f1b116626479117418f09f2c158efe88f082a89957f1a4a625474" +
+	"c970b0c7bdb0ae0552bece8485640c4bbdbe3e57d23f8d2419d8d5fe63cefa90b239f611a13d2768212ad616025f3989fecb6834f3644ed914d75f08b3dfbfe4" +
+	"97731faec81f84136a312bd91ec337e82524fc5e00eddc07f59823320ff38db34224bcc5502fd7bd572adcb0ef53e4c16a35f37ab8b90e908016a649588ad191" +
+	"7fd5fb489c105cd2e59470eed23c90c7d9370f6406bf7ebde494a658cfa1b93515c9894085dead882195e381bde00de045d1e1d4378d0dd80076c647c12dfe64" +
+	"41768ca16424331a8e8694c8442280bbd5cb6c1b6d504ae2da853d089f56100e2acc709a43fadf2ff110dde85d2ad3f9f74854931cfd1a45cc769a444cee2538" +
+	"17d66ac7d8d2e0088a63d86608dbe29d1147ae85bb7f8ec87564d70fb2bfe0eb6d130eaece850e9e030e1714d9e9a5bba7eec0fdf5bc660813b7893342b3959d" +
+	"137253f43efdc6214d20b3c3c905a4813522091fd9d35d41193ed8e8478aab5cc2650c19e4278ee10fc1f0ef3872c4cec40db39db6384193e67e7e105a781bfa" +
+	"fcdfa8e88e1c85c5b893b8a442b4bec0ed103f2f01c756b92a8ed8bc184632f9344c16ea3062457171cec635df6b1994cd1737c23cb37c32529b8a810db30af3" +
+	"376378f3f230bf58fdc564654acf8aeb082e3c4df005516d1522a7683f7a7092874861d46c44f605da94de8b004141b30152afadfbe54744b0c1deaf8f13221c" +
+	"050a9f4c967c1e5ba7bf78f579133c47767dda12cfa827e76fe8e4cf31483e883add009639ed4eed93f4956d93449659c83ec23a7bd30af8a55c8e6921a3b169" +
+	"59b3f1386a517a8c9416c838362e9ae08827f45bb10c1d222694aef09b15d79140f8c0aebcfd88394fb764371b67ef88e64c4140f34012179a394dcacd9e1cac" +
+	"e336bf723be8fea3d5e52e455e4f49f3900bed703acba38f27bfa3319445c4ec2eddbf9de7f9a1168cdc603c2c642764ddd0accd7809e98e4d36c838c2a57cda" +
+	"a9444cae82ce4de5ced4377ceee1922d10c96392262b4a57875a95fc4418a5953be192580854ee92af29e0949d4fddd15ac811279e8e8efc95183679117fe9c4" +
+	"3a26ad455960a07fba34fab01386ea50072a0c5c026d1fbda924525f3dfabac3bcb69a7d2f800ca81872707d4ee0af663768506c54a9a036d4d9c3fc3c20f8cc" +
+	"2203ca5f8de285f70f4919a8044d39fca06f484084f4f29471c2dfd3df9e6d1e1ab2de12287dcea64e91eaa7c9c4caa063710f4637983e66269d4c55cf24a1cc" +
+	"d1f02a08fd00ef4154dddd104040cd15f588c93d030afb06b35d7b06c3150e00fe3421dd24bcc0beaedb8185bb36d4e2f7a4493b98fe5613ab335475de06b3e7" +
+	"5766e9c662973a3ba91c0071606e4fd56ef9cf9e174be2a42d8158207dcb81eebde31daccc1ebc3befcedf6316f929740c1f54c9c95e1e890d0a12ca2edd0f26" +
+	"5b5c3381dc8b1c2e719a4382862481e9d990f70acab53dc63bd502d9c99473ca00c452a604c137921e7bc050a776f03ededf95634fad43d1df4a239f047595ef" +
+	"220882097b282bbdebd72ae26ab6db46930e9ed585943a7cfd3597b134ebd74ea45bed2e3e06601df441d7c2c9032e182b15e6b82276d4a450146b533bdcc662" +
+	"c9eb3d78ef75ce870272c0271c949dde533ffa6cb4b9c70224fd877054b500d2d6192126f4659d11dff75f624cf2304c92cfdcc1fbf02d57bef75c69ad9502e3" +
+	"87ab0f3c8a225d8486bddf480c5b10f9442bd52a0da149e1ad34185767a663a721218c7d06af3e6ae29f5da9bdb16e70856c3341dc58b8ab7cc133cfcace0798" +
+	"123ce6c4735477ccd8e10499a0bc2d992e084a5e438605fe967da5a24d0f66f769f78e2b321282717fffece8347b3aa78fcdd633e53b6709c2025c89a6da9538" +
+	"aa643b833718a85477817ad8af7b5986034cdae1a4816c7449c11a628577af65ad999eb00d08ac57053adf533b2563001d08b001a65d46970e00df0f83b692fb" +
+	"8683fbd62211b706e53c4aa30db159d14235d0ac88fe1fc4fc994277a3838cdd84a0a08061f85cc1575831e7b56b87ffeb5e404e64b72c36966323f98e8a1920" +
+	"2fa7f3c187e925da291fe4c3e34a06c0c5ceb76bb7f8ccc0436a0001db12b261bd47675c2490c914401694fdc04118372678ad2ae171f40b51c6cb4d40c84932" +
+	"0f58b877cb72b222f2e4562afc4c2ff91267f81bcf6d31db8bf838f6ec3a3c45"
+
+const acvpSigGenMessage = "4f4c7e0134be5200c4512299d134770a64a76b73a82463fd8c86594939dcfd9dc55b895b32a2e96b8afdb8ca83ab857679c372cd88754cd8a7b0a31d2addfd7d" +
+	"1ba64556aaf1cdd674f3e8f5fc0bad2fa38326365918430ab2344cff785d5f73f2b5d631db29faa0f9cce5cb7ffe0cf4af1c7a8950ef32f1d72080a492c7a25a" +
+	"bf67f409ff5d4b1e0d77268c0a1b2a32d9dec61bb71edae6bfd58f274707182058f0e6aa31e6d3763732a82bd6f2c76647c7acaae7fb4aa51125f0d2d48351b6" +
+	"a3fc7fd18172fa8689ae1602c4ec0cafa863aa98bdbb1cd8c2681c2b6c5c254e346c18e2a270caf2606a6504d30c0e2e505c2ff9d18523bbdf21424c645af0ef" +
+	"b2ea0fd21b5d0cd85c7c1ee176fcf904b481855c4cd739443f3340ae48276e7f4bdc00cd11c2b0d6b97bd00ac962ee1fcf8a73d3da3ccbb3b72095cb33c5542d" +
+	"86e843641cc98e27545f99188af064d5fe74739c54f5678f411d96a0ea043652935bfb2e37ec934327c7c841cb0cd04ec17fd06a18e88882177b51b00db6ef1d" +
+	"a164245a3f2554cede8c84dd777f0b92cda456d922d8b7b8b63b548cbb72cfaca540c0d69f9ef21759f243cfa03ebd6b080d23dd62945e623bc4f8323daec121" +
+	"5b251c35ea13a0f081b86e803bf37dae6d913b7d942bd1c276abea3f8f74d0c8727ec21eed2afd438bb7"
+
+func TestACVPKeyGenVector(t *testing.T) {
+	var seed [SEED_BYTES]uint8
+	if _, err := hex.Decode(seed[:], []byte(acvpKeyGenSeed)); err != nil {
+		t.Fatal(err)
+	}
+	var pk [CRYPTO_PUBLIC_KEY_BYTES]uint8
+	var sk [CRYPTO_SECRET_KEY_BYTES]uint8
+	if _, err := cryptoSignKeypair(&seed, &pk, &sk); err != nil {
+		t.Fatal(err)
+	}
+	if sum := sha256.Sum256(pk[:]); hex.EncodeToString(sum[:]) != acvpKeyGenPKSum {
+		t.Errorf("SHA-256(pk) = %x, want %s", sum, acvpKeyGenPKSum)
+	}
+	if sum := sha256.Sum256(sk[:]); hex.EncodeToString(sum[:]) != acvpKeyGenSKSum {
+		t.Errorf("SHA-256(sk) = %x, want %s", sum, acvpKeyGenSKSum)
+	}
+}
+
+func TestACVPSigGenVector(t *testing.T) {
+	var sk [CRYPTO_SECRET_KEY_BYTES]uint8
+	if _, err := hex.Decode(sk[:], []byte(acvpSigGenSK)); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := hex.DecodeString(acvpSigGenMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rnd [RND_BYTES]uint8 // deterministic variant
+	sig := make([]uint8, CRYPTO_BYTES)
+	if err := cryptoSignSignatureInternal(sig, msg, nil, rnd, &sk); err != nil {
+		t.Fatal(err)
+	}
+	if sum := sha256.Sum256(sig); hex.EncodeToString(sum[:]) != acvpSigGenSigSum {
+		t.Errorf("SHA-256(signature) = %x, want %s", sum, acvpSigGenSigSum)
+	}
+}
@@ -0,0 +1,100 @@
+package ml_dsa_87
+
+import (
+	"runtime"
+	"sync"
+)
+
+// BatchItem is one (public key, context, message, signature) tuple
+// for [VerifyBatch].
+type BatchItem struct {
+	PK        *[CRYPTO_PUBLIC_KEY_BYTES]uint8
+	Context   []uint8
+	Message   []uint8
+	Signature [CRYPTO_BYTES]uint8
+}
+
+// batchKey is the lazily prepared public key shared by every item in
+// a batch that uses the same public key.
+type batchKey struct {
+	once     sync.Once
+	pk       *[CRYPTO_PUBLIC_KEY_BYTES]uint8
+	prepared *PreparedPublicKey
+}
+
+func (k *batchKey) get() *PreparedPublicKey {
+	k.once.Do(func() {
+		// PreparePublicKey only fails for a nil key, which never
+		// reaches a batchKey.
+		k.prepared, _ = PreparePublicKey(k.pk)
+	})
+	return k.prepared
+}
+
+// VerifyBatch verifies every item and returns one result per item, in
+// order: results[i] is exactly what [Verify] would return for
+// items[i]. An item with a nil PK or a context longer than 255 bytes
+// is reported as false without affecting the other items.
+//
+// Work is spread over at most runtime.GOMAXPROCS(0) goroutines. Items
+// that repeat a public key share a single [PreparedPublicKey], so
+// batches dominated by a few hot keys cost little more than the
+// per-signature arithmetic.
+//
+// There is no aggregate shortcut: ML-DSA has no batch-verification
+// equation, so each signature is checked individually and a single
+// invalid item never masks or taints the others.
+func VerifyBatch(items []BatchItem) []bool {
+	return verifyBatch(items, runtime.GOMAXPROCS(0))
+}
+
+func verifyBatch(items []BatchItem, workers int) []bool {
+	results := make([]bool, len(items))
+	if len(items) == 0 {
+		return results
+	}
+
+	// Group items by public key. Map keys are the full key bytes, so
+	// two distinct keys can never share state.
+	keys := make(map[[CRYPTO_PUBLIC_KEY_BYTES]uint8]*batchKey)
+	itemKeys := make([]*batchKey, len(items))
+	for i := range items {
+		pk := items[i].PK
+		if pk == nil {
+			continue
+		}
+		k, ok := keys[*pk]
+		if !ok {
+			k = &batchKey{pk: pk}
+			keys[*pk] = k
+		}
+		itemKeys[i] = k
+	}
+
+	workers = max(1, min(workers, len(items)))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = verifyBatchItem(&items[i], itemKeys[i])
+			}
+		}()
+	}
+	for i := range items {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return results
+}
+
+func verifyBatchItem(item *BatchItem, k *batchKey) bool {
+	if k == nil {
+		return false
+	}
+	return k.get().Verify(item.Context, item.Message, item.Signature)
+}
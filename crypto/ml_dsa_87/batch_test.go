@@ -0,0 +1,105 @@
+package ml_dsa_87
+
+import (
+	"fmt"
+	"testing"
+)
+
+// newBatchFixture builds a batch over two keys where every third item
+// is corrupted and a handful of items carry malformed inputs.
+func newBatchFixture(t testing.TB, n int) ([]BatchItem, []bool) {
+	t.Helper()
+	signers := make([]*MLDSA87, 2)
+	pks := make([][CRYPTO_PUBLIC_KEY_BYTES]uint8, 2)
+	for i := range signers {
+		d, err := New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers[i] = d
+		pks[i] = d.GetPK()
+	}
+
+	items := make([]BatchItem, n)
+	want := make([]bool, n)
+	for i := range items {
+		k := i % 2
+		ctx := []byte(fmt.Sprintf("ctx-%d", i%5))
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sig, err := signers[k].Sign(ctx, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk := pks[k]
+		items[i] = BatchItem{PK: &pk, Context: ctx, Message: msg, Signature: sig}
+		want[i] = true
+		if i%3 == 0 {
+			items[i].Signature[i%CRYPTO_BYTES] ^= 0x01
+			want[i] = false
+		}
+	}
+	return items, want
+}
+
+func TestVerifyBatchPerItemResults(t *testing.T) {
+	items, want := newBatchFixture(t, 24)
+
+	// Inputs the single-item Verify rejects must be reported per item.
+	items[1].PK = nil
+	want[1] = false
+	items[5].Context = make([]byte, 256)
+	want[5] = false
+	items[7].Message = []byte("tampered")
+	want[7] = false
+
+	for _, workers := range []int{1, 3, 64} {
+		t.Run(fmt.Sprintf("workers=%d", workers), func(t *testing.T) {
+			got := verifyBatch(items, workers)
+			if len(got) != len(items) {
+				t.Fatalf("got %d results for %d items", len(got), len(items))
+			}
+			for i := range items {
+				if got[i] != want[i] {
+					t.Errorf("item %d: got %v, want %v", i, got[i], want[i])
+				}
+				if single := Verify(items[i].Context, items[i].Message, items[i].Signature, items[i].PK); got[i] != single {
+					t.Errorf("item %d: batch result %v differs from Verify %v", i, got[i], single)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyBatchEmpty(t *testing.T) {
+	if got := VerifyBatch(nil); len(got) != 0 {
+		t.Errorf("VerifyBatch(nil) returned %d results", len(got))
+	}
+}
+
+// TestVerifyBatchDistinctKeysSameRho checks that key sharing is keyed
+// on the whole public key: two keys with equal rho but different t1
+// must not share expanded state.
+func TestVerifyBatchDistinctKeysSameRho(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+	msg := []byte("msg")
+	sig, err := d.Sign(nil, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := pk
+	other[CRYPTO_PUBLIC_KEY_BYTES-1] ^= 0x01
+
+	got := VerifyBatch([]BatchItem{
+		{PK: &pk, Message: msg, Signature: sig},
+		{PK: &other, Message: msg, Signature: sig},
+	})
+	if !got[0] || got[1] {
+		t.Errorf("got %v, want [true false]", got)
+	}
+}
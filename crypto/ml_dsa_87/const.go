@@ -49,6 +49,7 @@ const (
 	SEED_BYTES = 32
 	CRH_BYTES  = 64 // hash of public key
 	TR_BYTES   = 64
+	MU_BYTES   = CRH_BYTES // message representative mu (ComputeMu, SignMu)
 	RND_BYTES  = 32
 	N          = 256
 	Q          = 8380417
@@ -65,6 +65,32 @@ func ExampleNewMLDSA87FromSeed() {
 	// Output: Public key generated: true
 }
 
+// ExampleNewMLDSA87FromSecretKey demonstrates importing an expanded
+// secret key that was exported without its seed.
+func ExampleNewMLDSA87FromSecretKey() {
+	m, err := ml_dsa_87.New()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer m.Zeroize()
+	sk, pk := m.GetSK(), m.GetPK()
+
+	imported, err := ml_dsa_87.NewMLDSA87FromSecretKey(&sk, &pk)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer imported.Zeroize()
+
+	_, err = imported.GetSeed()
+	fmt.Println("Same public key:", imported.GetPK() == pk)
+	fmt.Println("Seed available:", err == nil)
+	// Output:
+	// Same public key: true
+	// Seed available: false
+}
+
 // ExampleMLDSA87_Sign demonstrates signing with context.
 func ExampleMLDSA87_Sign() {
 	m, _ := ml_dsa_87.New()
@@ -154,3 +180,75 @@ func ExampleOpen() {
 	fmt.Println("Recovered:", string(message))
 	// Output: Recovered: example transaction payload
 }
+
+// ExampleMLDSA87_NewSigningStream demonstrates signing a message that is
+// written incrementally, for example from a file via io.Copy.
+func ExampleMLDSA87_NewSigningStream() {
+	m, err := ml_dsa_87.New()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer m.Zeroize()
+
+	ctx := []byte("my-application")
+	stream, err := m.NewSigningStream(ctx)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	_, _ = stream.Write([]byte("Hello, "))
+	_, _ = stream.Write([]byte("FIPS 204!"))
+	signature, err := stream.Sign()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	pk := m.GetPK()
+	verifier, err := ml_dsa_87.NewVerifyingStream(&pk, ctx)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	_, _ = verifier.Write([]byte("Hello, FIPS 204!"))
+	fmt.Println("Signature valid:", verifier.Verify(signature))
+	// Output: Signature valid: true
+}
+
+// ExampleMarshalPKCS8PrivateKeyPEM demonstrates exporting a key pair as
+// PEM for use with OpenSSL and importing it again.
+func ExampleMarshalPKCS8PrivateKeyPEM() {
+	m, err := ml_dsa_87.New()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer m.Zeroize()
+
+	privPEM, err := ml_dsa_87.MarshalPKCS8PrivateKeyPEM(m, ml_dsa_87.PRIVATE_KEY_SEED)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	pubPEM, err := ml_dsa_87.MarshalPKIXPublicKeyPEM(ml_dsa_87.NewCryptoSigner(m).Public())
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	imported, err := ml_dsa_87.ParsePKCS8PrivateKeyPEM(privPEM)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer imported.Zeroize()
+	pub, err := ml_dsa_87.ParsePKIXPublicKeyPEM(pubPEM)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Println("Same key:", pub.Equal(ml_dsa_87.NewCryptoSigner(imported).Public()))
+	// Output: Same key: true
+}
@@ -0,0 +1,29 @@
+// Package jose implements JSON Web Signature (RFC 7515) compact
+// serialization and JSON Web Keys (RFC 7517) for ML-DSA-87, as
+// registered by the IETF JOSE/COSE ML-DSA draft.
+//
+// # Algorithm and key type
+//
+// Signatures use the "ML-DSA-87" alg value. They are pure ML-DSA-87
+// signatures over the JWS signing input with an empty context, exactly
+// what [github.com/theQRL/go-qrllib/crypto/ml_dsa_87.CryptoSigner]
+// produces with nil options and [ml_dsa_87.Verify] checks with a nil
+// context.
+//
+// Keys use the "AKP" (Algorithm Key Pair) key type:
+//
+//	{"kty":"AKP","alg":"ML-DSA-87","pub":"<base64url public key>"}
+//
+// A private JWK adds "priv", the base64url 32-byte seed. Keys that
+// were imported from an expanded secret key have no seed and cannot be
+// written as private JWKs. [JWK.Thumbprint] computes the RFC 7638
+// thumbprint over the required members alg, kty and pub.
+//
+// # Verification policy
+//
+// [Verify] and [VerifyWithKeySet] accept only alg "ML-DSA-87", so a
+// token cannot downgrade itself to another algorithm, and reject any
+// "crit" header parameter since this package understands none.
+// Base64url segments must be unpadded and canonical. JWT claim checks
+// (exp, nbf, aud, ...) are left to the caller.
+package jose
@@ -0,0 +1,47 @@
+package jose_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87/jose"
+)
+
+// Example signs a JWS, publishes the public key as a JWK Set and
+// verifies the token against the set.
+func Example() {
+	key, err := ml_dsa_87.New()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer key.Zeroize()
+
+	token, err := jose.Sign(nil, ml_dsa_87.NewCryptoSigner(key), []byte(`{"sub":"alice"}`),
+		jose.Header{KeyID: "2026-10", Type: "JWT"})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	set := &jose.JWKSet{Keys: []*jose.JWK{{KeyID: "2026-10", PrivateKey: key}}}
+	published, err := json.Marshal(set.Public())
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	var received jose.JWKSet
+	if err := json.Unmarshal(published, &received); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	payload, header, _, err := jose.VerifyWithKeySet(token, &received)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println(header.Algorithm, header.KeyID, string(payload))
+	// Output: ML-DSA-87 2026-10 {"sub":"alice"}
+}
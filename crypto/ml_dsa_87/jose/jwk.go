@@ -0,0 +1,234 @@
+package jose
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"runtime"
+
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+)
+
+// KEY_TYPE is the JWK "kty" value for ML-DSA keys.
+const KEY_TYPE = "AKP"
+
+var (
+	// ErrInvalidJWK is returned for JWKs with missing or malformed
+	// members.
+	ErrInvalidJWK = errors.New("jose: invalid JWK")
+	// ErrKeyMismatch is returned when a JWK's private and public keys
+	// do not belong together.
+	ErrKeyMismatch = errors.New("jose: private key does not match public key")
+)
+
+// JWK is an ML-DSA-87 JSON Web Key. A public JWK sets PublicKey; a
+// private JWK sets PrivateKey, and PublicKey may then be left nil.
+type JWK struct {
+	KeyID  string
+	Use    string
+	KeyOps []string
+
+	PublicKey  *ml_dsa_87.CryptoPublicKey
+	PrivateKey *ml_dsa_87.MLDSA87
+}
+
+// jwkJSON is the wire form. Member order matches the draft's examples.
+type jwkJSON struct {
+	KeyType   string   `json:"kty"`
+	Algorithm string   `json:"alg"`
+	KeyID     string   `json:"kid,omitempty"`
+	Use       string   `json:"use,omitempty"`
+	KeyOps    []string `json:"key_ops,omitempty"`
+	Public    string   `json:"pub"`
+	Private   string   `json:"priv,omitempty"`
+}
+
+// publicKey returns the key's public half, deriving it from the
+// private key when PublicKey is unset.
+func (k *JWK) publicKey() *ml_dsa_87.CryptoPublicKey {
+	if k == nil {
+		return nil
+	}
+	if k.PublicKey != nil {
+		return k.PublicKey
+	}
+	if k.PrivateKey != nil {
+		return ml_dsa_87.NewCryptoSigner(k.PrivateKey).Public().(*ml_dsa_87.CryptoPublicKey)
+	}
+	return nil
+}
+
+// Public returns a copy of k without the private key.
+func (k *JWK) Public() *JWK {
+	return &JWK{
+		KeyID:     k.KeyID,
+		Use:       k.Use,
+		KeyOps:    append([]string(nil), k.KeyOps...),
+		PublicKey: k.publicKey(),
+	}
+}
+
+// IsPrivate reports whether k carries a private key.
+func (k *JWK) IsPrivate() bool {
+	return k.PrivateKey != nil
+}
+
+// MarshalJSON encodes k as an AKP JWK. Private keys are written as
+// their seed in "priv"; for a key without one it returns the
+// [ml_dsa_87.MLDSA87.GetSeed] error. The output of a private JWK is
+// secret and, being a Go string internally, cannot be wiped.
+func (k *JWK) MarshalJSON() ([]byte, error) {
+	pub := k.publicKey()
+	if pub == nil {
+		return nil, ErrInvalidJWK
+	}
+	if k.PrivateKey != nil && k.PublicKey != nil &&
+		!k.PublicKey.Equal(ml_dsa_87.NewCryptoSigner(k.PrivateKey).Public()) {
+		return nil, ErrKeyMismatch
+	}
+
+	pk := pub.Bytes()
+	out := jwkJSON{
+		KeyType:   KEY_TYPE,
+		Algorithm: ALGORITHM,
+		KeyID:     k.KeyID,
+		Use:       k.Use,
+		KeyOps:    k.KeyOps,
+		Public:    encoding.EncodeToString(pk[:]),
+	}
+	if k.PrivateKey != nil {
+		seed, err := k.PrivateKey.GetSeed()
+		if err != nil {
+			return nil, err
+		}
+		out.Private = encoding.EncodeToString(seed[:])
+		zeroBytes(seed[:])
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes an AKP JWK with alg ML-DSA-87. A "priv" member
+// is expanded from its seed and must yield the key in "pub".
+func (k *JWK) UnmarshalJSON(data []byte) error {
+	var in jwkJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return ErrInvalidJWK
+	}
+	if in.KeyType != KEY_TYPE || in.Algorithm != ALGORITHM {
+		return ErrUnsupportedAlgorithm
+	}
+
+	pkBytes, err := encoding.DecodeString(in.Public)
+	if err != nil || len(pkBytes) != ml_dsa_87.CRYPTO_PUBLIC_KEY_BYTES {
+		return ErrInvalidJWK
+	}
+	var pk [ml_dsa_87.CRYPTO_PUBLIC_KEY_BYTES]uint8
+	copy(pk[:], pkBytes)
+	pub := ml_dsa_87.NewCryptoPublicKey(&pk)
+
+	var priv *ml_dsa_87.MLDSA87
+	if in.Private != "" {
+		seedBytes, err := encoding.DecodeString(in.Private)
+		defer zeroBytes(seedBytes)
+		if err != nil || len(seedBytes) != ml_dsa_87.SEED_BYTES {
+			return ErrInvalidJWK
+		}
+		var seed [ml_dsa_87.SEED_BYTES]uint8
+		copy(seed[:], seedBytes)
+		priv, err = ml_dsa_87.NewMLDSA87FromSeed(seed)
+		zeroBytes(seed[:])
+		if err != nil {
+			//coverage:ignore
+			//rationale: NewMLDSA87FromSeed only fails if sha3 operations fail, which never happens
+			return err
+		}
+		if priv.GetPK() != pk {
+			priv.Zeroize()
+			return ErrKeyMismatch
+		}
+	}
+
+	*k = JWK{
+		KeyID:      in.KeyID,
+		Use:        in.Use,
+		KeyOps:     in.KeyOps,
+		PublicKey:  pub,
+		PrivateKey: priv,
+	}
+	return nil
+}
+
+// Thumbprint returns the RFC 7638 SHA-256 thumbprint of k: the hash of
+// {"alg":"ML-DSA-87","kty":"AKP","pub":"..."} with no whitespace.
+// The thumbprint of a private JWK equals that of its public JWK.
+func (k *JWK) Thumbprint() ([]byte, error) {
+	pub := k.publicKey()
+	if pub == nil {
+		return nil, ErrInvalidJWK
+	}
+	pk := pub.Bytes()
+	canonical := `{"alg":"` + ALGORITHM + `","kty":"` + KEY_TYPE + `","pub":"` + encoding.EncodeToString(pk[:]) + `"}`
+	sum := sha256.Sum256([]byte(canonical))
+	return sum[:], nil
+}
+
+// JWKSet is a JSON Web Key Set (RFC 7517 §5).
+type JWKSet struct {
+	Keys []*JWK `json:"keys"`
+}
+
+// UnmarshalJSON decodes a JWK Set. Keys of other types or algorithms
+// are skipped, as RFC 7517 §5 recommends; a malformed ML-DSA-87 key is
+// an error.
+func (s *JWKSet) UnmarshalJSON(data []byte) error {
+	var in struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &in); err != nil || in.Keys == nil {
+		return ErrInvalidJWK
+	}
+
+	keys := make([]*JWK, 0, len(in.Keys))
+	for _, raw := range in.Keys {
+		k := new(JWK)
+		err := k.UnmarshalJSON(raw)
+		if errors.Is(err, ErrUnsupportedAlgorithm) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		keys = append(keys, k)
+	}
+	s.Keys = keys
+	return nil
+}
+
+// LookupKeyID returns the keys in s whose kid is kid.
+func (s *JWKSet) LookupKeyID(kid string) []*JWK {
+	var out []*JWK
+	for _, k := range s.Keys {
+		if k.KeyID == kid {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// Public returns a copy of s with every private key removed.
+func (s *JWKSet) Public() *JWKSet {
+	out := &JWKSet{Keys: make([]*JWK, 0, len(s.Keys))}
+	for _, k := range s.Keys {
+		out.Keys = append(out.Keys, k.Public())
+	}
+	return out
+}
+
+// zeroBytes overwrites b with zeros. runtime.KeepAlive prevents the compiler
+// from eliding the writes as a dead store.
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+	runtime.KeepAlive(&b)
+}
@@ -0,0 +1,194 @@
+package jose
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+)
+
+func TestJWKPublicRoundTrip(t *testing.T) {
+	d := newTestKey(t, 1)
+	k := &JWK{KeyID: "k1", Use: "sig", PublicKey: publicKeyOf(d)}
+
+	data, err := json.Marshal(k)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	pk := d.GetPK()
+	want := `{"kty":"AKP","alg":"ML-DSA-87","kid":"k1","use":"sig","pub":"` +
+		base64.RawURLEncoding.EncodeToString(pk[:]) + `"}`
+	if string(data) != want {
+		t.Fatalf("JWK = %s", data)
+	}
+
+	var got JWK
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got.KeyID != "k1" || got.Use != "sig" || got.IsPrivate() || !got.PublicKey.Equal(k.PublicKey) {
+		t.Errorf("round trip = %+v", got)
+	}
+}
+
+func TestJWKPrivateRoundTrip(t *testing.T) {
+	d := newTestKey(t, 1)
+	k := &JWK{KeyID: "k1", PrivateKey: d}
+
+	data, err := json.Marshal(k)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	seed, err := d.GetSeed()
+	if err != nil {
+		t.Fatalf("GetSeed: %v", err)
+	}
+	if !strings.Contains(string(data), `"priv":"`+base64.RawURLEncoding.EncodeToString(seed[:])+`"`) {
+		t.Fatalf("private JWK lacks the seed: %s", data)
+	}
+
+	var got JWK
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !got.IsPrivate() || got.PrivateKey.GetSK() != d.GetSK() {
+		t.Fatal("private key changed across round trip")
+	}
+
+	// Public() drops the private half.
+	pubData, err := json.Marshal(got.Public())
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if strings.Contains(string(pubData), "priv") {
+		t.Errorf("public JWK leaks priv: %s", pubData)
+	}
+}
+
+func TestJWKMarshalErrors(t *testing.T) {
+	if _, err := json.Marshal(&JWK{}); !errors.Is(err, ErrInvalidJWK) {
+		t.Errorf("empty JWK error = %v", err)
+	}
+
+	d1, d2 := newTestKey(t, 1), newTestKey(t, 2)
+	if _, err := json.Marshal(&JWK{PublicKey: publicKeyOf(d1), PrivateKey: d2}); !errors.Is(err, ErrKeyMismatch) {
+		t.Errorf("mismatched pair error = %v", err)
+	}
+
+	// A key imported without its seed has no "priv" to write.
+	der, err := ml_dsa_87.MarshalPKCS8PrivateKey(d1, ml_dsa_87.PRIVATE_KEY_EXPANDED)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	seedless, err := ml_dsa_87.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		t.Fatalf("ParsePKCS8PrivateKey: %v", err)
+	}
+	if _, err := json.Marshal(&JWK{PrivateKey: seedless}); !errors.Is(err, cryptoerrors.ErrSeedUnavailable) {
+		t.Errorf("seedless error = %v", err)
+	}
+}
+
+func TestJWKUnmarshalErrors(t *testing.T) {
+	d1, d2 := newTestKey(t, 1), newTestKey(t, 2)
+	pk := d1.GetPK()
+	pub := base64.RawURLEncoding.EncodeToString(pk[:])
+	seed2, err := d2.GetSeed()
+	if err != nil {
+		t.Fatalf("GetSeed: %v", err)
+	}
+	priv2 := base64.RawURLEncoding.EncodeToString(seed2[:])
+
+	tests := []struct {
+		name string
+		json string
+		want error
+	}{
+		{"not_object", `[]`, ErrInvalidJWK},
+		{"okp", `{"kty":"OKP","crv":"Ed25519","x":"AA"}`, ErrUnsupportedAlgorithm},
+		{"other_alg", `{"kty":"AKP","alg":"ML-DSA-44","pub":"` + pub + `"}`, ErrUnsupportedAlgorithm},
+		{"missing_pub", `{"kty":"AKP","alg":"ML-DSA-87"}`, ErrInvalidJWK},
+		{"short_pub", `{"kty":"AKP","alg":"ML-DSA-87","pub":"AAAA"}`, ErrInvalidJWK},
+		{"padded_pub", `{"kty":"AKP","alg":"ML-DSA-87","pub":"` + pub + `=="}`, ErrInvalidJWK},
+		{"short_priv", `{"kty":"AKP","alg":"ML-DSA-87","pub":"` + pub + `","priv":"AAAA"}`, ErrInvalidJWK},
+		{"wrong_priv", `{"kty":"AKP","alg":"ML-DSA-87","pub":"` + pub + `","priv":"` + priv2 + `"}`, ErrKeyMismatch},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var k JWK
+			if err := json.Unmarshal([]byte(tc.json), &k); !errors.Is(err, tc.want) {
+				t.Errorf("error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestJWKThumbprint(t *testing.T) {
+	d := newTestKey(t, 1)
+	pk := d.GetPK()
+	canonical := `{"alg":"ML-DSA-87","kty":"AKP","pub":"` + base64.RawURLEncoding.EncodeToString(pk[:]) + `"}`
+	want := sha256.Sum256([]byte(canonical))
+
+	for _, k := range []*JWK{
+		{PublicKey: publicKeyOf(d)},
+		{KeyID: "ignored", Use: "sig", PrivateKey: d},
+	} {
+		got, err := k.Thumbprint()
+		if err != nil {
+			t.Fatalf("Thumbprint: %v", err)
+		}
+		if !bytes.Equal(got, want[:]) {
+			t.Errorf("Thumbprint = %x, want %x", got, want)
+		}
+	}
+	if _, err := (&JWK{}).Thumbprint(); !errors.Is(err, ErrInvalidJWK) {
+		t.Errorf("empty JWK error = %v", err)
+	}
+}
+
+func TestJWKSet(t *testing.T) {
+	d1, d2 := newTestKey(t, 1), newTestKey(t, 2)
+	set := &JWKSet{Keys: []*JWK{
+		{KeyID: "one", PrivateKey: d1},
+		{KeyID: "two", PublicKey: publicKeyOf(d2)},
+	}}
+	data, err := json.Marshal(set.Public())
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if strings.Contains(string(data), "priv") {
+		t.Fatalf("public set leaks priv: %s", data)
+	}
+
+	// Splice in keys this package does not handle; they are skipped.
+	mixed := strings.Replace(string(data), `{"keys":[`,
+		`{"keys":[{"kty":"EC","crv":"P-256","x":"AA","y":"AA"},{"kty":"AKP","alg":"ML-DSA-44","pub":"AA"},`, 1)
+	var got JWKSet
+	if err := json.Unmarshal([]byte(mixed), &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(got.Keys) != 2 {
+		t.Fatalf("got %d keys, want 2", len(got.Keys))
+	}
+	if ks := got.LookupKeyID("two"); len(ks) != 1 || !ks[0].PublicKey.Equal(publicKeyOf(d2)) {
+		t.Errorf("LookupKeyID(two) = %v", ks)
+	}
+	if ks := got.LookupKeyID("three"); len(ks) != 0 {
+		t.Errorf("LookupKeyID(three) = %v", ks)
+	}
+
+	// A malformed ML-DSA-87 key fails the whole set.
+	bad := `{"keys":[{"kty":"AKP","alg":"ML-DSA-87","pub":"AA"}]}`
+	if err := json.Unmarshal([]byte(bad), &got); !errors.Is(err, ErrInvalidJWK) {
+		t.Errorf("malformed member error = %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{}`), &got); !errors.Is(err, ErrInvalidJWK) {
+		t.Errorf("missing keys error = %v", err)
+	}
+}
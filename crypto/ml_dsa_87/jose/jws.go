@@ -0,0 +1,184 @@
+package jose
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+)
+
+// ALGORITHM is the JOSE "alg" value for ML-DSA-87.
+const ALGORITHM = "ML-DSA-87"
+
+var (
+	// ErrMalformedJWS is returned for tokens that are not three
+	// canonical base64url segments with a JSON object header.
+	ErrMalformedJWS = errors.New("jose: malformed compact JWS")
+	// ErrUnsupportedAlgorithm is returned for alg values other than
+	// ML-DSA-87 and for JWKs of another key type.
+	ErrUnsupportedAlgorithm = errors.New("jose: algorithm is not ML-DSA-87")
+	// ErrUnsupportedCritical is returned when a JWS header lists
+	// critical extensions, none of which this package implements.
+	ErrUnsupportedCritical = errors.New("jose: unsupported critical header parameter")
+	// ErrInvalidSignature is returned when a signature does not verify.
+	ErrInvalidSignature = errors.New("jose: invalid signature")
+	// ErrKeyNotFound is returned by [VerifyWithKeySet] when the set
+	// holds no candidate key.
+	ErrKeyNotFound = errors.New("jose: no matching key in set")
+)
+
+// encoding is unpadded base64url that rejects non-zero trailing bits,
+// so every segment has exactly one accepted spelling.
+var encoding = base64.RawURLEncoding.Strict()
+
+// Header is the protected JWS header. Algorithm is always ML-DSA-87;
+// [Sign] fills it in when empty.
+type Header struct {
+	Algorithm   string `json:"alg"`
+	KeyID       string `json:"kid,omitempty"`
+	Type        string `json:"typ,omitempty"`
+	ContentType string `json:"cty,omitempty"`
+}
+
+// parsedHeader adds the members Verify must look at but Sign never
+// writes.
+type parsedHeader struct {
+	Header
+	Critical json.RawMessage `json:"crit"`
+}
+
+// Sign returns the compact serialization of a JWS over payload,
+// signed by signer with the protected header h. h.Algorithm must be
+// empty or ML-DSA-87.
+//
+// rand is passed to [ml_dsa_87.CryptoSigner.SignMessage]; nil selects
+// crypto/rand.
+func Sign(rand io.Reader, signer *ml_dsa_87.CryptoSigner, payload []byte, h Header) (string, error) {
+	if h.Algorithm == "" {
+		h.Algorithm = ALGORITHM
+	}
+	if h.Algorithm != ALGORITHM {
+		return "", ErrUnsupportedAlgorithm
+	}
+	headerJSON, err := json.Marshal(h)
+	if err != nil {
+		//coverage:ignore
+		//rationale: Header has only string fields, which always marshal
+		return "", err
+	}
+
+	signingInput := encoding.EncodeToString(headerJSON) + "." + encoding.EncodeToString(payload)
+	sig, err := signer.SignMessage(rand, []byte(signingInput), nil)
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + encoding.EncodeToString(sig), nil
+}
+
+// compactJWS is a decoded, not yet verified, compact JWS.
+type compactJWS struct {
+	header       Header
+	payload      []byte
+	signingInput []byte
+	signature    [ml_dsa_87.CRYPTO_BYTES]uint8
+}
+
+func parseCompact(token string) (*compactJWS, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedJWS
+	}
+
+	headerJSON, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrMalformedJWS
+	}
+	var h parsedHeader
+	if err := json.Unmarshal(headerJSON, &h); err != nil {
+		return nil, ErrMalformedJWS
+	}
+	if h.Algorithm != ALGORITHM {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	if len(h.Critical) != 0 {
+		return nil, ErrUnsupportedCritical
+	}
+
+	payload, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformedJWS
+	}
+	sig, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedJWS
+	}
+	if len(sig) != ml_dsa_87.CRYPTO_BYTES {
+		return nil, ErrInvalidSignature
+	}
+
+	jws := &compactJWS{
+		header:       h.Header,
+		payload:      payload,
+		signingInput: []byte(token[:len(parts[0])+1+len(parts[1])]),
+	}
+	copy(jws.signature[:], sig)
+	return jws, nil
+}
+
+func (jws *compactJWS) verify(pub *ml_dsa_87.CryptoPublicKey) bool {
+	pk := pub.Bytes()
+	return ml_dsa_87.Verify(nil, jws.signingInput, jws.signature, &pk)
+}
+
+// Verify checks a compact JWS against pub and returns its payload and
+// protected header.
+func Verify(token string, pub *ml_dsa_87.CryptoPublicKey) ([]byte, *Header, error) {
+	if pub == nil {
+		return nil, nil, ErrKeyNotFound
+	}
+	jws, err := parseCompact(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !jws.verify(pub) {
+		return nil, nil, ErrInvalidSignature
+	}
+	return jws.payload, &jws.header, nil
+}
+
+// VerifyWithKeySet checks a compact JWS against the keys in set. If
+// the header carries a kid, only keys with that kid are tried;
+// otherwise every key is. It returns the payload, the protected header
+// and the key that verified.
+func VerifyWithKeySet(token string, set *JWKSet) ([]byte, *Header, *JWK, error) {
+	jws, err := parseCompact(token)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var candidates []*JWK
+	if set != nil {
+		candidates = set.Keys
+		if jws.header.KeyID != "" {
+			candidates = set.LookupKeyID(jws.header.KeyID)
+		}
+	}
+	tried := false
+	for _, k := range candidates {
+		pub := k.publicKey()
+		if pub == nil {
+			continue
+		}
+		tried = true
+		if jws.verify(pub) {
+			return jws.payload, &jws.header, k, nil
+		}
+	}
+	if !tried {
+		return nil, nil, nil, ErrKeyNotFound
+	}
+	return nil, nil, nil, ErrInvalidSignature
+}
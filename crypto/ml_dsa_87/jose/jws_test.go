@@ -0,0 +1,188 @@
+package jose
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+)
+
+func newTestKey(t *testing.T, b byte) *ml_dsa_87.MLDSA87 {
+	t.Helper()
+	var seed [ml_dsa_87.SEED_BYTES]uint8
+	for i := range seed {
+		seed[i] = b
+	}
+	d, err := ml_dsa_87.NewMLDSA87FromSeed(seed)
+	if err != nil {
+		t.Fatalf("NewMLDSA87FromSeed: %v", err)
+	}
+	return d
+}
+
+func publicKeyOf(d *ml_dsa_87.MLDSA87) *ml_dsa_87.CryptoPublicKey {
+	pk := d.GetPK()
+	return ml_dsa_87.NewCryptoPublicKey(&pk)
+}
+
+func TestSignVerify(t *testing.T) {
+	d := newTestKey(t, 1)
+	payload := []byte(`{"sub":"1234567890","iat":1516239022}`)
+
+	token, err := Sign(nil, ml_dsa_87.NewCryptoSigner(d), payload, Header{KeyID: "k1", Type: "JWT"})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token has %d segments", len(parts))
+	}
+	headerJSON, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	if string(headerJSON) != `{"alg":"ML-DSA-87","kid":"k1","typ":"JWT"}` {
+		t.Errorf("header = %s", headerJSON)
+	}
+
+	got, h, err := Verify(token, publicKeyOf(d))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("payload = %s", got)
+	}
+	if h.Algorithm != ALGORITHM || h.KeyID != "k1" || h.Type != "JWT" {
+		t.Errorf("header = %+v", h)
+	}
+
+	// The signature is plain ML-DSA-87 over the signing input with an
+	// empty context.
+	sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	var sigArr [ml_dsa_87.CRYPTO_BYTES]uint8
+	copy(sigArr[:], sig)
+	pk := d.GetPK()
+	if !ml_dsa_87.Verify(nil, []byte(parts[0]+"."+parts[1]), sigArr, &pk) {
+		t.Error("signature does not verify with ml_dsa_87.Verify and an empty context")
+	}
+}
+
+func TestSignRejectsOtherAlgorithm(t *testing.T) {
+	d := newTestKey(t, 1)
+	if _, err := Sign(nil, ml_dsa_87.NewCryptoSigner(d), nil, Header{Algorithm: "EdDSA"}); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("error = %v, want %v", err, ErrUnsupportedAlgorithm)
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	d := newTestKey(t, 1)
+	signer := ml_dsa_87.NewCryptoSigner(d)
+	token, err := Sign(nil, signer, []byte("payload"), Header{})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	parts := strings.Split(token, ".")
+	enc := base64.RawURLEncoding.EncodeToString
+
+	// A signature that is valid for its header, so only the header
+	// check can reject it.
+	signWithHeader := func(header string) string {
+		input := enc([]byte(header)) + "." + parts[1]
+		sig, err := signer.Sign(nil, []byte(input), nil)
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		return input + "." + enc(sig)
+	}
+
+	flipped := []byte(parts[2])
+	if flipped[10] == 'A' {
+		flipped[10] = 'B'
+	} else {
+		flipped[10] = 'A'
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"two_segments", parts[0] + "." + parts[1], ErrMalformedJWS},
+		{"four_segments", token + ".x", ErrMalformedJWS},
+		{"padded_header", parts[0] + "=." + parts[1] + "." + parts[2], ErrMalformedJWS},
+		{"std_alphabet", strings.ReplaceAll(token, "-", "+") + "+", ErrMalformedJWS},
+		{"header_not_json", enc([]byte("nope")) + "." + parts[1] + "." + parts[2], ErrMalformedJWS},
+		{"header_array", enc([]byte("[]")) + "." + parts[1] + "." + parts[2], ErrMalformedJWS},
+		{"alg_none", signWithHeader(`{"alg":"none"}`), ErrUnsupportedAlgorithm},
+		{"alg_other_mldsa", signWithHeader(`{"alg":"ML-DSA-65"}`), ErrUnsupportedAlgorithm},
+		{"alg_missing", signWithHeader(`{}`), ErrUnsupportedAlgorithm},
+		{"crit", signWithHeader(`{"alg":"ML-DSA-87","crit":["exp"],"exp":1}`), ErrUnsupportedCritical},
+		{"short_signature", parts[0] + "." + parts[1] + "." + enc([]byte{1, 2, 3}), ErrInvalidSignature},
+		{"tampered_payload", parts[0] + "." + enc([]byte("PAYLOAD")) + "." + parts[2], ErrInvalidSignature},
+		{"tampered_signature", parts[0] + "." + parts[1] + "." + string(flipped), ErrInvalidSignature},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := Verify(tc.token, publicKeyOf(d)); !errors.Is(err, tc.want) {
+				t.Errorf("error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	if _, _, err := Verify(token, publicKeyOf(newTestKey(t, 2))); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("wrong key error = %v", err)
+	}
+	if _, _, err := Verify(token, nil); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("nil key error = %v", err)
+	}
+}
+
+func TestVerifyWithKeySet(t *testing.T) {
+	d1, d2, d3 := newTestKey(t, 1), newTestKey(t, 2), newTestKey(t, 3)
+	set := &JWKSet{Keys: []*JWK{
+		{KeyID: "one", PublicKey: publicKeyOf(d1)},
+		{KeyID: "two", PublicKey: publicKeyOf(d2)},
+		{PublicKey: publicKeyOf(d3)},
+	}}
+
+	token, err := Sign(nil, ml_dsa_87.NewCryptoSigner(d2), []byte("hi"), Header{KeyID: "two"})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	payload, h, key, err := VerifyWithKeySet(token, set)
+	if err != nil {
+		t.Fatalf("VerifyWithKeySet: %v", err)
+	}
+	if string(payload) != "hi" || h.KeyID != "two" || key != set.Keys[1] {
+		t.Errorf("got %q kid=%q key=%v", payload, h.KeyID, key.KeyID)
+	}
+
+	// Without a kid every key is tried.
+	token, err = Sign(nil, ml_dsa_87.NewCryptoSigner(d3), []byte("anon"), Header{})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if _, _, key, err := VerifyWithKeySet(token, set); err != nil || key != set.Keys[2] {
+		t.Errorf("kid-less VerifyWithKeySet = %v, %v", key, err)
+	}
+
+	// A kid that names the wrong key does not fall back to the others.
+	token, err = Sign(nil, ml_dsa_87.NewCryptoSigner(d1), []byte("x"), Header{KeyID: "two"})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if _, _, _, err := VerifyWithKeySet(token, set); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("mislabelled kid error = %v", err)
+	}
+
+	token, err = Sign(nil, ml_dsa_87.NewCryptoSigner(d1), []byte("x"), Header{KeyID: "missing"})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if _, _, _, err := VerifyWithKeySet(token, set); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("unknown kid error = %v", err)
+	}
+	if _, _, _, err := VerifyWithKeySet(token, nil); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("nil set error = %v", err)
+	}
+}
@@ -574,7 +574,10 @@ func TestKATZeroize(t *testing.T) {
 
 	// Get references before zeroize
 	sk := mldsa.GetSK()
-	storedSeed := mldsa.GetSeed()
+	storedSeed, err := mldsa.GetSeed()
+	if err != nil {
+		t.Fatalf("GetSeed: %v", err)
+	}
 
 	// Verify not already zero
 	allZeroSK := true
@@ -601,7 +604,10 @@ func TestKATZeroize(t *testing.T) {
 	}
 
 	// Check seed is zeroed
-	seedAfter := mldsa.GetSeed()
+	seedAfter, err := mldsa.GetSeed()
+	if err != nil {
+		t.Fatalf("GetSeed after Zeroize: %v", err)
+	}
 	for i, b := range seedAfter {
 		if b != 0 {
 			t.Errorf("Seed byte %d not zeroed: %d", i, b)
@@ -51,19 +51,74 @@
 // rather than as alternatives to be picked casually. See SECURITY.md
 // for the full discussion (TOB-QRLLIB-6).
 //
-// [crypto.Signer.Sign] also honours its `rand io.Reader` parameter:
-// when non-nil, its bytes drive `RND_BYTES`; when nil, `crypto/rand`
-// is used.
+// [CryptoSigner.Sign] and [CryptoSigner.SignMessage] also honour their
+// `rand io.Reader` parameter: when non-nil, its bytes drive
+// `RND_BYTES`; when nil, `crypto/rand` is used. Both sign the whole
+// message; prefer SignMessage (or [crypto.SignMessage]) in generic
+// code, since Sign's parameter is named "digest" but is never hashed.
+//
+// # Pre-hash Mode (HashML-DSA)
+//
+// [MLDSA87.SignPrehash] and [VerifyPrehash] implement the FIPS 204
+// §5.4 HashML-DSA variant for callers that only hold a digest of the
+// message. The signed representative is
+// `0x01 || len(ctx) || ctx || OID(PH) || PH(M)`, so pre-hash and pure
+// signatures are never interchangeable. See [PreHashFunction] for the
+// supported hash functions.
+//
+// # External mu
+//
+// [ComputeMu] derives the 64-byte message representative mu from the
+// public key, context and message; [MLDSA87.SignMu] and [VerifyMu]
+// start from that value. This lets a remote or air-gapped signer sign
+// without ever receiving the message itself.
+//
+// # Streaming
+//
+// [MLDSA87.NewSigningStream] and [NewVerifyingStream] return
+// [io.Writer]s that absorb the message into the mu computation as it
+// arrives, so large files never need to be held in memory. Streamed
+// signatures are ordinary ML-DSA-87 signatures.
+//
+// # Precomputation
+//
+// Signers that sign repeatedly with one key can call
+// [MLDSA87.Precompute] to cache the expanded secret key (the matrix A
+// and NTT-domain s1, s2 and t0) instead of re-deriving it per
+// signature. Output is unchanged; the cost is extra secret state in
+// memory until [MLDSA87.Zeroize].
+//
+// # Key Encoding
+//
+// [MarshalPKIXPublicKey], [ParsePKIXPublicKey], [MarshalPKCS8PrivateKey]
+// and [ParsePKCS8PrivateKey] convert keys to and from the DER
+// SubjectPublicKeyInfo and PKCS#8 structures used by OpenSSL and other
+// X.509 tooling (OID 2.16.840.1.101.3.4.3.19). Private keys can be
+// written in the seed, expanded or both forms of the IETF lamps
+// ML-DSA certificates draft; see [PrivateKeyFormat]. The *PEM variants
+// wrap the same encodings in "PUBLIC KEY" and "PRIVATE KEY" blocks.
+//
+// [NewMLDSA87FromSecretKey] imports a raw expanded secret key and its
+// public key, as exported by other implementations or HSMs. Such keys
+// have no seed, so [MLDSA87.GetSeed] returns
+// [cryptoerrors.ErrSeedUnavailable] and seed-based encodings are
+// unavailable.
 //
 // # Thread Safety
 //
 // An MLDSA87 instance is safe for concurrent reads (GetPK, GetSK, GetSeed),
 // but Sign and SignAttached should not be called concurrently on the same instance.
 // The package-level Verify and Open functions are safe for concurrent use.
+// [VerifyBatch] verifies many signatures concurrently on its own,
+// sharing expanded public-key state between items that repeat a key.
+// A [PreparedPublicKey] is immutable and safe for concurrent use.
+// [MLDSA87.Precompute] and [MLDSA87.SetVerifyAfterSign] must not run
+// concurrently with signing on the same instance.
 package ml_dsa_87
 
 import (
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
 	"strings"
 
@@ -81,6 +136,17 @@ type MLDSA87 struct {
 	pk   [CRYPTO_PUBLIC_KEY_BYTES]uint8
 	sk   [CRYPTO_SECRET_KEY_BYTES]uint8
 	seed [SEED_BYTES]uint8
+
+	// seedless is set when the instance was built from an expanded
+	// secret key alone, so seed holds no key material.
+	seedless bool
+
+	// esk is the optional expanded signing key; see Precompute.
+	esk *expandedSK
+
+	// verifyAfterSign enables the fault countermeasure; see
+	// SetVerifyAfterSign.
+	verifyAfterSign bool
 }
 
 func New() (*MLDSA87, error) {
@@ -101,7 +167,7 @@ func New() (*MLDSA87, error) {
 		return nil, err
 	}
 
-	d := &MLDSA87{pk, sk, seed}
+	d := &MLDSA87{pk: pk, sk: sk, seed: seed}
 	// Wipe the constructor-local copies now that they live in the
 	// returned instance (the NewMLDSA87FromHexSeed pattern, TOB-QRLLIB-10).
 	zeroBytes(sk[:])
@@ -119,13 +185,85 @@ func NewMLDSA87FromSeed(seed [SEED_BYTES]uint8) (*MLDSA87, error) {
 		return nil, err
 	}
 
-	d := &MLDSA87{pk, sk, seed}
+	d := &MLDSA87{pk: pk, sk: sk, seed: seed}
 	// seed is a by-value parameter, so this wipes only the local copy.
 	zeroBytes(sk[:])
 	zeroBytes(seed[:])
 	return d, nil
 }
 
+// NewMLDSA87FromSecretKey builds an instance from a FIPS 204 expanded
+// secret key, as exported by other implementations and HSMs, and its
+// public key.
+//
+// The secret key must decode canonically (s1 and s2 within eta, t0 in
+// range). The public key and tr are recomputed from it and must match
+// pk and the embedded tr, and a pairwise-consistency sign/verify (FIPS
+// 140-3 IG 10.3.A) must succeed. Any failure returns
+// [cryptoerrors.ErrInvalidSecretKey], or [cryptoerrors.ErrInvalidPublicKey]
+// when the key pair is consistent but pk belongs to another key.
+//
+// The returned instance has no seed: [MLDSA87.HasSeed] reports false
+// and [MLDSA87.GetSeed] returns [cryptoerrors.ErrSeedUnavailable].
+func NewMLDSA87FromSecretKey(sk *[CRYPTO_SECRET_KEY_BYTES]uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) (*MLDSA87, error) {
+	if sk == nil {
+		return nil, cryptoerrors.ErrSecretKeyNil
+	}
+	if pk == nil {
+		return nil, cryptoerrors.ErrPublicKeyNil
+	}
+	d, err := newMLDSA87FromSecretKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(d.pk[:], pk[:]) != 1 {
+		d.Zeroize()
+		return nil, cryptoerrors.ErrInvalidPublicKey
+	}
+	return d, nil
+}
+
+// newMLDSA87FromSecretKey builds a seedless instance from an expanded
+// secret key, deriving the public key, rejecting non-canonical
+// encodings and running the pairwise-consistency test. It backs
+// NewMLDSA87FromSecretKey and the expanded-only PKCS#8 form.
+func newMLDSA87FromSecretKey(sk *[CRYPTO_SECRET_KEY_BYTES]uint8) (*MLDSA87, error) {
+	var pk [CRYPTO_PUBLIC_KEY_BYTES]uint8
+	if err := cryptoSignPublicKeyFromSecretKey(&pk, sk); err != nil {
+		return nil, err
+	}
+	d := &MLDSA87{pk: pk, sk: *sk, seedless: true}
+	if err := d.pairwiseConsistencyTest(); err != nil {
+		//coverage:ignore
+		//rationale: pk and tr were just recomputed from sk, so a canonical key always signs consistently
+		d.Zeroize()
+		return nil, err
+	}
+	return d, nil
+}
+
+// pctMessage is the fixed message signed by pairwiseConsistencyTest.
+var pctMessage = []byte("ML-DSA-87 pairwise consistency test")
+
+// pairwiseConsistencyTest signs pctMessage with the secret key and
+// verifies the signature under the public key (FIPS 140-3 IG 10.3.A).
+// The test signature is deterministic and is discarded.
+func (d *MLDSA87) pairwiseConsistencyTest() error {
+	var sig [CRYPTO_BYTES]uint8
+	var rnd [RND_BYTES]uint8
+	if err := d.signSignatureWithRnd(sig[:], pctMessage, nil, rnd); err != nil {
+		//coverage:ignore
+		//rationale: signing with an empty context and a fixed-size buffer cannot fail
+		return err
+	}
+	if !Verify(nil, pctMessage, sig, &d.pk) {
+		//coverage:ignore
+		//rationale: unreachable for keys whose public key was derived from the secret key
+		return cryptoerrors.ErrInvalidSecretKey
+	}
+	return nil
+}
+
 func NewMLDSA87FromHexSeed(hexSeed string) (*MLDSA87, error) {
 	if strings.HasPrefix(hexSeed, "0x") || strings.HasPrefix(hexSeed, "0X") {
 		hexSeed = hexSeed[2:]
@@ -163,13 +301,32 @@ func (d *MLDSA87) GetSK() [CRYPTO_SECRET_KEY_BYTES]uint8 {
 	return d.sk
 }
 
-func (d *MLDSA87) GetSeed() [SEED_BYTES]uint8 {
-	return d.seed
+// GetSeed returns the 32-byte seed the key was generated from, or
+// [cryptoerrors.ErrSeedUnavailable] if the instance was built from an
+// expanded secret key and has no seed.
+func (d *MLDSA87) GetSeed() ([SEED_BYTES]uint8, error) {
+	if d.seedless {
+		return [SEED_BYTES]uint8{}, cryptoerrors.ErrSeedUnavailable
+	}
+	return d.seed, nil
+}
+
+// HasSeed reports whether d holds the seed it was generated from.
+// Keys imported from an expanded secret key alone
+// ([NewMLDSA87FromSecretKey] or the expanded PKCS#8 form) do not, and
+// cannot be exported in seed-based encodings.
+func (d *MLDSA87) HasSeed() bool {
+	return !d.seedless
 }
 
-func (d *MLDSA87) GetHexSeed() string {
-	seed := d.GetSeed()
-	return "0x" + hex.EncodeToString(seed[:])
+// GetHexSeed returns the seed as a 0x-prefixed hex string, or
+// [cryptoerrors.ErrSeedUnavailable] if the instance has no seed.
+func (d *MLDSA87) GetHexSeed() (string, error) {
+	seed, err := d.GetSeed()
+	if err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(seed[:]), nil
 }
 
 // SignAttached signs message with the FIPS 204 context ctx and returns
@@ -190,7 +347,7 @@ func (d *MLDSA87) GetHexSeed() string {
 // (ctx, message) under the same key produce distinct signatures, both
 // of which verify under the same public key. (TOB-QRLLIB-6.)
 func (d *MLDSA87) SignAttached(ctx, message []uint8) ([]uint8, error) {
-	return cryptoSign(message, ctx, &d.sk)
+	return d.signAttached(message, ctx)
 }
 
 // Sign the message with the given context, and return a detached signature.
@@ -204,11 +361,11 @@ func (d *MLDSA87) SignAttached(ctx, message []uint8) ([]uint8, error) {
 func (d *MLDSA87) Sign(ctx, message []uint8) ([CRYPTO_BYTES]uint8, error) {
 	var signature [CRYPTO_BYTES]uint8
 
-	sm, err := cryptoSign(message, ctx, &d.sk)
-	if err == nil {
-		copy(signature[:CRYPTO_BYTES], sm[:CRYPTO_BYTES])
+	if err := d.signSignature(signature[:], message, ctx); err != nil {
+		zeroBytes(signature[:])
+		return signature, err
 	}
-	return signature, err
+	return signature, nil
 }
 
 // SignDeterministic produces an ML-DSA-87 signature using the FIPS 204
@@ -236,7 +393,7 @@ func (d *MLDSA87) Sign(ctx, message []uint8) ([CRYPTO_BYTES]uint8, error) {
 func (d *MLDSA87) SignDeterministic(ctx, message []uint8) ([CRYPTO_BYTES]uint8, error) {
 	var signature [CRYPTO_BYTES]uint8
 	var rnd [RND_BYTES]uint8 // zero — FIPS 204 §3.5 deterministic mode
-	if err := cryptoSignSignatureWithRnd(signature[:], message, ctx, &d.sk, rnd); err != nil {
+	if err := d.signSignatureWithRnd(signature[:], message, ctx, rnd); err != nil {
 		return signature, err
 	}
 	return signature, nil
@@ -271,15 +428,22 @@ func Open(ctx, signatureMessage []uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) ([]
 // Verify checks the signature against the message and public key with the given context.
 // The ctx parameter must match the context used during signing (FIPS 204 requirement).
 // Returns false if pk is nil rather than panicking. (TOB-QRLLIB-11)
+// Use [VerifyDetailed] to learn why a signature was rejected.
 func Verify(ctx, message []uint8, signature [CRYPTO_BYTES]uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) bool {
-	if pk == nil {
-		return false
-	}
-	result, err := cryptoSignVerify(signature, message, ctx, pk)
-	if err != nil {
-		return false
-	}
-	return result
+	return VerifyDetailed(ctx, message, signature, pk) == nil
+}
+
+// VerifyDetailed is [Verify] returning the reason for a rejection
+// instead of false. It returns nil for a valid signature,
+// [cryptoerrors.ErrPublicKeyNil] if pk is nil,
+// [cryptoerrors.ErrInvalidContext] if ctx exceeds 255 bytes,
+// [cryptoerrors.ErrNonCanonicalHint] or
+// [cryptoerrors.ErrSignatureNormExceeded] for a malformed signature, and
+// [cryptoerrors.ErrInvalidSignature] for a well-formed signature that
+// does not verify. The two malformed-signature errors also match
+// ErrInvalidSignature under errors.Is.
+func VerifyDetailed(ctx, message []uint8, signature [CRYPTO_BYTES]uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) error {
+	return cryptoSignVerify(signature, message, ctx, pk)
 }
 
 // ExtractMessage extracts message from Signature attached with message.
@@ -300,7 +464,58 @@ func ExtractSignature(signatureMessage []uint8) []uint8 {
 	return signatureMessage[:CRYPTO_BYTES]
 }
 
-// Zeroize clears the secret-key and seed fields of the MLDSA87 instance.
+// SetVerifyAfterSign enables or disables verify-after-sign, a
+// countermeasure against fault injection during signing. When enabled,
+// every signature d produces — through Sign, SignAttached,
+// SignDeterministic, SignPrehash, SignMu, the streaming and
+// crypto.Signer paths — is verified under d's own public key before it
+// is returned. A signature that does not verify is wiped and
+// [cryptoerrors.ErrSigningFailed] is returned instead, so a faulty
+// signature that could leak key material never leaves the library.
+//
+// The check roughly doubles the cost of signing and is off by default.
+// SetVerifyAfterSign must not be called concurrently with signing on
+// the same instance.
+func (d *MLDSA87) SetVerifyAfterSign(enabled bool) {
+	d.verifyAfterSign = enabled
+}
+
+// Precompute expands the secret key once — the matrix A and the NTT
+// forms of s1, s2 and t0 — and keeps the result on d, so later calls
+// to Sign, SignAttached, SignDeterministic, SignPrehash, SignMu and the
+// streaming and crypto.Signer paths skip that work. Signatures are
+// byte-identical to those of a non-precomputed instance.
+//
+// Precomputation is opt-in: the expanded key holds roughly 80 KiB of
+// secret-derived state in memory for as long as d lives, in exchange
+// for faster repeated signing. [MLDSA87.Zeroize] wipes it along with
+// the packed key. Calling Precompute again is a no-op.
+//
+// Precompute must not be called concurrently with signing on the
+// same instance.
+func (d *MLDSA87) Precompute() error {
+	if d.esk != nil {
+		return nil
+	}
+	esk := new(expandedSK)
+	if err := expandSK(esk, &d.sk); err != nil {
+		//coverage:ignore
+		//rationale: expandSK's sha3 operations never return errors
+		zeroExpandedSK(esk)
+		return err
+	}
+	d.esk = esk
+	return nil
+}
+
+// IsPrecomputed reports whether [MLDSA87.Precompute] has cached the
+// expanded signing key on d.
+func (d *MLDSA87) IsPrecomputed() bool {
+	return d.esk != nil
+}
+
+// Zeroize clears the secret-key and seed fields of the MLDSA87 instance,
+// and the expanded signing key if [MLDSA87.Precompute] was called.
 // Call this when the instance is no longer needed.
 //
 // # Guarantee boundary (best-effort under Go's memory model)
@@ -330,4 +545,8 @@ func ExtractSignature(signatureMessage []uint8) []uint8 {
 func (d *MLDSA87) Zeroize() {
 	zeroBytes(d.sk[:])
 	zeroBytes(d.seed[:])
+	if d.esk != nil {
+		zeroExpandedSK(d.esk)
+		d.esk = nil
+	}
 }
@@ -182,3 +182,63 @@ func BenchmarkSignContextSizes(b *testing.B) {
 		})
 	}
 }
+
+// Benchmark batch verification of 64 signatures under 4 keys
+func BenchmarkVerifyBatch(b *testing.B) {
+	items, _ := newBatchFixture(b, 64)
+	for i := range items {
+		items[i].PK = items[i%4].PK
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyBatch(items)
+	}
+}
+
+// Benchmark verification against a PreparedPublicKey
+func BenchmarkPreparedPublicKeyVerify(b *testing.B) {
+	mldsa, err := New()
+	if err != nil {
+		b.Fatal(err)
+	}
+	msg := []byte("benchmark message for verification")
+	sig, err := mldsa.Sign(nil, msg)
+	if err != nil {
+		b.Fatal(err)
+	}
+	pk := mldsa.GetPK()
+	p, err := PreparePublicKey(&pk)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !p.Verify(nil, msg, sig) {
+			b.Fatal("verification failed")
+		}
+	}
+}
+
+// Benchmark signing with the expanded key cached by Precompute
+func BenchmarkSignPrecomputed(b *testing.B) {
+	mldsa, err := New()
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := mldsa.Precompute(); err != nil {
+		b.Fatal(err)
+	}
+
+	msg := []byte("benchmark message for signing")
+	ctx := []byte("ZOND")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := mldsa.Sign(ctx, msg)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -3,8 +3,11 @@ package ml_dsa_87
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"reflect"
 	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
 )
 
 const (
@@ -78,8 +81,12 @@ func TestNewMLDSA87FromSeed(t *testing.T) {
 		t.Errorf("sk mismatch\nExpected: %s\nFound: %s", SK, strSK)
 	}
 
-	if "0x"+HexSeed != d.GetHexSeed() {
-		t.Errorf("hexseed mismatch\nExpected: %s\nFound: %s", HexSeed, d.GetHexSeed())
+	hexSeed, err := d.GetHexSeed()
+	if err != nil {
+		t.Fatalf("GetHexSeed: %v", err)
+	}
+	if "0x"+HexSeed != hexSeed {
+		t.Errorf("hexseed mismatch\nExpected: %s\nFound: %s", HexSeed, hexSeed)
 	}
 }
 
@@ -110,8 +117,12 @@ func TestNewMLDSA87FromHexSeed(t *testing.T) {
 		t.Errorf("sk mismatch\nExpected: %s\nFound: %s", SK, strSK)
 	}
 
-	if "0x"+HexSeed != d.GetHexSeed() {
-		t.Errorf("hexseed mismatch\nExpected: %s\nFound: %s", HexSeed, d.GetHexSeed())
+	hexSeed, err := d.GetHexSeed()
+	if err != nil {
+		t.Fatalf("GetHexSeed: %v", err)
+	}
+	if "0x"+HexSeed != hexSeed {
+		t.Errorf("hexseed mismatch\nExpected: %s\nFound: %s", HexSeed, hexSeed)
 	}
 }
 
@@ -155,15 +166,113 @@ func TestMLDSA87_GetSeed(t *testing.T) {
 		t.Error("failed to generate new ml-dsa-87 from seed", err.Error())
 	}
 
-	if !reflect.DeepEqual(binSeed, d.GetSeed()) {
+	seed, err := d.GetSeed()
+	if err != nil {
+		t.Fatalf("GetSeed: %v", err)
+	}
+	if !reflect.DeepEqual(binSeed, seed) {
 		t.Error("Seed Mismatch")
 	}
 }
 
 func TestMLDSA87_GetHexSeed(t *testing.T) {
 	d := newMLDSA87FromSeed(t, HexSeed)
-	if "0x"+HexSeed != d.GetHexSeed() {
-		t.Errorf("HexSeed mismatch\nExpected: 0x%s\nFound: %s", HexSeed, d.GetHexSeed())
+	hexSeed, err := d.GetHexSeed()
+	if err != nil {
+		t.Fatalf("GetHexSeed: %v", err)
+	}
+	if "0x"+HexSeed != hexSeed {
+		t.Errorf("HexSeed mismatch\nExpected: 0x%s\nFound: %s", HexSeed, hexSeed)
+	}
+}
+
+func TestNewMLDSA87FromSecretKey(t *testing.T) {
+	d := newMLDSA87FromSeed(t, HexSeed)
+	sk, pk := d.GetSK(), d.GetPK()
+
+	imported, err := NewMLDSA87FromSecretKey(&sk, &pk)
+	if err != nil {
+		t.Fatalf("NewMLDSA87FromSecretKey: %v", err)
+	}
+	if imported.GetPK() != pk || imported.GetSK() != sk {
+		t.Fatal("imported key pair differs from the original")
+	}
+	if imported.HasSeed() {
+		t.Error("imported key reports a seed")
+	}
+	if _, err := imported.GetSeed(); !errors.Is(err, cryptoerrors.ErrSeedUnavailable) {
+		t.Errorf("GetSeed error = %v, want %v", err, cryptoerrors.ErrSeedUnavailable)
+	}
+	if _, err := imported.GetHexSeed(); !errors.Is(err, cryptoerrors.ErrSeedUnavailable) {
+		t.Errorf("GetHexSeed error = %v, want %v", err, cryptoerrors.ErrSeedUnavailable)
+	}
+
+	msg := []byte("imported")
+	sig, err := imported.Sign(nil, msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if !Verify(nil, msg, sig, &pk) {
+		t.Error("signature from imported key does not verify")
+	}
+}
+
+func TestNewMLDSA87FromSecretKeyRejects(t *testing.T) {
+	d := newMLDSA87FromSeed(t, HexSeed)
+	sk, pk := d.GetSK(), d.GetPK()
+	var otherSeed [SEED_BYTES]uint8
+	other, err := NewMLDSA87FromSeed(otherSeed)
+	if err != nil {
+		t.Fatalf("NewMLDSA87FromSeed: %v", err)
+	}
+	otherPK := other.GetPK()
+
+	// Flip an s1 coefficient to 7, which unpacks to ETA-7 = -5.
+	badEta := sk
+	badEta[2*SEED_BYTES+TR_BYTES] |= 0x07
+	// Corrupt tr so it no longer matches H(pk).
+	badTr := sk
+	badTr[2*SEED_BYTES] ^= 0x01
+	// Corrupt t0.
+	badT0 := sk
+	badT0[CRYPTO_SECRET_KEY_BYTES-1] ^= 0x01
+
+	tests := []struct {
+		name string
+		sk   *[CRYPTO_SECRET_KEY_BYTES]uint8
+		pk   *[CRYPTO_PUBLIC_KEY_BYTES]uint8
+		want error
+	}{
+		{"nil_sk", nil, &pk, cryptoerrors.ErrSecretKeyNil},
+		{"nil_pk", &sk, nil, cryptoerrors.ErrPublicKeyNil},
+		{"eta_out_of_range", &badEta, &pk, cryptoerrors.ErrInvalidSecretKey},
+		{"bad_tr", &badTr, &pk, cryptoerrors.ErrInvalidSecretKey},
+		{"bad_t0", &badT0, &pk, cryptoerrors.ErrInvalidSecretKey},
+		{"other_pk", &sk, &otherPK, cryptoerrors.ErrInvalidPublicKey},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewMLDSA87FromSecretKey(tc.sk, tc.pk)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("error = %v, want %v", err, tc.want)
+			}
+			if got != nil {
+				t.Error("returned a key alongside the error")
+			}
+		})
+	}
+}
+
+func TestPairwiseConsistencyTest(t *testing.T) {
+	d := newMLDSA87FromSeed(t, HexSeed)
+	if err := d.pairwiseConsistencyTest(); err != nil {
+		t.Fatalf("pairwiseConsistencyTest: %v", err)
+	}
+
+	// A public key that does not belong to the secret key fails.
+	d.pk[0] ^= 0x01
+	if err := d.pairwiseConsistencyTest(); !errors.Is(err, cryptoerrors.ErrInvalidSecretKey) {
+		t.Errorf("error = %v, want %v", err, cryptoerrors.ErrInvalidSecretKey)
 	}
 }
 
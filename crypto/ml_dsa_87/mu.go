@@ -0,0 +1,82 @@
+package ml_dsa_87
+
+import (
+	"crypto/rand"
+	"crypto/sha3"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+// ComputeMu computes the FIPS 204 message representative
+// mu = SHAKE256(tr || 0x00 || len(ctx) || ctx || message, 64), where
+// tr = SHAKE256(pk, 64). Only the public key is needed, so mu can be
+// computed by whoever holds the message and then handed to an isolated
+// signer through [MLDSA87.SignMu] (the "external mu" split described in
+// FIPS 204 §6.2 and its accompanying guidance).
+//
+// Returns [cryptoerrors.ErrPublicKeyNil] if pk is nil and
+// [cryptoerrors.ErrInvalidContext] if len(ctx) > 255.
+func ComputeMu(pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8, ctx, message []uint8) ([MU_BYTES]uint8, error) {
+	var mu [MU_BYTES]uint8
+	if pk == nil {
+		return mu, cryptoerrors.ErrPublicKeyNil
+	}
+	pre, err := messagePrefix(ctx)
+	if err != nil {
+		return mu, err
+	}
+
+	computeMu(&mu, sha3.SumSHAKE256(pk[:], TR_BYTES), pre, message)
+	return mu, nil
+}
+
+// SignMu signs a message representative previously produced by
+// [ComputeMu] for this key's public key. The signer never sees the
+// message or context; both are already bound into mu.
+//
+// The caller is responsible for mu having been computed over this
+// signer's public key: a mu computed over a different key yields a
+// signature that does not verify, but SignMu cannot detect that.
+//
+// Signing is hedged (FIPS 204 §3.4), as with [MLDSA87.Sign]. For
+// (key, ctx, message), SignMu(ComputeMu(pk, ctx, message)) verifies
+// under [Verify] exactly as a Sign signature does.
+func (d *MLDSA87) SignMu(mu [MU_BYTES]uint8) ([CRYPTO_BYTES]uint8, error) {
+	var signature [CRYPTO_BYTES]uint8
+	var rnd [RND_BYTES]uint8
+	if _, err := rand.Read(rnd[:]); err != nil {
+		//coverage:ignore
+		//rationale: crypto/rand.Read only fails if system entropy source is broken
+		return signature, cryptoerrors.ErrSeedGeneration
+	}
+	if err := d.signMu(signature[:], &mu, rnd); err != nil {
+		return signature, err
+	}
+	return signature, nil
+}
+
+// SignMuDeterministic is the FIPS 204 §3.5 deterministic counterpart
+// of [MLDSA87.SignMu] (rnd = 32 zero bytes). Its output is
+// byte-identical to [MLDSA87.SignDeterministic] over the message and
+// context mu was computed from. The same caveats as for
+// SignDeterministic apply: prefer the hedged SignMu unless determinism
+// is itself a requirement.
+func (d *MLDSA87) SignMuDeterministic(mu [MU_BYTES]uint8) ([CRYPTO_BYTES]uint8, error) {
+	var signature [CRYPTO_BYTES]uint8
+	var rnd [RND_BYTES]uint8 // zero — FIPS 204 §3.5 deterministic mode
+	if err := d.signMu(signature[:], &mu, rnd); err != nil {
+		return signature, err
+	}
+	return signature, nil
+}
+
+// VerifyMu checks signature against a precomputed message
+// representative mu and public key. It accepts exactly the signatures
+// that [Verify] accepts for the message and context mu was computed
+// from. Returns false if pk is nil rather than panicking.
+func VerifyMu(mu [MU_BYTES]uint8, signature [CRYPTO_BYTES]uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) bool {
+	if pk == nil {
+		return false
+	}
+	return cryptoSignVerifyMu(signature, &mu, pk) == nil
+}
@@ -0,0 +1,149 @@
+package ml_dsa_87
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+// TestSignMuMatchesSignDeterministic checks that the external-mu split
+// (ComputeMu on the message holder, SignMuDeterministic on the signer)
+// reproduces SignDeterministic byte for byte.
+func TestSignMuMatchesSignDeterministic(t *testing.T) {
+	for _, vec := range katVectors {
+		t.Run(vec.name, func(t *testing.T) {
+			seedBytes, _ := hex.DecodeString(vec.seed)
+			msg, _ := hex.DecodeString(vec.message)
+			ctx, _ := hex.DecodeString(vec.ctx)
+			var seed [SEED_BYTES]uint8
+			copy(seed[:], seedBytes)
+
+			d, err := NewMLDSA87FromSeed(seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer d.Zeroize()
+			pk := d.GetPK()
+
+			want, err := d.SignDeterministic(ctx, msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mu, err := ComputeMu(&pk, ctx, msg)
+			if err != nil {
+				t.Fatalf("ComputeMu: %v", err)
+			}
+			got, err := d.SignMuDeterministic(mu)
+			if err != nil {
+				t.Fatalf("SignMuDeterministic: %v", err)
+			}
+			if !bytes.Equal(got[:], want[:]) {
+				t.Error("SignMuDeterministic output differs from SignDeterministic")
+			}
+			if !VerifyMu(mu, got, &pk) {
+				t.Error("VerifyMu rejected a valid signature")
+			}
+		})
+	}
+}
+
+func TestSignMuVerify(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+	ctx := []byte("air-gapped")
+	msg := []byte("transaction bytes that never reach the signer")
+
+	mu, err := ComputeMu(&pk, ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig1, err := d.SignMu(mu)
+	if err != nil {
+		t.Fatalf("SignMu: %v", err)
+	}
+	sig2, err := d.SignMu(mu)
+	if err != nil {
+		t.Fatalf("SignMu: %v", err)
+	}
+	if bytes.Equal(sig1[:], sig2[:]) {
+		t.Error("SignMu should be hedged; got identical signatures")
+	}
+
+	for _, sig := range [][CRYPTO_BYTES]uint8{sig1, sig2} {
+		if !Verify(ctx, msg, sig, &pk) {
+			t.Error("Verify rejected a SignMu signature")
+		}
+		if !VerifyMu(mu, sig, &pk) {
+			t.Error("VerifyMu rejected a SignMu signature")
+		}
+	}
+
+	// A signature from the ordinary path verifies through VerifyMu too.
+	sig3, err := d.Sign(ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyMu(mu, sig3, &pk) {
+		t.Error("VerifyMu rejected a Sign signature")
+	}
+
+	otherMu, err := ComputeMu(&pk, []byte("other"), msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifyMu(otherMu, sig1, &pk) {
+		t.Error("VerifyMu accepted a signature under a different mu")
+	}
+	if VerifyMu(mu, sig1, nil) {
+		t.Error("VerifyMu returned true for nil pk")
+	}
+}
+
+// TestSignMuWrongKey checks that a mu bound to one public key does not
+// yield a valid signature when signed by another key.
+func TestSignMuWrongKey(t *testing.T) {
+	d1, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d1.Zeroize()
+	d2, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d2.Zeroize()
+	pk1 := d1.GetPK()
+	pk2 := d2.GetPK()
+
+	mu, err := ComputeMu(&pk1, nil, []byte("msg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := d2.SignMu(mu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Verify(nil, []byte("msg"), sig, &pk2) {
+		t.Error("signature over a mu computed for another key verified")
+	}
+}
+
+func TestComputeMuErrors(t *testing.T) {
+	if _, err := ComputeMu(nil, nil, nil); !errors.Is(err, cryptoerrors.ErrPublicKeyNil) {
+		t.Errorf("ComputeMu(nil pk) error = %v, want ErrPublicKeyNil", err)
+	}
+	var pk [CRYPTO_PUBLIC_KEY_BYTES]uint8
+	if _, err := ComputeMu(&pk, make([]byte, 256), nil); !errors.Is(err, cryptoerrors.ErrInvalidContext) {
+		t.Errorf("ComputeMu(256-byte ctx) error = %v, want ErrInvalidContext", err)
+	}
+	if _, err := ComputeMu(&pk, make([]byte, 255), nil); err != nil {
+		t.Errorf("ComputeMu(255-byte ctx) error = %v, want nil", err)
+	}
+}
@@ -75,10 +75,7 @@ func TestOpen_NilPublicKey_ReturnsNilNoPanic(t *testing.T) {
 func TestCryptoSignVerify_NilPublicKey_ReturnsErrPublicKeyNil(t *testing.T) {
 	msg, ctx, sig, _ := fixtureSign(t)
 
-	ok, err := cryptoSignVerify(sig, msg, ctx, nil)
-	if ok {
-		t.Error("cryptoSignVerify(nil pk) returned ok=true; want false")
-	}
+	err := cryptoSignVerify(sig, msg, ctx, nil)
 	if !errors.Is(err, cryptoerrors.ErrPublicKeyNil) {
 		t.Errorf("cryptoSignVerify(nil pk) err = %v; want ErrPublicKeyNil", err)
 	}
@@ -0,0 +1,312 @@
+package ml_dsa_87
+
+import (
+	"crypto"
+	"crypto/subtle"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+// oidMLDSA87 is id-ml-dsa-87 (2.16.840.1.101.3.4.3.19) from the NIST
+// signature-algorithm arc. The AlgorithmIdentifier carries no
+// parameters.
+var oidMLDSA87 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 19}
+
+// PEM block types used by the PEM helpers.
+const (
+	PEM_PUBLIC_KEY_TYPE  = "PUBLIC KEY"
+	PEM_PRIVATE_KEY_TYPE = "PRIVATE KEY"
+)
+
+// PrivateKeyFormat selects which arm of the ML-DSA-PrivateKey CHOICE
+// (IETF lamps ML-DSA certificates draft) MarshalPKCS8PrivateKey emits.
+//
+// The zero value is deliberately invalid so an unset field cannot
+// silently select a format.
+type PrivateKeyFormat uint8
+
+const (
+	// PRIVATE_KEY_SEED — seed [0] IMPLICIT OCTET STRING (32 bytes).
+	// The most compact form and the one recommended by the draft.
+	PRIVATE_KEY_SEED PrivateKeyFormat = iota + 1
+	// PRIVATE_KEY_EXPANDED — expandedKey OCTET STRING (the 4,896-byte
+	// FIPS 204 secret key). For tooling that cannot re-derive keys.
+	PRIVATE_KEY_EXPANDED
+	// PRIVATE_KEY_BOTH — SEQUENCE { seed, expandedKey }.
+	PRIVATE_KEY_BOTH
+)
+
+func (f PrivateKeyFormat) IsValid() bool {
+	return f >= PRIVATE_KEY_SEED && f <= PRIVATE_KEY_BOTH
+}
+
+// subjectPublicKeyInfo is the RFC 5280 SubjectPublicKeyInfo.
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// oneAsymmetricKey is the RFC 5958 OneAsymmetricKey (PKCS#8
+// PrivateKeyInfo) without the optional trailing fields, which this
+// package neither emits nor accepts.
+type oneAsymmetricKey struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// privateKeyBoth is the "both" arm of ML-DSA-PrivateKey.
+type privateKeyBoth struct {
+	Seed        []byte
+	ExpandedKey []byte
+}
+
+func checkAlgorithm(a pkix.AlgorithmIdentifier) error {
+	if !a.Algorithm.Equal(oidMLDSA87) {
+		return cryptoerrors.ErrUnsupportedAlgorithm
+	}
+	// id-ml-dsa-87 is defined with absent parameters; NULL is not
+	// an acceptable substitute.
+	if len(a.Parameters.FullBytes) != 0 {
+		return cryptoerrors.ErrInvalidEncoding
+	}
+	return nil
+}
+
+// MarshalPKIXPublicKey encodes an ML-DSA-87 public key as a DER
+// SubjectPublicKeyInfo. pub may be a *CryptoPublicKey (for example the
+// result of [CryptoSigner.Public]) or a *[CRYPTO_PUBLIC_KEY_BYTES]uint8.
+func MarshalPKIXPublicKey(pub crypto.PublicKey) ([]byte, error) {
+	var pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8
+	switch k := pub.(type) {
+	case *CryptoPublicKey:
+		if k != nil {
+			pk = &k.key
+		}
+	case *[CRYPTO_PUBLIC_KEY_BYTES]uint8:
+		pk = k
+	case nil:
+	default:
+		return nil, cryptoerrors.ErrUnsupportedAlgorithm
+	}
+	if pk == nil {
+		return nil, cryptoerrors.ErrPublicKeyNil
+	}
+
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidMLDSA87},
+		PublicKey: asn1.BitString{Bytes: pk[:], BitLength: 8 * CRYPTO_PUBLIC_KEY_BYTES},
+	})
+}
+
+// ParsePKIXPublicKey decodes a DER SubjectPublicKeyInfo holding an
+// ML-DSA-87 public key. Trailing data, a different algorithm, present
+// parameters or a key of the wrong length are rejected.
+func ParsePKIXPublicKey(der []byte) (*CryptoPublicKey, error) {
+	var spki subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &spki)
+	if err != nil || len(rest) != 0 {
+		return nil, cryptoerrors.ErrInvalidEncoding
+	}
+	if err := checkAlgorithm(spki.Algorithm); err != nil {
+		return nil, err
+	}
+	if spki.PublicKey.BitLength != 8*CRYPTO_PUBLIC_KEY_BYTES ||
+		len(spki.PublicKey.Bytes) != CRYPTO_PUBLIC_KEY_BYTES {
+		return nil, cryptoerrors.ErrInvalidPublicKey
+	}
+
+	pub := new(CryptoPublicKey)
+	copy(pub.key[:], spki.PublicKey.Bytes)
+	return pub, nil
+}
+
+// MarshalPKCS8PrivateKey encodes an ML-DSA-87 private key as a DER
+// PKCS#8 OneAsymmetricKey in the requested format. key may be an
+// *MLDSA87 or a *CryptoSigner.
+//
+// The seed and both formats need the key generation seed and return
+// [cryptoerrors.ErrSeedUnavailable] for keys that were imported from
+// an expanded key alone. The returned slice holds secret key material;
+// callers should wipe it once written out.
+func MarshalPKCS8PrivateKey(key crypto.PrivateKey, format PrivateKeyFormat) ([]byte, error) {
+	var d *MLDSA87
+	switch k := key.(type) {
+	case *MLDSA87:
+		d = k
+	case *CryptoSigner:
+		if k != nil {
+			d = k.d
+		}
+	case nil:
+	default:
+		return nil, cryptoerrors.ErrUnsupportedAlgorithm
+	}
+	if d == nil {
+		return nil, cryptoerrors.ErrSecretKeyNil
+	}
+	if !format.IsValid() {
+		return nil, cryptoerrors.ErrInvalidEncoding
+	}
+	if d.seedless && format != PRIVATE_KEY_EXPANDED {
+		return nil, cryptoerrors.ErrSeedUnavailable
+	}
+
+	var inner []byte
+	var err error
+	switch format {
+	case PRIVATE_KEY_SEED:
+		inner, err = asn1.Marshal(asn1.RawValue{
+			Class: asn1.ClassContextSpecific,
+			Tag:   0,
+			Bytes: d.seed[:],
+		})
+	case PRIVATE_KEY_EXPANDED:
+		inner, err = asn1.Marshal(d.sk[:])
+	default:
+		inner, err = asn1.Marshal(privateKeyBoth{Seed: d.seed[:], ExpandedKey: d.sk[:]})
+	}
+	if err != nil {
+		//coverage:ignore
+		//rationale: asn1.Marshal only fails for unsupported Go types, and these are fixed
+		return nil, err
+	}
+	defer zeroBytes(inner)
+
+	return asn1.Marshal(oneAsymmetricKey{
+		Algorithm:  pkix.AlgorithmIdentifier{Algorithm: oidMLDSA87},
+		PrivateKey: inner,
+	})
+}
+
+// ParsePKCS8PrivateKey decodes a DER PKCS#8 OneAsymmetricKey holding
+// an ML-DSA-87 private key in any of the three ML-DSA-PrivateKey
+// forms:
+//
+//   - seed: the key pair is regenerated from the seed.
+//   - expandedKey: the public key is re-derived and the secret key is
+//     checked for canonicality. The result has no seed, so it can only
+//     be re-encoded with [PRIVATE_KEY_EXPANDED].
+//   - both: the key pair is regenerated from the seed and must match
+//     the expanded key byte for byte.
+//
+// Inconsistent or non-canonical keys return
+// [cryptoerrors.ErrInvalidSecretKey]; malformed DER returns
+// [cryptoerrors.ErrInvalidEncoding].
+func ParsePKCS8PrivateKey(der []byte) (*MLDSA87, error) {
+	var p8 oneAsymmetricKey
+	rest, err := asn1.Unmarshal(der, &p8)
+	if err != nil || len(rest) != 0 {
+		return nil, cryptoerrors.ErrInvalidEncoding
+	}
+	defer zeroBytes(p8.PrivateKey)
+	if p8.Version != 0 {
+		return nil, cryptoerrors.ErrInvalidEncoding
+	}
+	if err := checkAlgorithm(p8.Algorithm); err != nil {
+		return nil, err
+	}
+
+	var raw asn1.RawValue
+	rest, err = asn1.Unmarshal(p8.PrivateKey, &raw)
+	if err != nil || len(rest) != 0 {
+		return nil, cryptoerrors.ErrInvalidEncoding
+	}
+
+	switch {
+	case raw.Class == asn1.ClassContextSpecific && raw.Tag == 0 && !raw.IsCompound:
+		if len(raw.Bytes) != SEED_BYTES {
+			return nil, cryptoerrors.ErrInvalidSeed
+		}
+		var seed [SEED_BYTES]uint8
+		copy(seed[:], raw.Bytes)
+		defer zeroBytes(seed[:])
+		return NewMLDSA87FromSeed(seed)
+
+	case raw.Class == asn1.ClassUniversal && raw.Tag == asn1.TagOctetString && !raw.IsCompound:
+		if len(raw.Bytes) != CRYPTO_SECRET_KEY_BYTES {
+			return nil, cryptoerrors.ErrInvalidSecretKey
+		}
+		var sk [CRYPTO_SECRET_KEY_BYTES]uint8
+		copy(sk[:], raw.Bytes)
+		defer zeroBytes(sk[:])
+		return newMLDSA87FromSecretKey(&sk)
+
+	case raw.Class == asn1.ClassUniversal && raw.Tag == asn1.TagSequence && raw.IsCompound:
+		var both privateKeyBoth
+		rest, err := asn1.Unmarshal(raw.FullBytes, &both)
+		defer zeroBytes(both.Seed)
+		defer zeroBytes(both.ExpandedKey)
+		if err != nil || len(rest) != 0 {
+			return nil, cryptoerrors.ErrInvalidEncoding
+		}
+		if len(both.Seed) != SEED_BYTES {
+			return nil, cryptoerrors.ErrInvalidSeed
+		}
+		if len(both.ExpandedKey) != CRYPTO_SECRET_KEY_BYTES {
+			return nil, cryptoerrors.ErrInvalidSecretKey
+		}
+		var seed [SEED_BYTES]uint8
+		copy(seed[:], both.Seed)
+		defer zeroBytes(seed[:])
+		d, err := NewMLDSA87FromSeed(seed)
+		if err != nil {
+			//coverage:ignore
+			//rationale: NewMLDSA87FromSeed only fails if sha3 operations fail, which never happens
+			return nil, err
+		}
+		if subtle.ConstantTimeCompare(d.sk[:], both.ExpandedKey) != 1 {
+			d.Zeroize()
+			return nil, cryptoerrors.ErrInvalidSecretKey
+		}
+		return d, nil
+
+	default:
+		return nil, cryptoerrors.ErrInvalidEncoding
+	}
+}
+
+// MarshalPKIXPublicKeyPEM is [MarshalPKIXPublicKey] wrapped in a
+// "PUBLIC KEY" PEM block.
+func MarshalPKIXPublicKeyPEM(pub crypto.PublicKey) ([]byte, error) {
+	der, err := MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PEM_PUBLIC_KEY_TYPE, Bytes: der}), nil
+}
+
+// ParsePKIXPublicKeyPEM decodes the first PEM block in data, which
+// must be of type "PUBLIC KEY", with [ParsePKIXPublicKey].
+func ParsePKIXPublicKeyPEM(data []byte) (*CryptoPublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != PEM_PUBLIC_KEY_TYPE {
+		return nil, cryptoerrors.ErrInvalidEncoding
+	}
+	return ParsePKIXPublicKey(block.Bytes)
+}
+
+// MarshalPKCS8PrivateKeyPEM is [MarshalPKCS8PrivateKey] wrapped in a
+// "PRIVATE KEY" PEM block. The result holds secret key material.
+func MarshalPKCS8PrivateKeyPEM(key crypto.PrivateKey, format PrivateKeyFormat) ([]byte, error) {
+	der, err := MarshalPKCS8PrivateKey(key, format)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroBytes(der)
+	return pem.EncodeToMemory(&pem.Block{Type: PEM_PRIVATE_KEY_TYPE, Bytes: der}), nil
+}
+
+// ParsePKCS8PrivateKeyPEM decodes the first PEM block in data, which
+// must be of type "PRIVATE KEY", with [ParsePKCS8PrivateKey].
+func ParsePKCS8PrivateKeyPEM(data []byte) (*MLDSA87, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != PEM_PRIVATE_KEY_TYPE {
+		return nil, cryptoerrors.ErrInvalidEncoding
+	}
+	defer zeroBytes(block.Bytes)
+	return ParsePKCS8PrivateKey(block.Bytes)
+}
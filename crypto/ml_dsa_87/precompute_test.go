@@ -0,0 +1,158 @@
+package ml_dsa_87
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+// TestPrecomputeMatchesUnexpanded checks that every deterministic
+// signing path produces byte-identical output with and without the
+// expanded key cache.
+func TestPrecomputeMatchesUnexpanded(t *testing.T) {
+	for _, vec := range katVectors {
+		t.Run(vec.name, func(t *testing.T) {
+			seedBytes, _ := hex.DecodeString(vec.seed)
+			msg, _ := hex.DecodeString(vec.message)
+			ctx, _ := hex.DecodeString(vec.ctx)
+			var seed [SEED_BYTES]uint8
+			copy(seed[:], seedBytes)
+
+			plain, err := NewMLDSA87FromSeed(seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer plain.Zeroize()
+			fast, err := NewMLDSA87FromSeed(seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer fast.Zeroize()
+			if err := fast.Precompute(); err != nil {
+				t.Fatalf("Precompute: %v", err)
+			}
+			if !fast.IsPrecomputed() || plain.IsPrecomputed() {
+				t.Fatal("IsPrecomputed does not reflect Precompute")
+			}
+
+			want, err := plain.SignDeterministic(ctx, msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := fast.SignDeterministic(ctx, msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got[:], want[:]) {
+				t.Error("precomputed SignDeterministic differs")
+			}
+
+			pk := fast.GetPK()
+			mu, err := ComputeMu(&pk, ctx, msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotMu, err := fast.SignMuDeterministic(mu)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(gotMu[:], want[:]) {
+				t.Error("precomputed SignMuDeterministic differs")
+			}
+		})
+	}
+}
+
+func TestPrecomputeHedgedSigningVerifies(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	if err := d.Precompute(); err != nil {
+		t.Fatal(err)
+	}
+	// A second call must be a harmless no-op.
+	if err := d.Precompute(); err != nil {
+		t.Fatal(err)
+	}
+	pk := d.GetPK()
+	ctx := []byte("ZOND")
+	msg := []byte("precomputed signing")
+
+	sig, err := d.Sign(ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify(ctx, msg, sig, &pk) {
+		t.Error("Verify rejected a precomputed Sign signature")
+	}
+
+	sm, err := d.SignAttached(ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Open(ctx, sm, &pk); err != nil || !bytes.Equal(got, msg) {
+		t.Errorf("Open = %q, %v", got, err)
+	}
+
+	digest := sha256.Sum256(msg)
+	psig, err := d.SignPrehash(ctx, SHA2_256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyPrehash(ctx, SHA2_256, digest[:], psig, &pk) {
+		t.Error("VerifyPrehash rejected a precomputed SignPrehash signature")
+	}
+
+	s, err := d.NewSigningStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Write(msg); err != nil {
+		t.Fatal(err)
+	}
+	ssig, err := s.Sign()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify(ctx, msg, ssig, &pk) {
+		t.Error("Verify rejected a precomputed streaming signature")
+	}
+}
+
+func TestZeroizeClearsPrecomputed(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Precompute(); err != nil {
+		t.Fatal(err)
+	}
+	esk := d.esk
+	d.Zeroize()
+
+	if d.IsPrecomputed() {
+		t.Error("Zeroize left the expanded key attached")
+	}
+	for i, b := range esk.key {
+		if b != 0 {
+			t.Fatalf("esk.key[%d] = %#x after Zeroize", i, b)
+		}
+	}
+	for i := range esk.s1.vec {
+		for j, c := range esk.s1.vec[i].coeffs {
+			if c != 0 {
+				t.Fatalf("esk.s1[%d][%d] = %d after Zeroize", i, j, c)
+			}
+		}
+	}
+	for i := range esk.t0.vec {
+		for j, c := range esk.t0.vec[i].coeffs {
+			if c != 0 {
+				t.Fatalf("esk.t0[%d][%d] = %d after Zeroize", i, j, c)
+			}
+		}
+	}
+}
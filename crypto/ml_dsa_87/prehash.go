@@ -0,0 +1,109 @@
+package ml_dsa_87
+
+import (
+	"crypto/rand"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/crypto/internal/prehash"
+)
+
+// PreHashFunction selects the hash or XOF used to pre-hash the message
+// for HashML-DSA (FIPS 204 §5.4). The caller computes the digest; the
+// library only needs the function's identity to embed its DER-encoded
+// OID in the signed message representative and to check the digest
+// length.
+//
+// The zero value is deliberately invalid so an unset field cannot
+// silently select a hash function. The table is shared with the other
+// pre-hash scheme in this module, so the same constants select the same
+// function in both.
+type PreHashFunction = prehash.Function
+
+const (
+	// SHA2_224 — SHA-224 (FIPS 180-4), 28-byte digest.
+	SHA2_224 = prehash.SHA2_224
+	// SHA2_256 — SHA-256 (FIPS 180-4), 32-byte digest.
+	SHA2_256 = prehash.SHA2_256
+	// SHA2_384 — SHA-384 (FIPS 180-4), 48-byte digest.
+	SHA2_384 = prehash.SHA2_384
+	// SHA2_512 — SHA-512 (FIPS 180-4), 64-byte digest.
+	SHA2_512 = prehash.SHA2_512
+	// SHA2_512_224 — SHA-512/224 (FIPS 180-4), 28-byte digest.
+	SHA2_512_224 = prehash.SHA2_512_224
+	// SHA2_512_256 — SHA-512/256 (FIPS 180-4), 32-byte digest.
+	SHA2_512_256 = prehash.SHA2_512_256
+	// SHA3_224 — SHA3-224 (FIPS 202), 28-byte digest.
+	SHA3_224 = prehash.SHA3_224
+	// SHA3_256 — SHA3-256 (FIPS 202), 32-byte digest.
+	SHA3_256 = prehash.SHA3_256
+	// SHA3_384 — SHA3-384 (FIPS 202), 48-byte digest.
+	SHA3_384 = prehash.SHA3_384
+	// SHA3_512 — SHA3-512 (FIPS 202), 64-byte digest.
+	SHA3_512 = prehash.SHA3_512
+	// SHAKE_128 — SHAKE128 (FIPS 202) with a 256-bit output, as fixed
+	// by FIPS 204 §5.4.
+	SHAKE_128 = prehash.SHAKE_128
+	// SHAKE_256 — SHAKE256 (FIPS 202) with a 512-bit output, as fixed
+	// by FIPS 204 §5.4.
+	SHAKE_256 = prehash.SHAKE_256
+)
+
+// PRE_HASH_OID_BYTES is the length of the DER-encoded OID that
+// HashML-DSA places between the context and the digest.
+const PRE_HASH_OID_BYTES = prehash.OID_BYTES
+
+// SignPrehash produces a HashML-DSA signature (FIPS 204 §5.4) over a
+// digest the caller has already computed with ph. The ctx parameter
+// has the same role and 255-byte limit as in [MLDSA87.Sign].
+//
+// digest must be exactly ph.DigestSize() bytes: ErrInvalidLength is
+// returned otherwise, ErrInvalidHashFunction for an unsupported ph and
+// ErrInvalidContext for an oversized ctx.
+//
+// HashML-DSA signatures are domain-separated from pure ML-DSA ones by
+// the leading 0x01 byte, so a SignPrehash signature never verifies
+// under [Verify] and vice versa; use [VerifyPrehash]. Signing is
+// hedged, as with [MLDSA87.Sign].
+func (d *MLDSA87) SignPrehash(ctx []uint8, ph PreHashFunction, digest []uint8) ([CRYPTO_BYTES]uint8, error) {
+	var signature [CRYPTO_BYTES]uint8
+	var rnd [RND_BYTES]uint8
+	if _, err := rand.Read(rnd[:]); err != nil {
+		//coverage:ignore
+		//rationale: crypto/rand.Read only fails if system entropy source is broken
+		return signature, cryptoerrors.ErrSeedGeneration
+	}
+	pre, err := prehash.Prefix(ctx, ph, digest)
+	if err != nil {
+		return signature, err
+	}
+	if err := d.signSignatureInternal(signature[:], digest, pre, rnd); err != nil {
+		return signature, err
+	}
+	return signature, nil
+}
+
+// VerifyPrehash checks a HashML-DSA signature produced by
+// [MLDSA87.SignPrehash] against digest, which must have been computed
+// with ph over the original message. Returns false if pk is nil, ph is
+// unsupported, the digest length does not match ph, or ctx exceeds 255
+// bytes.
+func VerifyPrehash(ctx []uint8, ph PreHashFunction, digest []uint8, signature [CRYPTO_BYTES]uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) bool {
+	if pk == nil {
+		return false
+	}
+	pre, err := prehash.Prefix(ctx, ph, digest)
+	if err != nil {
+		return false
+	}
+	return cryptoSignVerifyInternal(signature, digest, pre, pk) == nil
+}
+
+// cryptoSignPrehashWithRnd is the HashML-DSA counterpart of
+// [cryptoSignSignatureWithRnd]; ACVP vectors drive it with rnd=zero.
+func cryptoSignPrehashWithRnd(sig, digest []uint8, ctx []uint8, ph PreHashFunction, sk *[CRYPTO_SECRET_KEY_BYTES]uint8, rnd [RND_BYTES]uint8) error {
+	pre, err := prehash.Prefix(ctx, ph, digest)
+	if err != nil {
+		return err
+	}
+	return cryptoSignSignatureInternal(sig, digest, pre, rnd, sk)
+}
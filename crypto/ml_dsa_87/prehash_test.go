@@ -0,0 +1,237 @@
+package ml_dsa_87
+
+import (
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+// preHashDigest computes the HashML-DSA digest of msg under ph using
+// the standard library, mirroring what a caller would do before
+// SignPrehash.
+func preHashDigest(t *testing.T, ph PreHashFunction, msg []byte) []byte {
+	t.Helper()
+	switch ph {
+	case SHA2_224:
+		s := sha256.Sum224(msg)
+		return s[:]
+	case SHA2_256:
+		s := sha256.Sum256(msg)
+		return s[:]
+	case SHA2_384:
+		s := sha512.Sum384(msg)
+		return s[:]
+	case SHA2_512:
+		s := sha512.Sum512(msg)
+		return s[:]
+	case SHA2_512_224:
+		s := sha512.Sum512_224(msg)
+		return s[:]
+	case SHA2_512_256:
+		s := sha512.Sum512_256(msg)
+		return s[:]
+	case SHA3_224:
+		s := sha3.Sum224(msg)
+		return s[:]
+	case SHA3_256:
+		s := sha3.Sum256(msg)
+		return s[:]
+	case SHA3_384:
+		s := sha3.Sum384(msg)
+		return s[:]
+	case SHA3_512:
+		s := sha3.Sum512(msg)
+		return s[:]
+	case SHAKE_128:
+		return sha3.SumSHAKE128(msg, 32)
+	case SHAKE_256:
+		return sha3.SumSHAKE256(msg, 64)
+	}
+	t.Fatalf("unsupported pre-hash function %v", ph)
+	return nil
+}
+
+var allPreHashFunctions = []PreHashFunction{
+	SHA2_224, SHA2_256, SHA2_384, SHA2_512, SHA2_512_224, SHA2_512_256,
+	SHA3_224, SHA3_256, SHA3_384, SHA3_512, SHAKE_128, SHAKE_256,
+}
+
+func TestPreHashFunctionDigestSize(t *testing.T) {
+	for _, ph := range allPreHashFunctions {
+		if !ph.IsValid() {
+			t.Errorf("%v should be valid", ph)
+		}
+		if got, want := ph.DigestSize(), len(preHashDigest(t, ph, nil)); got != want {
+			t.Errorf("%v.DigestSize() = %d, want %d", ph, got, want)
+		}
+	}
+	for _, ph := range []PreHashFunction{0, SHAKE_256 + 1} {
+		if ph.IsValid() {
+			t.Errorf("%v should be invalid", ph)
+		}
+		if ph.DigestSize() != 0 {
+			t.Errorf("%v.DigestSize() = %d, want 0", ph, ph.DigestSize())
+		}
+	}
+}
+
+func TestSignPrehashVerifyPrehash(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+	ctx := []byte("document-signing")
+	msg := []byte("a multi-gigabyte file, hashed elsewhere")
+
+	for _, ph := range allPreHashFunctions {
+		t.Run(ph.String(), func(t *testing.T) {
+			digest := preHashDigest(t, ph, msg)
+			sig, err := d.SignPrehash(ctx, ph, digest)
+			if err != nil {
+				t.Fatalf("SignPrehash: %v", err)
+			}
+			if !VerifyPrehash(ctx, ph, digest, sig, &pk) {
+				t.Fatal("VerifyPrehash rejected a valid signature")
+			}
+			if VerifyPrehash([]byte("other"), ph, digest, sig, &pk) {
+				t.Error("VerifyPrehash accepted a signature under the wrong context")
+			}
+			other := preHashDigest(t, ph, []byte("different message"))
+			if VerifyPrehash(ctx, ph, other, sig, &pk) {
+				t.Error("VerifyPrehash accepted a signature over a different digest")
+			}
+			// The pure and pre-hash modes are domain-separated.
+			if Verify(ctx, digest, sig, &pk) {
+				t.Error("pure Verify accepted a HashML-DSA signature")
+			}
+		})
+	}
+}
+
+// TestSignPrehashWrongFunction checks that the OID binds the signature
+// to its hash function, even when two functions share a digest size.
+func TestSignPrehashWrongFunction(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+
+	digest := preHashDigest(t, SHA2_256, []byte("msg"))
+	sig, err := d.SignPrehash(nil, SHA2_256, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ph := range []PreHashFunction{SHA2_512_256, SHA3_256, SHAKE_128} {
+		if VerifyPrehash(nil, ph, digest, sig, &pk) {
+			t.Errorf("signature made with SHA2_256 verified as %v", ph)
+		}
+	}
+}
+
+func TestPureSignatureRejectedByVerifyPrehash(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+
+	digest := preHashDigest(t, SHA2_256, []byte("msg"))
+	sig, err := d.Sign(nil, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifyPrehash(nil, SHA2_256, digest, sig, &pk) {
+		t.Error("VerifyPrehash accepted a pure ML-DSA signature")
+	}
+}
+
+func TestSignPrehashErrors(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+	digest := preHashDigest(t, SHA2_256, []byte("msg"))
+
+	tests := []struct {
+		name   string
+		ctx    []byte
+		ph     PreHashFunction
+		digest []byte
+		want   error
+	}{
+		{"context too long", make([]byte, 256), SHA2_256, digest, cryptoerrors.ErrInvalidContext},
+		{"zero hash function", nil, 0, digest, cryptoerrors.ErrInvalidHashFunction},
+		{"unknown hash function", nil, SHAKE_256 + 1, digest, cryptoerrors.ErrInvalidHashFunction},
+		{"short digest", nil, SHA2_256, digest[:31], cryptoerrors.ErrInvalidLength},
+		{"digest for another function", nil, SHA2_512, digest, cryptoerrors.ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := d.SignPrehash(tt.ctx, tt.ph, tt.digest); !errors.Is(err, tt.want) {
+				t.Errorf("SignPrehash error = %v, want %v", err, tt.want)
+			}
+			var sig [CRYPTO_BYTES]uint8
+			if VerifyPrehash(tt.ctx, tt.ph, tt.digest, sig, &pk) {
+				t.Error("VerifyPrehash returned true for invalid input")
+			}
+		})
+	}
+
+	var sig [CRYPTO_BYTES]uint8
+	if VerifyPrehash(nil, SHA2_256, digest, sig, nil) {
+		t.Error("VerifyPrehash returned true for nil pk")
+	}
+}
+
+// TestKATSignPrehashDeterministic pins deterministic (rnd = 0)
+// HashML-DSA signatures for a fixed seed. The expected values match
+// Go 1.27's crypto/mldsa SignDeterministic with crypto.MLDSAMu over the
+// external mu of M' = 0x01 || len(ctx) || ctx || OID || PH(M); the
+// ACVP pre-hash vectors run under the "acvp" build tag.
+func TestKATSignPrehashDeterministic(t *testing.T) {
+	var seed [SEED_BYTES]uint8
+	for i := range seed {
+		seed[i] = uint8(i)
+	}
+	d, err := NewMLDSA87FromSeed(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	sk := d.GetSK()
+	msg := []byte("HashML-DSA known answer")
+	ctx := []byte("ZOND")
+
+	tests := []struct {
+		ph        PreHashFunction
+		sigSHA256 string
+	}{
+		{SHA2_256, "2a7d833b70446588ed624874a98d38942478d5f12526fbbaf1af1cad8e53a9fd"},
+		{SHAKE_256, "c5ae9759e11f617e15aacdb57d07270935762b588d8a5e1c8f367a044f1c4891"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ph.String(), func(t *testing.T) {
+			var rnd [RND_BYTES]uint8
+			sig := make([]uint8, CRYPTO_BYTES)
+			if err := cryptoSignPrehashWithRnd(sig, preHashDigest(t, tt.ph, msg), ctx, tt.ph, &sk, rnd); err != nil {
+				t.Fatalf("cryptoSignPrehashWithRnd: %v", err)
+			}
+			sum := sha256.Sum256(sig)
+			if got := hex.EncodeToString(sum[:]); got != tt.sigSHA256 {
+				t.Errorf("SHA-256(signature) = %s, want %s", got, tt.sigSHA256)
+			}
+		})
+	}
+}
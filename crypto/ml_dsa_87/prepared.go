@@ -0,0 +1,61 @@
+package ml_dsa_87
+
+import (
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+// PreparedPublicKey is an ML-DSA-87 public key with the state that
+// verification derives from it computed once up front: the matrix A
+// in NTT form, NTT(t1 * 2^d) and tr = H(pk). Verifying against a
+// PreparedPublicKey skips the SHAKE128 expansion of A and the hash of
+// the 2,592-byte key, which dominate the cost of [Verify] for short
+// messages.
+//
+// A PreparedPublicKey occupies roughly 64 KiB, so it pays off for keys
+// that verify many signatures (exchange hot wallets, validator keys)
+// rather than for one-off checks. It is immutable once created and
+// safe for concurrent use.
+type PreparedPublicKey struct {
+	pk  [CRYPTO_PUBLIC_KEY_BYTES]uint8
+	epk expandedPK
+}
+
+// PreparePublicKey expands pk for repeated verification. Returns
+// [cryptoerrors.ErrPublicKeyNil] if pk is nil.
+func PreparePublicKey(pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) (*PreparedPublicKey, error) {
+	if pk == nil {
+		return nil, cryptoerrors.ErrPublicKeyNil
+	}
+	p := &PreparedPublicKey{pk: *pk}
+	if err := expandPK(&p.epk, &p.pk); err != nil {
+		//coverage:ignore
+		//rationale: expandPK's sha3 operations never return errors
+		return nil, err
+	}
+	return p, nil
+}
+
+// Bytes returns a copy of the packed public key.
+func (p *PreparedPublicKey) Bytes() [CRYPTO_PUBLIC_KEY_BYTES]uint8 {
+	return p.pk
+}
+
+// Verify checks signature against message and context ctx. It accepts
+// exactly the signatures that [Verify] accepts for the same public key.
+// Returns false if len(ctx) > 255.
+func (p *PreparedPublicKey) Verify(ctx, message []uint8, signature [CRYPTO_BYTES]uint8) bool {
+	pre, err := messagePrefix(ctx)
+	if err != nil {
+		return false
+	}
+
+	var mu [CRH_BYTES]uint8
+	computeMu(&mu, p.epk.tr[:], pre, message)
+	return p.VerifyMu(mu, signature)
+}
+
+// VerifyMu checks signature against a message representative mu, as
+// [VerifyMu] does for a packed public key.
+func (p *PreparedPublicKey) VerifyMu(mu [MU_BYTES]uint8, signature [CRYPTO_BYTES]uint8) bool {
+	return cryptoSignVerifyMuExpanded(signature, &mu, &p.epk) == nil
+}
@@ -0,0 +1,129 @@
+package ml_dsa_87
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+func TestPreparedPublicKeyVerify(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+
+	p, err := PreparePublicKey(&pk)
+	if err != nil {
+		t.Fatalf("PreparePublicKey: %v", err)
+	}
+	if p.Bytes() != pk {
+		t.Error("Bytes() does not return the prepared key")
+	}
+
+	ctx := []byte("exchange")
+	for i := 0; i < 5; i++ {
+		msg := []byte{byte(i), 'm', 's', 'g'}
+		sig, err := d.Sign(ctx, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !p.Verify(ctx, msg, sig) {
+			t.Errorf("message %d: prepared Verify rejected a valid signature", i)
+		}
+		mu, err := ComputeMu(&pk, ctx, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !p.VerifyMu(mu, sig) {
+			t.Errorf("message %d: prepared VerifyMu rejected a valid signature", i)
+		}
+
+		if p.Verify(nil, msg, sig) {
+			t.Errorf("message %d: prepared Verify accepted the wrong context", i)
+		}
+		bad := sig
+		bad[100] ^= 0x80
+		if p.Verify(ctx, msg, bad) != Verify(ctx, msg, bad, &pk) {
+			t.Errorf("message %d: prepared Verify disagrees with Verify on a corrupted signature", i)
+		}
+	}
+
+	sig, err := d.Sign(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Verify(make([]byte, 256), nil, sig) {
+		t.Error("prepared Verify accepted an oversized context")
+	}
+}
+
+// TestPreparedPublicKeyWrongKey checks that a signature from one key
+// is rejected by another key's prepared form.
+func TestPreparedPublicKeyWrongKey(t *testing.T) {
+	d1, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d1.Zeroize()
+	d2, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d2.Zeroize()
+
+	pk2 := d2.GetPK()
+	p2, err := PreparePublicKey(&pk2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := d1.Sign(nil, []byte("msg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p2.Verify(nil, []byte("msg"), sig) {
+		t.Error("prepared key accepted a signature from another key")
+	}
+}
+
+func TestPreparePublicKeyNil(t *testing.T) {
+	if _, err := PreparePublicKey(nil); !errors.Is(err, cryptoerrors.ErrPublicKeyNil) {
+		t.Errorf("PreparePublicKey(nil) error = %v, want ErrPublicKeyNil", err)
+	}
+}
+
+// TestThreadSafetyPreparedPublicKey verifies concurrently against one
+// shared PreparedPublicKey. Run with -race.
+func TestThreadSafetyPreparedPublicKey(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+	p, err := PreparePublicKey(&pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("hot key")
+	sig, err := d.Sign(nil, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const numGoroutines = 20
+	var wg sync.WaitGroup
+	wg.Add(numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		go func() {
+			defer wg.Done()
+			if !p.Verify(nil, msg, sig) {
+				t.Error("concurrent prepared Verify failed")
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -145,53 +145,162 @@ func cryptoSignKeypair(seed *[SEED_BYTES]uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uin
 	return seed, nil
 }
 
-func cryptoSignSignatureInternal(sig, m []uint8, pre []uint8, rnd [RND_BYTES]uint8, sk *[CRYPTO_SECRET_KEY_BYTES]uint8) error {
+// cryptoSignPublicKeyFromSecretKey recomputes the public key that
+// belongs to the packed secret key sk and writes it to pk. It rejects
+// secret keys that are not the canonical output of key generation:
+// s1 and s2 must lie in [-ETA, ETA], and tr and t0 must match the
+// values re-derived from rho, s1 and s2. Any mismatch returns
+// ErrInvalidSecretKey without writing pk.
+func cryptoSignPublicKeyFromSecretKey(pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8, sk *[CRYPTO_SECRET_KEY_BYTES]uint8) error {
 	var rho, key [SEED_BYTES]uint8
 	var tr [TR_BYTES]uint8
-	var mu, rhoPrime [CRH_BYTES]uint8
-	var s1, y, z polyVecL
 	var mat [K]polyVecL
-	var s2, t0, w1, h, w0 polyVecK
-	var cp poly
-	var nonce uint16
-
-	unpackSk(&rho, &tr, &key, &t0, &s1, &s2, sk)
+	var s1, s1hat polyVecL
+	var s2, t0, t1, t0Packed polyVecK
+	var pkb [CRYPTO_PUBLIC_KEY_BYTES]uint8
+	var skb [CRYPTO_SECRET_KEY_BYTES]uint8
 
-	// Zeroize secret temporaries when signing completes.
-	// Go's GC may copy values before zeroization, but this still reduces
-	// the window for secrets persisting in freed memory.
 	defer func() {
 		zeroBytes(key[:])
-		zeroBytes(rhoPrime[:])
+		zeroBytes(skb[:])
 		zeroPolyVecL(&s1)
+		zeroPolyVecL(&s1hat)
 		zeroPolyVecK(&s2)
 		zeroPolyVecK(&t0)
+		zeroPolyVecK(&t0Packed)
 	}()
 
-	/* Compute mu = CRH(tr, 0, ctxlen, ctx, msg) */
+	unpackSk(&rho, &tr, &key, &t0Packed, &s1, &s2, sk)
+
+	/* polyEtaUnpack maps every 3-bit pattern, so reject out-of-range coefficients */
+	if polyVecLChkNorm(&s1, ETA+1) != 0 || polyVecKChkNorm(&s2, ETA+1) != 0 {
+		return cryptoerrors.ErrInvalidSecretKey
+	}
+
+	if err := polyVecMatrixExpand(&mat, &rho); err != nil {
+		//coverage:ignore
+		//rationale: polyVecMatrixExpand's sha3 operations never return errors
+		return err
+	}
+
+	/* t = A*s1 + s2, split into t1 and t0 exactly as in key generation */
+	s1hat = s1
+	polyVecLNTT(&s1hat)
+	polyVecMatrixPointWiseMontgomery(&t1, &mat, &s1hat)
+	polyVecKReduce(&t1)
+	polyVecKInvNTTToMont(&t1)
+	polyVecKAdd(&t1, &t1, &s2)
+	polyVecKCAddQ(&t1)
+	polyVecKPower2Round(&t1, &t0, &t1)
+	packPk(&pkb, rho, &t1)
+
+	/* Re-pack with the derived tr and t0; the result must equal sk byte for byte */
+	copy(tr[:], sha3.SumSHAKE256(pkb[:], TR_BYTES))
+	packSk(&skb, rho, tr, key, &t0, &s1, &s2)
+	if subtle.ConstantTimeCompare(skb[:], sk[:]) != 1 {
+		return cryptoerrors.ErrInvalidSecretKey
+	}
+
+	*pk = pkb
+	return nil
+}
+
+// computeMu computes the FIPS 204 message representative
+// mu = CRH(tr || pre || m), where pre is the pure (0x00) or pre-hash
+// (0x01) domain prefix including the context.
+func computeMu(mu *[CRH_BYTES]uint8, tr []uint8, pre, m []uint8) {
 	state := getShake256()
 	defer putShake256(state)
-	_, _ = state.Write(tr[:])
+	_, _ = state.Write(tr)
 	_, _ = state.Write(pre)
 	_, _ = state.Write(m)
 	_, _ = state.Read(mu[:]) // ShakeHash.Read never returns an error
+}
 
-	/* Compute rhoprime = CRH(key, rnd, mu) */
-	state.Reset() // Reuse pooled hasher
-	_, _ = state.Write(key[:])
-	_, _ = state.Write(rnd[:])
-	_, _ = state.Write(mu[:])
-	_, _ = state.Read(rhoPrime[:]) // ShakeHash.Read never returns an error
+func cryptoSignSignatureInternal(sig, m []uint8, pre []uint8, rnd [RND_BYTES]uint8, sk *[CRYPTO_SECRET_KEY_BYTES]uint8) error {
+	var mu [CRH_BYTES]uint8
+
+	/* Compute mu = CRH(tr, 0, ctxlen, ctx, msg); tr sits after rho and key in sk */
+	computeMu(&mu, sk[2*SEED_BYTES:2*SEED_BYTES+TR_BYTES], pre, m)
+
+	return cryptoSignSignatureMu(sig, &mu, rnd, sk)
+}
+
+// expandedSK holds the secret-key-derived state that signing
+// recomputes on every call: A in NTT form, s1, s2 and t0 in NTT form,
+// plus key and tr. It contains secret material and must be wiped with
+// zeroExpandedSK once no longer needed.
+type expandedSK struct {
+	key [SEED_BYTES]uint8
+	tr  [TR_BYTES]uint8
+	mat [K]polyVecL
+	s1  polyVecL
+	s2  polyVecK
+	t0  polyVecK
+}
+
+// expandSK fills esk from the packed secret key sk.
+func expandSK(esk *expandedSK, sk *[CRYPTO_SECRET_KEY_BYTES]uint8) error {
+	var rho [SEED_BYTES]uint8
+
+	unpackSk(&rho, &esk.tr, &esk.key, &esk.t0, &esk.s1, &esk.s2, sk)
 
 	/* Expand matrix and transform vectors */
-	if err := polyVecMatrixExpand(&mat, &rho); err != nil {
+	if err := polyVecMatrixExpand(&esk.mat, &rho); err != nil {
 		//coverage:ignore
 		//rationale: polyVecMatrixExpand's sha3 operations never return errors
 		return err
 	}
-	polyVecLNTT(&s1)
-	polyVecKNTT(&s2)
-	polyVecKNTT(&t0)
+	polyVecLNTT(&esk.s1)
+	polyVecKNTT(&esk.s2)
+	polyVecKNTT(&esk.t0)
+	return nil
+}
+
+func zeroExpandedSK(esk *expandedSK) {
+	zeroBytes(esk.key[:])
+	zeroPolyVecL(&esk.s1)
+	zeroPolyVecK(&esk.s2)
+	zeroPolyVecK(&esk.t0)
+}
+
+// cryptoSignSignatureMu signs a precomputed message representative mu
+// (FIPS 204 Algorithm 7 from line 7 onward, i.e. the external-mu
+// entry point).
+func cryptoSignSignatureMu(sig []uint8, mu *[CRH_BYTES]uint8, rnd [RND_BYTES]uint8, sk *[CRYPTO_SECRET_KEY_BYTES]uint8) error {
+	var esk expandedSK
+
+	// Zeroize secret temporaries when signing completes.
+	// Go's GC may copy values before zeroization, but this still reduces
+	// the window for secrets persisting in freed memory.
+	defer zeroExpandedSK(&esk)
+
+	if err := expandSK(&esk, sk); err != nil {
+		//coverage:ignore
+		//rationale: expandSK's sha3 operations never return errors
+		return err
+	}
+	return cryptoSignSignatureMuExpanded(sig, mu, rnd, &esk)
+}
+
+// cryptoSignSignatureMuExpanded is [cryptoSignSignatureMu] against an
+// already expanded secret key. esk is only read.
+func cryptoSignSignatureMuExpanded(sig []uint8, mu *[CRH_BYTES]uint8, rnd [RND_BYTES]uint8, esk *expandedSK) error {
+	var rhoPrime [CRH_BYTES]uint8
+	var y, z polyVecL
+	var w1, h, w0 polyVecK
+	var cp poly
+	var nonce uint16
+
+	defer zeroBytes(rhoPrime[:])
+
+	/* Compute rhoprime = CRH(key, rnd, mu) */
+	state := getShake256()
+	defer putShake256(state)
+	_, _ = state.Write(esk.key[:])
+	_, _ = state.Write(rnd[:])
+	_, _ = state.Write(mu[:])
+	_, _ = state.Read(rhoPrime[:]) // ShakeHash.Read never returns an error
 
 rej:
 
@@ -202,7 +311,7 @@ rej:
 	/* Matrix-vector multiplication */
 	z = y
 	polyVecLNTT(&z)
-	polyVecMatrixPointWiseMontgomery(&w1, &mat, &z)
+	polyVecMatrixPointWiseMontgomery(&w1, &esk.mat, &z)
 	polyVecKReduce(&w1)
 	polyVecKInvNTTToMont(&w1)
 
@@ -239,7 +348,7 @@ rej:
 	polyNTT(&cp)
 
 	/* Compute z, reject if it reveals secret */
-	polyVecLPointWisePolyMontgomery(&z, &cp, &s1)
+	polyVecLPointWisePolyMontgomery(&z, &cp, &esk.s1)
 	polyVecLInvNTTToMont(&z)
 	polyVecLAdd(&z, &z, &y)
 	polyVecLReduce(&z)
@@ -249,7 +358,7 @@ rej:
 
 	/* Check that subtracting cs2 does not change high bits of w and low bits
 	 * do not reveal secret information */
-	polyVecKPointWisePolyMontgomery(&h, &cp, &s2)
+	polyVecKPointWisePolyMontgomery(&h, &cp, &esk.s2)
 	polyVecKInvNTTToMont(&h)
 	polyVecKSub(&w0, &w0, &h)
 	polyVecKReduce(&w0)
@@ -258,7 +367,7 @@ rej:
 	}
 
 	/* Compute hints for w1 */
-	polyVecKPointWisePolyMontgomery(&h, &cp, &t0)
+	polyVecKPointWisePolyMontgomery(&h, &cp, &esk.t0)
 	polyVecKInvNTTToMont(&h)
 	polyVecKReduce(&h)
 	if polyVecKChkNorm(&h, GAMMA2) != 0 {
@@ -284,9 +393,22 @@ rej:
 	return nil
 }
 
-// cryptoSignSignature is the standard hedged-signing entry point. It
+// messagePrefix returns the pure ML-DSA domain prefix
+// `0x00 || len(ctx) || ctx` (FIPS 204 Algorithm 2, line 10).
+func messagePrefix(ctx []uint8) ([]uint8, error) {
+	if len(ctx) > 255 {
+		return nil, cryptoerrors.ErrInvalidContext
+	}
+	pre := make([]uint8, len(ctx)+2)
+	pre[0] = 0
+	pre[1] = uint8(len(ctx))
+	copy(pre[2:], ctx)
+	return pre, nil
+}
+
+// signSignature is the standard hedged-signing entry point. It
 // reads RND_BYTES from crypto/rand and calls
-// [cryptoSignSignatureWithRnd]. Per FIPS 204 §3.4, hedged (randomised)
+// [MLDSA87.signSignatureWithRnd]. Per FIPS 204 §3.4, hedged (randomised)
 // signing reduces side-channel and fault-injection leverage relative
 // to the deterministic variant; all public ML-DSA-87 signing in this
 // library uses this path. (TOB-QRLLIB-6.)
@@ -294,14 +416,71 @@ rej:
 // Callers needing an explicit rnd value (the crypto.Signer.Sign
 // caller-supplied io.Reader path; ACVP / KAT determinism tests with
 // rnd=zero) call [cryptoSignSignatureWithRnd] directly.
-func cryptoSignSignature(sig, m []uint8, ctx []uint8, sk *[CRYPTO_SECRET_KEY_BYTES]uint8) error {
+func (d *MLDSA87) signSignature(sig, m []uint8, ctx []uint8) error {
 	var rnd [RND_BYTES]uint8
 	if _, err := rand.Read(rnd[:]); err != nil {
 		//coverage:ignore
 		//rationale: crypto/rand.Read only fails if system entropy source is broken
 		return cryptoerrors.ErrSeedGeneration
 	}
-	return cryptoSignSignatureWithRnd(sig, m, ctx, sk, rnd)
+	return d.signSignatureWithRnd(sig, m, ctx, rnd)
+}
+
+// signSignatureWithRnd is [cryptoSignSignatureWithRnd] for d's key,
+// using the expanded key from [MLDSA87.Precompute] when present. Both
+// paths produce byte-identical signatures.
+func (d *MLDSA87) signSignatureWithRnd(sig, m []uint8, ctx []uint8, rnd [RND_BYTES]uint8) error {
+	pre, err := messagePrefix(ctx)
+	if err != nil {
+		return err
+	}
+	return d.signSignatureInternal(sig, m, pre, rnd)
+}
+
+// signSignatureInternal signs m under the domain prefix pre (pure or
+// pre-hash) with d's key.
+func (d *MLDSA87) signSignatureInternal(sig, m []uint8, pre []uint8, rnd [RND_BYTES]uint8) error {
+	var err error
+	if d.esk == nil {
+		err = cryptoSignSignatureInternal(sig, m, pre, rnd, &d.sk)
+	} else {
+		var mu [CRH_BYTES]uint8
+		computeMu(&mu, d.esk.tr[:], pre, m)
+		err = cryptoSignSignatureMuExpanded(sig, &mu, rnd, d.esk)
+	}
+	if err != nil || !d.verifyAfterSign {
+		return err
+	}
+	var s [CRYPTO_BYTES]uint8
+	copy(s[:], sig)
+	return checkSignature(sig, cryptoSignVerifyInternal(s, m, pre, &d.pk))
+}
+
+// signMu signs the message representative mu with d's key.
+func (d *MLDSA87) signMu(sig []uint8, mu *[CRH_BYTES]uint8, rnd [RND_BYTES]uint8) error {
+	var err error
+	if d.esk == nil {
+		err = cryptoSignSignatureMu(sig, mu, rnd, &d.sk)
+	} else {
+		err = cryptoSignSignatureMuExpanded(sig, mu, rnd, d.esk)
+	}
+	if err != nil || !d.verifyAfterSign {
+		return err
+	}
+	var s [CRYPTO_BYTES]uint8
+	copy(s[:], sig)
+	return checkSignature(sig, cryptoSignVerifyMu(s, mu, &d.pk))
+}
+
+// checkSignature finishes the verify-after-sign check: if the fresh
+// signature sig failed verification (verifyErr != nil) it is wiped and
+// ErrSigningFailed is returned.
+func checkSignature(sig []uint8, verifyErr error) error {
+	if verifyErr != nil {
+		zeroBytes(sig)
+		return cryptoerrors.ErrSigningFailed
+	}
+	return nil
 }
 
 // cryptoSignSignatureWithRnd signs m using the explicit rnd value
@@ -309,23 +488,22 @@ func cryptoSignSignature(sig, m []uint8, ctx []uint8, sk *[CRYPTO_SECRET_KEY_BYT
 // Pass an all-zero rnd for FIPS-204-deterministic signing (used by
 // ACVP / KAT vectors); pass entropy from crypto/rand or an
 // authenticated source for hedged signing. The crypto.Signer wrapper
-// uses this path when the caller supplies an io.Reader.
+// uses this path (via [MLDSA87.signSignatureWithRnd]) when the caller
+// supplies an io.Reader.
 func cryptoSignSignatureWithRnd(sig, m []uint8, ctx []uint8, sk *[CRYPTO_SECRET_KEY_BYTES]uint8, rnd [RND_BYTES]uint8) error {
-	if len(ctx) > 255 {
-		return cryptoerrors.ErrInvalidContext
+	pre, err := messagePrefix(ctx)
+	if err != nil {
+		return err
 	}
-	pre := make([]uint8, len(ctx)+2)
-	pre[0] = 0
-	pre[1] = uint8(len(ctx))
-	copy(pre[2:], ctx)
-	return cryptoSignSignatureInternal(sig, m, pre[:], rnd, sk)
+	return cryptoSignSignatureInternal(sig, m, pre, rnd, sk)
 }
 
-// attached sig wrappers
-func cryptoSign(msg []uint8, ctx []uint8, sk *[CRYPTO_SECRET_KEY_BYTES]uint8) ([]uint8, error) {
+// signAttached is the attached-signature wrapper: it returns
+// `signature || msg`.
+func (d *MLDSA87) signAttached(msg []uint8, ctx []uint8) ([]uint8, error) {
 	sm := make([]uint8, CRYPTO_BYTES+len(msg))
 	copy(sm[CRYPTO_BYTES:], msg)
-	err := cryptoSignSignature(sm[:CRYPTO_BYTES], sm[CRYPTO_BYTES:], ctx, sk)
+	err := d.signSignature(sm[:CRYPTO_BYTES], sm[CRYPTO_BYTES:], ctx)
 	if err != nil {
 		for i := range sm {
 			sm[i] = 0
@@ -335,70 +513,87 @@ func cryptoSign(msg []uint8, ctx []uint8, sk *[CRYPTO_SECRET_KEY_BYTES]uint8) ([
 	return sm, nil
 }
 
-func cryptoSignVerifyInternal(sig [CRYPTO_BYTES]uint8, m []uint8, pre []uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) (bool, error) {
-	var buf [K * POLY_W1_PACKED_BYTES]uint8
-	var rho [SEED_BYTES]uint8
+func cryptoSignVerifyInternal(sig [CRYPTO_BYTES]uint8, m []uint8, pre []uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) error {
 	var mu [CRH_BYTES]uint8
-	var c, c2 [C_TILDE_BYTES]uint8
-	var cp poly
-	var mat [K]polyVecL
-	var z polyVecL
-	var t1, w1, h polyVecK
-
-	unpackPk(&rho, &t1, pk)
-	if unpackSig(&c, &z, &h, sig) != 0 {
-		return false, nil
-	}
-	if polyVecLChkNorm(&z, GAMMA1-BETA) != 0 {
-		return false, nil
-	}
 
 	/* Compute CRH(H(rho, t1), pre, msg) */
-	copy(mu[:TR_BYTES], sha3.SumSHAKE256(pk[:CRYPTO_PUBLIC_KEY_BYTES], TR_BYTES))
-	state := getShake256()
-	defer putShake256(state)
-	if _, err := state.Write(mu[:TR_BYTES]); err != nil {
+	computeMu(&mu, sha3.SumSHAKE256(pk[:CRYPTO_PUBLIC_KEY_BYTES], TR_BYTES), pre, m)
+
+	return cryptoSignVerifyMu(sig, &mu, pk)
+}
+
+// expandedPK holds the public-key-derived state that verification
+// recomputes on every call: A in NTT form (ExpandA samples directly in
+// the NTT domain), NTT(t1 * 2^d) and tr = H(pk). It is read-only once
+// filled in, so one expandedPK may be shared by concurrent verifiers.
+type expandedPK struct {
+	mat [K]polyVecL
+	t1  polyVecK
+	tr  [TR_BYTES]uint8
+}
+
+// expandPK fills epk from the packed public key pk.
+func expandPK(epk *expandedPK, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) error {
+	var rho [SEED_BYTES]uint8
+
+	unpackPk(&rho, &epk.t1, pk)
+	if err := polyVecMatrixExpand(&epk.mat, &rho); err != nil {
 		//coverage:ignore
-		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
-		return false, err
+		//rationale: polyVecMatrixExpand's sha3 operations never return errors
+		return err
 	}
-	if _, err := state.Write(pre); err != nil {
+	polyVecKShiftL(&epk.t1)
+	polyVecKNTT(&epk.t1)
+	copy(epk.tr[:], sha3.SumSHAKE256(pk[:], TR_BYTES))
+	return nil
+}
+
+// cryptoSignVerifyMu verifies sig against a precomputed message
+// representative mu (FIPS 204 Algorithm 8 from line 7 onward). It
+// returns nil for a valid signature and the reason otherwise; see
+// [cryptoSignVerifyMuExpanded].
+func cryptoSignVerifyMu(sig [CRYPTO_BYTES]uint8, mu *[CRH_BYTES]uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) error {
+	var epk expandedPK
+	if err := expandPK(&epk, pk); err != nil {
 		//coverage:ignore
-		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
-		return false, err
+		//rationale: expandPK's sha3 operations never return errors
+		return err
 	}
-	if _, err := state.Write(m); err != nil {
-		//coverage:ignore
-		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
-		return false, err
+	return cryptoSignVerifyMuExpanded(sig, mu, &epk)
+}
+
+// cryptoSignVerifyMuExpanded is [cryptoSignVerifyMu] against an
+// already expanded public key. epk is only read. A rejected signature
+// yields ErrNonCanonicalHint, ErrSignatureNormExceeded or, when the
+// recomputed challenge differs, ErrInvalidSignature.
+func cryptoSignVerifyMuExpanded(sig [CRYPTO_BYTES]uint8, mu *[CRH_BYTES]uint8, epk *expandedPK) error {
+	var buf [K * POLY_W1_PACKED_BYTES]uint8
+	var c, c2 [C_TILDE_BYTES]uint8
+	var cp poly
+	var z polyVecL
+	var ct1, w1, h polyVecK
+
+	if unpackSig(&c, &z, &h, sig) != 0 {
+		return cryptoerrors.ErrNonCanonicalHint
 	}
-	if _, err := state.Read(mu[:CRH_BYTES]); err != nil {
-		//coverage:ignore
-		//rationale: sha3.ShakeHash.Read never returns an error for XOF
-		return false, err
+	if polyVecLChkNorm(&z, GAMMA1-BETA) != 0 {
+		return cryptoerrors.ErrSignatureNormExceeded
 	}
 
 	/* Matrix-vector multiplication; compute Az - c2^dt1 */
 	if err := polyChallenge(&cp, c[:]); err != nil {
 		//coverage:ignore
 		//rationale: polyChallenge's sha3 operations never return errors
-		return false, err
-	}
-	if err := polyVecMatrixExpand(&mat, &rho); err != nil {
-		//coverage:ignore
-		//rationale: polyVecMatrixExpand's sha3 operations never return errors
-		return false, err
+		return err
 	}
 
 	polyVecLNTT(&z)
-	polyVecMatrixPointWiseMontgomery(&w1, &mat, &z)
+	polyVecMatrixPointWiseMontgomery(&w1, &epk.mat, &z)
 
 	polyNTT(&cp)
-	polyVecKShiftL(&t1)
-	polyVecKNTT(&t1)
-	polyVecKPointWisePolyMontgomery(&t1, &cp, &t1)
+	polyVecKPointWisePolyMontgomery(&ct1, &cp, &epk.t1)
 
-	polyVecKSub(&w1, &w1, &t1)
+	polyVecKSub(&w1, &w1, &ct1)
 	polyVecKReduce(&w1)
 	polyVecKInvNTTToMont(&w1)
 
@@ -408,48 +603,48 @@ func cryptoSignVerifyInternal(sig [CRYPTO_BYTES]uint8, m []uint8, pre []uint8, p
 	if err := polyVecKPackW1(buf[:], &w1); err != nil {
 		//coverage:ignore
 		//rationale: buf is always correctly sized for K*POLY_W1_PACKED_BYTES
-		return false, err
+		return err
 	}
 
 	/* Call random oracle and verify challenge */
-	state.Reset() // Reuse pooled hasher
+	state := getShake256()
+	defer putShake256(state)
 	if _, err := state.Write(mu[:CRH_BYTES]); err != nil {
 		//coverage:ignore
 		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
-		return false, err
+		return err
 	}
 	if _, err := state.Write(buf[:K*POLY_W1_PACKED_BYTES]); err != nil {
 		//coverage:ignore
 		//rationale: sha3.ShakeHash.Write never returns an error per Go's hash.Hash contract
-		return false, err
+		return err
 	}
 	if _, err := state.Read(c2[:C_TILDE_BYTES]); err != nil {
 		//coverage:ignore
 		//rationale: sha3.ShakeHash.Read never returns an error for XOF
-		return false, err
+		return err
 	}
 
 	// Use constant-time comparison to prevent timing side-channel attacks
-	return subtle.ConstantTimeCompare(c[:], c2[:]) == 1, nil
+	if subtle.ConstantTimeCompare(c[:], c2[:]) != 1 {
+		return cryptoerrors.ErrInvalidSignature
+	}
+	return nil
 }
 
-func cryptoSignVerify(sig [CRYPTO_BYTES]uint8, m []uint8, ctx []uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) (bool, error) {
+func cryptoSignVerify(sig [CRYPTO_BYTES]uint8, m []uint8, ctx []uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) error {
 	// Defense-in-depth nil-check (TOB-QRLLIB-11). The public Verify/Open
 	// wrappers also check, but this internal entry point is reachable
 	// from crypto.Signer (via cryptoSign etc.) and any future caller.
 	if pk == nil {
-		return false, cryptoerrors.ErrPublicKeyNil
+		return cryptoerrors.ErrPublicKeyNil
 	}
-	if len(ctx) > 255 {
-		return false, cryptoerrors.ErrInvalidContext
+	pre, err := messagePrefix(ctx)
+	if err != nil {
+		return err
 	}
 
-	pre := make([]uint8, len(ctx)+2)
-	pre[0] = 0
-	pre[1] = uint8(len(ctx))
-	copy(pre[2:], ctx)
-
-	return cryptoSignVerifyInternal(sig, m, pre[:], pk)
+	return cryptoSignVerifyInternal(sig, m, pre, pk)
 }
 
 func cryptoSignOpen(sm []uint8, ctx []uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) ([]uint8, error) {
@@ -467,13 +662,9 @@ func cryptoSignOpen(sm []uint8, ctx []uint8, pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8)
 	copy(sig[:], sm)
 	copy(msg, sm[CRYPTO_BYTES:])
 
-	result, err := cryptoSignVerify(sig, msg, ctx, pk)
-	if err != nil {
+	if err := cryptoSignVerify(sig, msg, ctx, pk); err != nil {
 		return nil, err
 	}
-	if !result {
-		return nil, cryptoerrors.ErrInvalidSignature
-	}
 
 	return msg, nil
 }
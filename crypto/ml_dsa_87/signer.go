@@ -7,7 +7,7 @@ import (
 	"io"
 )
 
-var errUnsupportedSignerOpts = errors.New("ml_dsa_87: opts must be *SignerOpts or nil")
+var errUnsupportedSignerOpts = errors.New("ml_dsa_87: opts.HashFunc() must be 0; pre-hashed signing is not supported")
 
 // SignerOpts carries the FIPS 204 context for use with crypto.Signer.
 type SignerOpts struct {
@@ -21,6 +21,15 @@ type CryptoPublicKey struct {
 	key [CRYPTO_PUBLIC_KEY_BYTES]uint8
 }
 
+// NewCryptoPublicKey wraps a packed ML-DSA-87 public key. Returns nil
+// if pk is nil.
+func NewCryptoPublicKey(pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8) *CryptoPublicKey {
+	if pk == nil {
+		return nil
+	}
+	return &CryptoPublicKey{key: *pk}
+}
+
 func (pk *CryptoPublicKey) Equal(x crypto.PublicKey) bool {
 	other, ok := x.(*CryptoPublicKey)
 	if !ok {
@@ -34,12 +43,13 @@ func (pk *CryptoPublicKey) Bytes() [CRYPTO_PUBLIC_KEY_BYTES]uint8 {
 	return pk.key
 }
 
-// CryptoSigner wraps an MLDSA87 instance to implement crypto.Signer.
+// CryptoSigner wraps an MLDSA87 instance to implement crypto.Signer and
+// crypto.MessageSigner.
 type CryptoSigner struct {
 	d *MLDSA87
 }
 
-// NewCryptoSigner returns a crypto.Signer backed by the given MLDSA87 instance.
+// NewCryptoSigner returns a crypto.MessageSigner backed by the given MLDSA87 instance.
 func NewCryptoSigner(d *MLDSA87) *CryptoSigner {
 	return &CryptoSigner{d: d}
 }
@@ -49,15 +59,25 @@ func (s *CryptoSigner) Public() crypto.PublicKey {
 	return &CryptoPublicKey{key: pk}
 }
 
-// Sign implements crypto.Signer. The opts parameter must be *SignerOpts
-// (to provide the FIPS 204 context) or nil (empty context). Passing
-// any other SignerOpts type returns an error.
+// Sign implements crypto.Signer. ML-DSA signs whole messages, so
+// digest is the message itself, not a hash of it; Sign behaves exactly
+// like [CryptoSigner.SignMessage], which generic code should prefer via
+// [crypto.SignMessage].
+func (s *CryptoSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.SignMessage(rand, digest, opts)
+}
+
+// SignMessage implements crypto.MessageSigner, signing msg in full. The
+// opts parameter may be *SignerOpts to provide the FIPS 204 context;
+// nil or any other SignerOpts whose HashFunc is 0 (such as the
+// crypto.Hash(0) passed by crypto.SignMessage) selects the empty
+// context. A non-zero HashFunc returns an error.
 //
 // The rand parameter, when non-nil, is honoured as the source of the
 // per-signature RND_BYTES (FIPS 204 §3.5 hedged signing); when nil,
 // crypto/rand is used. Either way signing is hedged — the deterministic
 // path was removed in TOB-QRLLIB-6 alongside the rand-discarding bug.
-func (s *CryptoSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+func (s *CryptoSigner) SignMessage(rand io.Reader, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
 	var ctx []byte
 	switch o := opts.(type) {
 	case *SignerOpts:
@@ -67,12 +87,15 @@ func (s *CryptoSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpt
 	case nil:
 		// empty context
 	default:
-		return nil, errUnsupportedSignerOpts
+		if o.HashFunc() != 0 {
+			return nil, errUnsupportedSignerOpts
+		}
+		// empty context
 	}
 
 	// nil rand → standard hedged path (crypto/rand under the hood).
 	if rand == nil {
-		sig, err := s.d.Sign(ctx, digest)
+		sig, err := s.d.Sign(ctx, msg)
 		if err != nil {
 			return nil, err
 		}
@@ -87,7 +110,7 @@ func (s *CryptoSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpt
 		return nil, err
 	}
 	var sigBuf [CRYPTO_BYTES]uint8
-	if err := cryptoSignSignatureWithRnd(sigBuf[:], digest, ctx, &s.d.sk, rnd); err != nil {
+	if err := s.d.signSignatureWithRnd(sigBuf[:], msg, ctx, rnd); err != nil {
 		return nil, err
 	}
 	return sigBuf[:], nil
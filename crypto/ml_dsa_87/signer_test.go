@@ -273,10 +273,36 @@ func TestCryptoSignerUnsupportedOpts(t *testing.T) {
 	signer := NewCryptoSigner(d)
 	msg := []byte("test message")
 
-	// Passing a non-*SignerOpts type should return an error
+	// Opts selecting a pre-hash function should return an error
 	_, err = signer.Sign(nil, msg, crypto.SHA256)
 	if err == nil {
-		t.Error("Expected error for unsupported SignerOpts type")
+		t.Error("Expected error for SignerOpts with a non-zero HashFunc")
+	}
+}
+
+// TestCryptoSignerZeroHashOpts checks that crypto.SignMessage with
+// crypto.Hash(0), the opts generic callers pass for pure signing, signs
+// under the empty context.
+func TestCryptoSignerZeroHashOpts(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+
+	signer := NewCryptoSigner(d)
+	msg := []byte("test message")
+
+	sig, err := crypto.SignMessage(signer, nil, msg, crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("crypto.SignMessage with crypto.Hash(0) failed: %v", err)
+	}
+
+	pk := d.GetPK()
+	var sigArr [CRYPTO_BYTES]uint8
+	copy(sigArr[:], sig)
+	if !Verify(nil, msg, sigArr, &pk) {
+		t.Error("Signature with crypto.Hash(0) opts failed verification with empty context")
 	}
 }
 
@@ -402,3 +428,46 @@ func TestCryptoSignerRandSuppliedContextTooLong(t *testing.T) {
 		t.Error("expected an error for context > 255 bytes via the rand-supplied path")
 	}
 }
+
+// TestCryptoSignerMessageSigner checks that CryptoSigner satisfies
+// crypto.MessageSigner, that crypto.SignMessage dispatches to it
+// without hashing, and that Sign and SignMessage are interchangeable.
+func TestCryptoSignerMessageSigner(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	signer := NewCryptoSigner(d)
+	var _ crypto.MessageSigner = signer
+
+	msg := []byte("message signer test")
+	opts := &SignerOpts{Context: []byte("ctx")}
+	pk := d.GetPK()
+
+	sig, err := crypto.SignMessage(signer, nil, msg, opts)
+	if err != nil {
+		t.Fatalf("crypto.SignMessage failed: %v", err)
+	}
+	var sigArr [CRYPTO_BYTES]uint8
+	copy(sigArr[:], sig)
+	if !Verify(opts.Context, msg, sigArr, &pk) {
+		t.Error("crypto.SignMessage signature failed verification over the unhashed message")
+	}
+
+	viaSign, err := signer.Sign(zeroReader{}, msg, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	viaSignMessage, err := signer.SignMessage(zeroReader{}, msg, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(viaSign, viaSignMessage) {
+		t.Error("Sign and SignMessage produced different signatures from the same rand")
+	}
+
+	if _, err := signer.SignMessage(nil, msg, crypto.SHA256); !errors.Is(err, errUnsupportedSignerOpts) {
+		t.Errorf("SignMessage with crypto.SHA256 opts: got %v, want %v", err, errUnsupportedSignerOpts)
+	}
+}
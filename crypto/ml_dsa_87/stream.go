@@ -0,0 +1,140 @@
+package ml_dsa_87
+
+import (
+	"crypto/rand"
+	"crypto/sha3"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+// muStream absorbs `tr || 0x00 || len(ctx) || ctx` up front and the
+// message incrementally, so that mu can be squeezed once the caller
+// has written the whole message.
+type muStream struct {
+	state     *sha3.SHAKE
+	finalized bool
+}
+
+func newMuStream(tr []uint8, ctx []uint8) (*muStream, error) {
+	pre, err := messagePrefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Not taken from shake256Pool: the stream's lifetime is controlled
+	// by the caller and it may never be finalized.
+	state := sha3.NewSHAKE256()
+	_, _ = state.Write(tr)
+	_, _ = state.Write(pre)
+	return &muStream{state: state}, nil
+}
+
+func (s *muStream) Write(p []byte) (int, error) {
+	if s.finalized {
+		return 0, cryptoerrors.ErrStreamFinalized
+	}
+	return s.state.Write(p) // ShakeHash.Write never returns an error
+}
+
+func (s *muStream) finalize() ([MU_BYTES]uint8, error) {
+	var mu [MU_BYTES]uint8
+	if s.finalized {
+		return mu, cryptoerrors.ErrStreamFinalized
+	}
+	s.finalized = true
+	_, _ = s.state.Read(mu[:]) // ShakeHash.Read never returns an error
+	s.state.Reset()
+	return mu, nil
+}
+
+// SigningStream is an [io.Writer] that absorbs a message into the
+// ML-DSA-87 mu computation as it is written, so that arbitrarily large
+// messages can be signed without buffering them. Create one with
+// [MLDSA87.NewSigningStream], write the message, then call
+// [SigningStream.Sign] exactly once.
+//
+// A SigningStream is not safe for concurrent use, and the MLDSA87 it
+// was created from must not be zeroized before Sign is called.
+type SigningStream struct {
+	mu *muStream
+	d  *MLDSA87
+}
+
+// NewSigningStream starts a streaming signature under ctx (FIPS 204
+// context, max 255 bytes). The signature produced by the stream is
+// identical in format to [MLDSA87.Sign] and verifies under [Verify]
+// over the concatenation of everything written.
+func (d *MLDSA87) NewSigningStream(ctx []uint8) (*SigningStream, error) {
+	// tr is stored in sk right after rho and key.
+	s, err := newMuStream(d.sk[2*SEED_BYTES:2*SEED_BYTES+TR_BYTES], ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &SigningStream{mu: s, d: d}, nil
+}
+
+// Write absorbs p into the message. It never returns an error before
+// the stream is finalized, and [cryptoerrors.ErrStreamFinalized]
+// afterwards.
+func (s *SigningStream) Write(p []byte) (int, error) {
+	return s.mu.Write(p)
+}
+
+// Sign finalizes the stream and returns a hedged (FIPS 204 §3.4)
+// detached signature over the written message. Subsequent calls to
+// Write or Sign return [cryptoerrors.ErrStreamFinalized].
+func (s *SigningStream) Sign() ([CRYPTO_BYTES]uint8, error) {
+	var signature [CRYPTO_BYTES]uint8
+	mu, err := s.mu.finalize()
+	if err != nil {
+		return signature, err
+	}
+	var rnd [RND_BYTES]uint8
+	if _, err := rand.Read(rnd[:]); err != nil {
+		//coverage:ignore
+		//rationale: crypto/rand.Read only fails if system entropy source is broken
+		return signature, cryptoerrors.ErrSeedGeneration
+	}
+	if err := s.d.signMu(signature[:], &mu, rnd); err != nil {
+		return signature, err
+	}
+	return signature, nil
+}
+
+// VerifyingStream is the verification counterpart of [SigningStream]:
+// an [io.Writer] that absorbs the message and then checks a signature
+// with [VerifyingStream.Verify]. It is not safe for concurrent use.
+type VerifyingStream struct {
+	mu *muStream
+	pk [CRYPTO_PUBLIC_KEY_BYTES]uint8
+}
+
+// NewVerifyingStream starts streaming verification of a message under
+// pk and ctx. Returns [cryptoerrors.ErrPublicKeyNil] if pk is nil and
+// [cryptoerrors.ErrInvalidContext] if len(ctx) > 255.
+func NewVerifyingStream(pk *[CRYPTO_PUBLIC_KEY_BYTES]uint8, ctx []uint8) (*VerifyingStream, error) {
+	if pk == nil {
+		return nil, cryptoerrors.ErrPublicKeyNil
+	}
+	s, err := newMuStream(sha3.SumSHAKE256(pk[:], TR_BYTES), ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &VerifyingStream{mu: s, pk: *pk}, nil
+}
+
+// Write absorbs p into the message. It never returns an error before
+// the stream is finalized, and [cryptoerrors.ErrStreamFinalized]
+// afterwards.
+func (s *VerifyingStream) Write(p []byte) (int, error) {
+	return s.mu.Write(p)
+}
+
+// Verify finalizes the stream and reports whether signature is valid
+// for the written message. It returns false if called more than once.
+func (s *VerifyingStream) Verify(signature [CRYPTO_BYTES]uint8) bool {
+	mu, err := s.mu.finalize()
+	if err != nil {
+		return false
+	}
+	return cryptoSignVerifyMu(signature, &mu, &s.pk) == nil
+}
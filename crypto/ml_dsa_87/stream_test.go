@@ -0,0 +1,171 @@
+package ml_dsa_87
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+func TestSigningStreamVerifies(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+	ctx := []byte("file-signing")
+
+	msg := make([]byte, 1<<20+17)
+	if _, err := rand.Read(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := d.NewSigningStream(ctx)
+	if err != nil {
+		t.Fatalf("NewSigningStream: %v", err)
+	}
+	// Uneven chunk sizes exercise partial-block absorption.
+	if _, err := io.CopyBuffer(s, bytes.NewReader(msg), make([]byte, 4093)); err != nil {
+		t.Fatalf("io.Copy: %v", err)
+	}
+	sig, err := s.Sign()
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	if !Verify(ctx, msg, sig, &pk) {
+		t.Error("Verify rejected a streamed signature")
+	}
+	if Verify([]byte("other"), msg, sig, &pk) {
+		t.Error("Verify accepted a streamed signature under another context")
+	}
+}
+
+func TestVerifyingStream(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+	ctx := []byte("ctx")
+	msg := []byte("The sleeper must awaken")
+
+	sig, err := d.Sign(ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := NewVerifyingStream(&pk, ctx)
+	if err != nil {
+		t.Fatalf("NewVerifyingStream: %v", err)
+	}
+	for _, b := range msg {
+		if _, err := v.Write([]byte{b}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !v.Verify(sig) {
+		t.Error("VerifyingStream rejected a valid signature")
+	}
+	if v.Verify(sig) {
+		t.Error("second Verify on a finalized stream should return false")
+	}
+
+	v, err = NewVerifyingStream(&pk, ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = v.Write(msg[:len(msg)-1])
+	if v.Verify(sig) {
+		t.Error("VerifyingStream accepted a truncated message")
+	}
+}
+
+// TestStreamMuMatchesComputeMu checks that the streamed mu is the same
+// value ComputeMu produces over the whole message, so streaming does
+// not change the signature format.
+func TestStreamMuMatchesComputeMu(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+	ctx := []byte("ZOND")
+	msg := bytes.Repeat([]byte("0123456789"), 100)
+
+	want, err := ComputeMu(&pk, ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := d.NewSigningStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = s.Write(msg[:333])
+	_, _ = s.Write(msg[333:])
+	got, err := s.mu.finalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Error("signing stream mu differs from ComputeMu")
+	}
+
+	v, err := NewVerifyingStream(&pk, ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = v.Write(msg)
+	if got, _ = v.mu.finalize(); got != want {
+		t.Error("verifying stream mu differs from ComputeMu")
+	}
+}
+
+func TestStreamFinalized(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+
+	s, err := d.NewSigningStream(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Sign(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Write([]byte("late")); !errors.Is(err, cryptoerrors.ErrStreamFinalized) {
+		t.Errorf("Write after Sign error = %v, want ErrStreamFinalized", err)
+	}
+	if _, err := s.Sign(); !errors.Is(err, cryptoerrors.ErrStreamFinalized) {
+		t.Errorf("second Sign error = %v, want ErrStreamFinalized", err)
+	}
+}
+
+func TestStreamConstructorErrors(t *testing.T) {
+	d, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Zeroize()
+	pk := d.GetPK()
+	longCtx := make([]byte, 256)
+
+	if _, err := d.NewSigningStream(longCtx); !errors.Is(err, cryptoerrors.ErrInvalidContext) {
+		t.Errorf("NewSigningStream error = %v, want ErrInvalidContext", err)
+	}
+	if _, err := NewVerifyingStream(&pk, longCtx); !errors.Is(err, cryptoerrors.ErrInvalidContext) {
+		t.Errorf("NewVerifyingStream error = %v, want ErrInvalidContext", err)
+	}
+	if _, err := NewVerifyingStream(nil, nil); !errors.Is(err, cryptoerrors.ErrPublicKeyNil) {
+		t.Errorf("NewVerifyingStream(nil) error = %v, want ErrPublicKeyNil", err)
+	}
+}
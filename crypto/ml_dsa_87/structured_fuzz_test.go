@@ -219,7 +219,11 @@ func FuzzMLDSA87FromHexSeedAndSigner(f *testing.F) {
 			return
 		}
 
-		roundTrip, err := NewMLDSA87FromHexSeed(mldsa.GetHexSeed())
+		hexSeedOut, err := mldsa.GetHexSeed()
+		if err != nil {
+			t.Fatalf("GetHexSeed: %v", err)
+		}
+		roundTrip, err := NewMLDSA87FromHexSeed(hexSeedOut)
 		if err != nil {
 			t.Fatalf("Round-trip seed decode failed: %v", err)
 		}
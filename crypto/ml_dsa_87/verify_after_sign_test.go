@@ -0,0 +1,110 @@
+package ml_dsa_87
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+// signAllPaths signs msg through every public signing entry point and
+// returns each path's detached signature (the attached one with its
+// message stripped) and error.
+func signAllPaths(t *testing.T, d *MLDSA87, ctx, msg []uint8) (map[string][]uint8, map[string]error) {
+	t.Helper()
+	sigs := map[string][]uint8{}
+	errs := map[string]error{}
+	record := func(name string, sig []uint8, err error) {
+		sigs[name], errs[name] = sig, err
+	}
+
+	sig, err := d.Sign(ctx, msg)
+	record("Sign", sig[:], err)
+	sm, err := d.SignAttached(ctx, msg)
+	if sm != nil {
+		sm = sm[:CRYPTO_BYTES]
+	}
+	record("SignAttached", sm, err)
+	sig, err = d.SignDeterministic(ctx, msg)
+	record("SignDeterministic", sig[:], err)
+
+	pk := d.GetPK()
+	mu, err := ComputeMu(&pk, ctx, msg)
+	if err != nil {
+		t.Fatalf("ComputeMu: %v", err)
+	}
+	sig, err = d.SignMu(mu)
+	record("SignMu", sig[:], err)
+	sig, err = d.SignMuDeterministic(mu)
+	record("SignMuDeterministic", sig[:], err)
+
+	digest := sha256.Sum256(msg)
+	sig, err = d.SignPrehash(ctx, SHA2_256, digest[:])
+	record("SignPrehash", sig[:], err)
+
+	stream, err := d.NewSigningStream(ctx)
+	if err != nil {
+		t.Fatalf("NewSigningStream: %v", err)
+	}
+	if _, err := stream.Write(msg); err != nil {
+		t.Fatalf("stream.Write: %v", err)
+	}
+	sig, err = stream.Sign()
+	record("SigningStream", sig[:], err)
+
+	cs, err := NewCryptoSigner(d).SignMessage(nil, msg, &SignerOpts{Context: ctx})
+	record("CryptoSigner", cs, err)
+	return sigs, errs
+}
+
+func TestVerifyAfterSign(t *testing.T) {
+	ctx := []uint8("verify-after-sign")
+	msg := []uint8("message")
+
+	d := newMLDSA87FromSeed(t, HexSeed)
+	d.SetVerifyAfterSign(true)
+	_, errs := signAllPaths(t, d, ctx, msg)
+	for name, err := range errs {
+		if err != nil {
+			t.Errorf("%s with a sound key: %v", name, err)
+		}
+	}
+
+	// Simulate a fault by corrupting the public key the check runs
+	// against: every signature now fails verification.
+	d.pk[0] ^= 0x01
+	sigs, errs := signAllPaths(t, d, ctx, msg)
+	for name, err := range errs {
+		if !errors.Is(err, cryptoerrors.ErrSigningFailed) {
+			t.Errorf("%s error = %v, want %v", name, err, cryptoerrors.ErrSigningFailed)
+		}
+		for _, b := range sigs[name] {
+			if b != 0 {
+				t.Errorf("%s left signature bytes behind", name)
+				break
+			}
+		}
+	}
+
+	// With the check off the same key signs without complaint.
+	d.SetVerifyAfterSign(false)
+	if _, err := d.Sign(ctx, msg); err != nil {
+		t.Errorf("Sign with verify-after-sign off: %v", err)
+	}
+}
+
+func TestVerifyAfterSignPrecomputed(t *testing.T) {
+	d := newMLDSA87FromSeed(t, HexSeed)
+	if err := d.Precompute(); err != nil {
+		t.Fatalf("Precompute: %v", err)
+	}
+	d.SetVerifyAfterSign(true)
+	if _, err := d.Sign(nil, []uint8("message")); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	d.pk[0] ^= 0x01
+	if _, err := d.Sign(nil, []uint8("message")); !errors.Is(err, cryptoerrors.ErrSigningFailed) {
+		t.Errorf("error = %v, want %v", err, cryptoerrors.ErrSigningFailed)
+	}
+}
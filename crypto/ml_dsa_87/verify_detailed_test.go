@@ -0,0 +1,65 @@
+package ml_dsa_87
+
+import (
+	"errors"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+func TestVerifyDetailed(t *testing.T) {
+	d := newMLDSA87FromSeed(t, HexSeed)
+	pk := d.GetPK()
+	ctx := []uint8("verify-detailed")
+	msg := []uint8("message")
+	sig, err := d.Sign(ctx, msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	// A hint count above OMEGA is not a canonical hint encoding.
+	badHint := sig
+	badHint[CRYPTO_BYTES-1] = OMEGA + 1
+	// A packed z coefficient of 0 unpacks to GAMMA1, outside the bound.
+	badNorm := sig
+	badNorm[C_TILDE_BYTES] = 0
+	badNorm[C_TILDE_BYTES+1] = 0
+	badNorm[C_TILDE_BYTES+2] &^= 0x0f
+
+	tests := []struct {
+		name string
+		ctx  []uint8
+		msg  []uint8
+		sig  [CRYPTO_BYTES]uint8
+		pk   *[CRYPTO_PUBLIC_KEY_BYTES]uint8
+		want error
+	}{
+		{"valid", ctx, msg, sig, &pk, nil},
+		{"nil_pk", ctx, msg, sig, nil, cryptoerrors.ErrPublicKeyNil},
+		{"long_ctx", make([]uint8, 256), msg, sig, &pk, cryptoerrors.ErrInvalidContext},
+		{"non_canonical_hint", ctx, msg, badHint, &pk, cryptoerrors.ErrNonCanonicalHint},
+		{"norm_exceeded", ctx, msg, badNorm, &pk, cryptoerrors.ErrSignatureNormExceeded},
+		{"wrong_message", ctx, []uint8("other"), sig, &pk, cryptoerrors.ErrInvalidSignature},
+		{"wrong_ctx", nil, msg, sig, &pk, cryptoerrors.ErrInvalidSignature},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := VerifyDetailed(tc.ctx, tc.msg, tc.sig, tc.pk)
+			if !errors.Is(err, tc.want) || (tc.want == nil) != (err == nil) {
+				t.Errorf("VerifyDetailed error = %v, want %v", err, tc.want)
+			}
+			if got := Verify(tc.ctx, tc.msg, tc.sig, tc.pk); got != (tc.want == nil) {
+				t.Errorf("Verify = %v, want %v", got, tc.want == nil)
+			}
+		})
+	}
+
+	// Malformed signatures still count as invalid signatures, so Open
+	// keeps reporting ErrInvalidSignature for them.
+	for _, bad := range [][CRYPTO_BYTES]uint8{badHint, badNorm} {
+		sm := append(bad[:], msg...)
+		if _, err := Open(ctx, sm, &pk); !errors.Is(err, cryptoerrors.ErrInvalidSignature) {
+			t.Errorf("Open error = %v, want %v", err, cryptoerrors.ErrInvalidSignature)
+		}
+	}
+}
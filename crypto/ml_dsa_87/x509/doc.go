@@ -0,0 +1,43 @@
+// Package x509 builds, parses and verifies X.509 v3 certificates and
+// PKCS #10 certificate requests whose keys and signatures are
+// ML-DSA-87.
+//
+// # Why a separate package
+//
+// The standard library's crypto/x509 only signs with the key types it
+// knows, so it cannot issue certificates from a
+// [github.com/theQRL/go-qrllib/crypto/ml_dsa_87.CryptoSigner]. This
+// package covers the subset needed to run an internal ML-DSA-87 PKI:
+//
+//   - [CreateCertificate] / [ParseCertificate] for v3 certificates
+//     with basicConstraints, keyUsage, subjectKeyIdentifier,
+//     authorityKeyIdentifier and subjectAltName (DNS, email, IP, URI).
+//   - [CreateCertificateRequest] / [ParseCertificateRequest] for
+//     PKCS #10 requests carrying subjectAltName in an extensionRequest.
+//   - [Certificate.Verify] to build and check a chain against a
+//     [CertPool] of ML-DSA-87 trust anchors at a given time.
+//
+// Both ends of every chain are ML-DSA-87: certificates signed with any
+// other algorithm, or carrying any other public key type, are rejected
+// with [ErrUnsupportedAlgorithm].
+//
+// # Encoding
+//
+// Keys and signatures follow the IETF lamps ML-DSA certificates draft
+// (RFC 9881): the AlgorithmIdentifier is id-ml-dsa-87
+// (2.16.840.1.101.3.4.3.19) with absent parameters, and the signature
+// is pure ML-DSA-87 over the DER TBSCertificate with an empty context.
+// Only the digitalSignature, nonRepudiation, keyCertSign and cRLSign
+// key usages are meaningful for a signature-only key, so [KeyUsage]
+// offers exactly those.
+//
+// PEM framing is left to encoding/pem with the usual "CERTIFICATE" and
+// "CERTIFICATE REQUEST" block types.
+//
+// # Verification scope
+//
+// [Certificate.Verify] checks signatures, validity periods, the CA
+// flag, keyCertSign and path length constraints, and rejects unknown
+// critical extensions. It does not check revocation, name constraints,
+// policies, extended key usage or host names.
+package x509
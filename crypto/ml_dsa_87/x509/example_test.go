@@ -0,0 +1,81 @@
+package x509_test
+
+import (
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87/x509"
+)
+
+// Example issues a self-signed root and a server certificate, then
+// verifies the server certificate against the root.
+func Example() {
+	rootKey, err := ml_dsa_87.New()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer rootKey.Zeroize()
+	rootSigner := ml_dsa_87.NewCryptoSigner(rootKey)
+
+	now := time.Now()
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Internal Root CA"},
+		NotBefore:             now,
+		NotAfter:              now.AddDate(10, 0, 0),
+		KeyUsage:              x509.KEY_USAGE_CERT_SIGN | x509.KEY_USAGE_CRL_SIGN,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	rootPub := rootSigner.Public().(*ml_dsa_87.CryptoPublicKey)
+	rootDER, err := x509.CreateCertificate(nil, rootTemplate, rootTemplate, rootPub, rootSigner)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	root, err := x509.ParseCertificate(rootDER)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	serverKey, err := ml_dsa_87.New()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer serverKey.Zeroize()
+	serverPub := ml_dsa_87.NewCryptoSigner(serverKey).Public().(*ml_dsa_87.CryptoPublicKey)
+
+	serverDER, err := x509.CreateCertificate(nil, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "api.internal"},
+		DNSNames:     []string{"api.internal"},
+		NotBefore:    now,
+		NotAfter:     now.AddDate(0, 3, 0),
+		KeyUsage:     x509.KEY_USAGE_DIGITAL_SIGNATURE,
+	}, root, serverPub, rootSigner)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	server, err := x509.ParseCertificate(serverDER)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	chain, err := server.Verify(x509.VerifyOptions{Roots: roots})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println("Chain:", chain[0].Subject.CommonName, "->", chain[1].Subject.CommonName)
+	// Output: Chain: api.internal -> Internal Root CA
+}
@@ -0,0 +1,317 @@
+package x509
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+)
+
+var (
+	oidExtensionSubjectKeyId     = asn1.ObjectIdentifier{2, 5, 29, 14}
+	oidExtensionKeyUsage         = asn1.ObjectIdentifier{2, 5, 29, 15}
+	oidExtensionSubjectAltName   = asn1.ObjectIdentifier{2, 5, 29, 17}
+	oidExtensionBasicConstraints = asn1.ObjectIdentifier{2, 5, 29, 19}
+	oidExtensionAuthorityKeyId   = asn1.ObjectIdentifier{2, 5, 29, 35}
+)
+
+// GeneralName tags used in subjectAltName (RFC 5280 §4.2.1.6).
+const (
+	nameTypeEmail = 1
+	nameTypeDNS   = 2
+	nameTypeURI   = 6
+	nameTypeIP    = 7
+)
+
+type basicConstraints struct {
+	IsCA       bool `asn1:"optional"`
+	MaxPathLen int  `asn1:"optional,default:-1"`
+}
+
+type authorityKeyIdentifier struct {
+	Id []byte `asn1:"optional,tag:0"`
+}
+
+// keyIdentifier derives a key identifier from pub with RFC 7093
+// method 1: the leftmost 160 bits of SHA-256 over the subjectPublicKey
+// BIT STRING contents.
+func keyIdentifier(pub *ml_dsa_87.CryptoPublicKey) []byte {
+	pk := pub.Bytes()
+	sum := sha256.Sum256(pk[:])
+	return sum[:20]
+}
+
+// buildExtensions returns the extensions for a certificate created from
+// template. subjectEmpty makes subjectAltName critical, as RFC 5280
+// requires when the subject name is empty.
+func buildExtensions(template *Certificate, subjectEmpty bool, subjectKeyId, authorityKeyId []byte) ([]pkix.Extension, error) {
+	var exts []pkix.Extension
+
+	if template.BasicConstraintsValid {
+		maxPathLen := template.MaxPathLen
+		if maxPathLen == 0 && !template.MaxPathLenZero {
+			maxPathLen = -1
+		}
+		ext, err := marshalExtension(oidExtensionBasicConstraints, true,
+			basicConstraints{IsCA: template.IsCA, MaxPathLen: maxPathLen})
+		if err != nil {
+			return nil, err
+		}
+		exts = append(exts, ext)
+	}
+
+	if template.KeyUsage != 0 {
+		ext, err := marshalExtension(oidExtensionKeyUsage, true, marshalKeyUsage(template.KeyUsage))
+		if err != nil {
+			//coverage:ignore
+			//rationale: asn1.Marshal of a BitString cannot fail
+			return nil, err
+		}
+		exts = append(exts, ext)
+	}
+
+	if len(subjectKeyId) > 0 {
+		ext, err := marshalExtension(oidExtensionSubjectKeyId, false, subjectKeyId)
+		if err != nil {
+			//coverage:ignore
+			//rationale: asn1.Marshal of a byte slice cannot fail
+			return nil, err
+		}
+		exts = append(exts, ext)
+	}
+
+	if len(authorityKeyId) > 0 {
+		ext, err := marshalExtension(oidExtensionAuthorityKeyId, false, authorityKeyIdentifier{Id: authorityKeyId})
+		if err != nil {
+			//coverage:ignore
+			//rationale: asn1.Marshal of a tagged byte slice cannot fail
+			return nil, err
+		}
+		exts = append(exts, ext)
+	}
+
+	if hasSANs(template.DNSNames, template.EmailAddresses, template.IPAddresses, template.URIs) {
+		ext, err := marshalSANs(template.DNSNames, template.EmailAddresses, template.IPAddresses, template.URIs)
+		if err != nil {
+			return nil, err
+		}
+		ext.Critical = subjectEmpty
+		exts = append(exts, ext)
+	}
+
+	return mergeExtraExtensions(exts, template.ExtraExtensions), nil
+}
+
+// mergeExtraExtensions appends extra to exts, with each extra extension
+// replacing a generated one of the same OID.
+func mergeExtraExtensions(exts, extra []pkix.Extension) []pkix.Extension {
+	for _, e := range extra {
+		replaced := false
+		for i := range exts {
+			if exts[i].Id.Equal(e.Id) {
+				exts[i] = e
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			exts = append(exts, e)
+		}
+	}
+	return exts
+}
+
+func marshalExtension(id asn1.ObjectIdentifier, critical bool, v any) (pkix.Extension, error) {
+	value, err := asn1.Marshal(v)
+	if err != nil {
+		return pkix.Extension{}, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	return pkix.Extension{Id: id, Critical: critical, Value: value}, nil
+}
+
+// marshalKeyUsage encodes ku as the DER keyUsage BIT STRING: bit 0 is
+// the most significant bit of the first octet and trailing zero bits
+// are dropped.
+func marshalKeyUsage(ku KeyUsage) asn1.BitString {
+	var b [2]byte
+	for i := 0; i < 9; i++ {
+		if ku&(1<<i) != 0 {
+			b[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	n := 1
+	if b[1] != 0 {
+		n = 2
+	}
+	bitLength := 8 * n
+	for bitLength > 0 && b[(bitLength-1)/8]&(0x80>>((bitLength-1)%8)) == 0 {
+		bitLength--
+	}
+	return asn1.BitString{Bytes: b[:n], BitLength: bitLength}
+}
+
+func parseKeyUsage(bs asn1.BitString) KeyUsage {
+	var ku KeyUsage
+	for i := 0; i < 9; i++ {
+		if bs.At(i) != 0 {
+			ku |= 1 << i
+		}
+	}
+	return ku
+}
+
+func hasSANs(dnsNames, emails []string, ips []net.IP, uris []*url.URL) bool {
+	return len(dnsNames) > 0 || len(emails) > 0 || len(ips) > 0 || len(uris) > 0
+}
+
+func isIA5String(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] > 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// marshalSANs encodes a non-critical subjectAltName extension.
+func marshalSANs(dnsNames, emails []string, ips []net.IP, uris []*url.URL) (pkix.Extension, error) {
+	var names []asn1.RawValue
+	for _, name := range dnsNames {
+		if !isIA5String(name) {
+			return pkix.Extension{}, fmt.Errorf("%w: DNS name %q is not ASCII", ErrInvalidTemplate, name)
+		}
+		names = append(names, asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: nameTypeDNS, Bytes: []byte(name)})
+	}
+	for _, email := range emails {
+		if !isIA5String(email) {
+			return pkix.Extension{}, fmt.Errorf("%w: email address %q is not ASCII", ErrInvalidTemplate, email)
+		}
+		names = append(names, asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: nameTypeEmail, Bytes: []byte(email)})
+	}
+	for _, ip := range ips {
+		raw := ip.To4()
+		if raw == nil {
+			raw = ip.To16()
+		}
+		if raw == nil {
+			return pkix.Extension{}, fmt.Errorf("%w: invalid IP address", ErrInvalidTemplate)
+		}
+		names = append(names, asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: nameTypeIP, Bytes: raw})
+	}
+	for _, u := range uris {
+		if u == nil {
+			return pkix.Extension{}, fmt.Errorf("%w: nil URI", ErrInvalidTemplate)
+		}
+		s := u.String()
+		if !isIA5String(s) {
+			return pkix.Extension{}, fmt.Errorf("%w: URI %q is not ASCII", ErrInvalidTemplate, s)
+		}
+		names = append(names, asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: nameTypeURI, Bytes: []byte(s)})
+	}
+	return marshalExtension(oidExtensionSubjectAltName, false, names)
+}
+
+// parseSANs decodes a subjectAltName value. GeneralName forms other
+// than DNS, email, IP and URI are skipped.
+func parseSANs(value []byte) (dnsNames, emails []string, ips []net.IP, uris []*url.URL, err error) {
+	var seq asn1.RawValue
+	rest, err := asn1.Unmarshal(value, &seq)
+	if err != nil || len(rest) != 0 || seq.Class != asn1.ClassUniversal || seq.Tag != asn1.TagSequence || !seq.IsCompound {
+		return nil, nil, nil, nil, ErrMalformedCertificate
+	}
+	rest = seq.Bytes
+	for len(rest) > 0 {
+		var name asn1.RawValue
+		rest, err = asn1.Unmarshal(rest, &name)
+		if err != nil {
+			return nil, nil, nil, nil, ErrMalformedCertificate
+		}
+		if name.Class != asn1.ClassContextSpecific {
+			return nil, nil, nil, nil, ErrMalformedCertificate
+		}
+		switch name.Tag {
+		case nameTypeDNS:
+			if !isIA5String(string(name.Bytes)) {
+				return nil, nil, nil, nil, ErrMalformedCertificate
+			}
+			dnsNames = append(dnsNames, string(name.Bytes))
+		case nameTypeEmail:
+			if !isIA5String(string(name.Bytes)) {
+				return nil, nil, nil, nil, ErrMalformedCertificate
+			}
+			emails = append(emails, string(name.Bytes))
+		case nameTypeURI:
+			u, err := url.Parse(string(name.Bytes))
+			if err != nil || !isIA5String(string(name.Bytes)) {
+				return nil, nil, nil, nil, ErrMalformedCertificate
+			}
+			uris = append(uris, u)
+		case nameTypeIP:
+			if len(name.Bytes) != net.IPv4len && len(name.Bytes) != net.IPv6len {
+				return nil, nil, nil, nil, ErrMalformedCertificate
+			}
+			ips = append(ips, net.IP(bytes.Clone(name.Bytes)))
+		}
+	}
+	return dnsNames, emails, ips, uris, nil
+}
+
+// parseCertificateExtensions fills the extension-derived fields of c
+// from c.Extensions.
+func parseCertificateExtensions(c *Certificate) error {
+	seen := make(map[string]bool, len(c.Extensions))
+	for _, ext := range c.Extensions {
+		key := ext.Id.String()
+		if seen[key] {
+			return ErrMalformedCertificate
+		}
+		seen[key] = true
+
+		var err error
+		switch {
+		case ext.Id.Equal(oidExtensionBasicConstraints):
+			var bc basicConstraints
+			err = unmarshalExtension(ext.Value, &bc)
+			if err == nil && (bc.MaxPathLen < -1 || (!bc.IsCA && bc.MaxPathLen != -1)) {
+				err = ErrMalformedCertificate
+			}
+			c.BasicConstraintsValid = true
+			c.IsCA = bc.IsCA
+			c.MaxPathLen = bc.MaxPathLen
+			c.MaxPathLenZero = bc.MaxPathLen == 0
+		case ext.Id.Equal(oidExtensionKeyUsage):
+			var bs asn1.BitString
+			err = unmarshalExtension(ext.Value, &bs)
+			c.KeyUsage = parseKeyUsage(bs)
+		case ext.Id.Equal(oidExtensionSubjectKeyId):
+			err = unmarshalExtension(ext.Value, &c.SubjectKeyId)
+		case ext.Id.Equal(oidExtensionAuthorityKeyId):
+			var aki authorityKeyIdentifier
+			err = unmarshalExtension(ext.Value, &aki)
+			c.AuthorityKeyId = aki.Id
+		case ext.Id.Equal(oidExtensionSubjectAltName):
+			c.DNSNames, c.EmailAddresses, c.IPAddresses, c.URIs, err = parseSANs(ext.Value)
+		default:
+			if ext.Critical {
+				c.UnhandledCriticalExtensions = append(c.UnhandledCriticalExtensions, ext.Id)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func unmarshalExtension(value []byte, v any) error {
+	rest, err := asn1.Unmarshal(value, v)
+	if err != nil || len(rest) != 0 {
+		return ErrMalformedCertificate
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package x509
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+)
+
+// oidExtensionRequest is the PKCS #9 extensionRequest attribute
+// (RFC 2985 §5.4.2), which carries requested extensions in a CSR.
+var oidExtensionRequest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 14}
+
+// CertificateRequest is a parsed PKCS #10 certificate signing request,
+// or a template for [CreateCertificateRequest]. Fields prefixed Raw
+// are set by [ParseCertificateRequest] only.
+type CertificateRequest struct {
+	Raw                      []byte // complete DER request
+	RawTBSCertificateRequest []byte // DER CertificationRequestInfo, the signed part
+	RawSubjectPublicKeyInfo  []byte
+	RawSubject               []byte
+
+	Signature []byte
+
+	Version   int
+	Subject   pkix.Name
+	PublicKey *ml_dsa_87.CryptoPublicKey
+
+	// Subject alternative names, carried in an extensionRequest
+	// attribute.
+	DNSNames       []string
+	EmailAddresses []string
+	IPAddresses    []net.IP
+	URIs           []*url.URL
+
+	// Extensions holds the requested extensions of a parsed request.
+	// ExtraExtensions is added to a created request and replaces a
+	// generated extension with the same OID.
+	Extensions      []pkix.Extension
+	ExtraExtensions []pkix.Extension
+}
+
+type certificateRequest struct {
+	Raw                asn1.RawContent
+	TBSCSR             tbsCertificateRequest
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+type tbsCertificateRequest struct {
+	Raw           asn1.RawContent
+	Version       int
+	Subject       asn1.RawValue
+	PublicKey     asn1.RawValue
+	RawAttributes []asn1.RawValue `asn1:"tag:0"`
+}
+
+type pkcs10Attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+// CreateCertificateRequest creates a DER PKCS #10 request for the
+// signer's public key, self-signed with signer as proof of possession.
+// Subject alternative names and ExtraExtensions from template are
+// placed in an extensionRequest attribute.
+//
+// rand is passed to [ml_dsa_87.CryptoSigner.SignMessage]; nil selects
+// crypto/rand.
+func CreateCertificateRequest(rand io.Reader, template *CertificateRequest, signer *ml_dsa_87.CryptoSigner) ([]byte, error) {
+	if template == nil || signer == nil {
+		return nil, fmt.Errorf("%w: nil template or signer", ErrInvalidTemplate)
+	}
+
+	spki, err := ml_dsa_87.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		//coverage:ignore
+		//rationale: ml_dsa_87.CryptoSigner.Public always returns a *CryptoPublicKey
+		return nil, err
+	}
+	subject, err := marshalName(template.RawSubject, template.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	var exts []pkix.Extension
+	if hasSANs(template.DNSNames, template.EmailAddresses, template.IPAddresses, template.URIs) {
+		ext, err := marshalSANs(template.DNSNames, template.EmailAddresses, template.IPAddresses, template.URIs)
+		if err != nil {
+			return nil, err
+		}
+		exts = append(exts, ext)
+	}
+	exts = mergeExtraExtensions(exts, template.ExtraExtensions)
+
+	var attributes []asn1.RawValue
+	if len(exts) > 0 {
+		extsDER, err := asn1.Marshal(exts)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+		}
+		attrDER, err := asn1.Marshal(pkcs10Attribute{
+			Type:   oidExtensionRequest,
+			Values: []asn1.RawValue{{FullBytes: extsDER}},
+		})
+		if err != nil {
+			//coverage:ignore
+			//rationale: the attribute holds an OID and pre-encoded DER only
+			return nil, err
+		}
+		attributes = append(attributes, asn1.RawValue{FullBytes: attrDER})
+	}
+
+	tbs := tbsCertificateRequest{
+		Subject:       asn1.RawValue{FullBytes: subject},
+		PublicKey:     asn1.RawValue{FullBytes: spki},
+		RawAttributes: attributes,
+	}
+	tbsDER, err := asn1.Marshal(tbs)
+	if err != nil {
+		//coverage:ignore
+		//rationale: every field is either an int or pre-encoded DER
+		return nil, err
+	}
+	tbs.Raw = tbsDER
+
+	signature, err := signer.SignMessage(rand, tbsDER, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(certificateRequest{
+		TBSCSR:             tbs,
+		SignatureAlgorithm: signatureAlgorithm(),
+		SignatureValue:     asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)},
+	})
+}
+
+// ParseCertificateRequest parses a single DER PKCS #10 request. The
+// signature is not checked; call [CertificateRequest.CheckSignature].
+func ParseCertificateRequest(der []byte) (*CertificateRequest, error) {
+	var req certificateRequest
+	rest, err := asn1.Unmarshal(der, &req)
+	if err != nil || len(rest) != 0 {
+		return nil, ErrMalformedCertificate
+	}
+	tbs := &req.TBSCSR
+
+	if err := checkSignatureAlgorithm(req.SignatureAlgorithm); err != nil {
+		return nil, err
+	}
+	if tbs.Version != 0 {
+		return nil, ErrMalformedCertificate
+	}
+	pub, err := parsePublicKey(tbs.PublicKey.FullBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := &CertificateRequest{
+		Raw:                      req.Raw,
+		RawTBSCertificateRequest: tbs.Raw,
+		RawSubjectPublicKeyInfo:  tbs.PublicKey.FullBytes,
+		RawSubject:               tbs.Subject.FullBytes,
+		Signature:                req.SignatureValue.RightAlign(),
+		Version:                  tbs.Version,
+		PublicKey:                pub,
+	}
+	if err := parseName(&cr.Subject, cr.RawSubject); err != nil {
+		return nil, err
+	}
+
+	for _, raw := range tbs.RawAttributes {
+		var attr pkcs10Attribute
+		if err := unmarshalExtension(raw.FullBytes, &attr); err != nil {
+			return nil, err
+		}
+		if !attr.Type.Equal(oidExtensionRequest) {
+			continue
+		}
+		if len(attr.Values) != 1 || cr.Extensions != nil {
+			return nil, ErrMalformedCertificate
+		}
+		if err := unmarshalExtension(attr.Values[0].FullBytes, &cr.Extensions); err != nil {
+			return nil, err
+		}
+	}
+	for _, ext := range cr.Extensions {
+		if ext.Id.Equal(oidExtensionSubjectAltName) {
+			cr.DNSNames, cr.EmailAddresses, cr.IPAddresses, cr.URIs, err = parseSANs(ext.Value)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return cr, nil
+}
+
+// CheckSignature verifies that the request is signed by the key it
+// carries.
+func (cr *CertificateRequest) CheckSignature() error {
+	return checkSignature(cr.PublicKey, cr.RawTBSCertificateRequest, cr.Signature)
+}
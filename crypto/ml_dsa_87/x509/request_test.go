@@ -0,0 +1,117 @@
+package x509
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestCertificateRequestRoundTrip(t *testing.T) {
+	signer := newTestSigner(t, 5)
+	tmpl := &CertificateRequest{
+		Subject:        pkix.Name{CommonName: "node-5", Organization: []string{"QRL"}},
+		DNSNames:       []string{"node-5.internal"},
+		EmailAddresses: []string{"ops@example.org"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.5")},
+	}
+	der, err := CreateCertificateRequest(nil, tmpl, signer)
+	if err != nil {
+		t.Fatalf("CreateCertificateRequest: %v", err)
+	}
+	cr, err := ParseCertificateRequest(der)
+	if err != nil {
+		t.Fatalf("ParseCertificateRequest: %v", err)
+	}
+	if err := cr.CheckSignature(); err != nil {
+		t.Fatalf("CheckSignature: %v", err)
+	}
+	if cr.Subject.CommonName != "node-5" || len(cr.Subject.Organization) != 1 {
+		t.Errorf("Subject = %v", cr.Subject)
+	}
+	if !cr.PublicKey.Equal(publicKey(signer)) {
+		t.Error("public key changed across round trip")
+	}
+	if len(cr.DNSNames) != 1 || cr.DNSNames[0] != "node-5.internal" ||
+		len(cr.EmailAddresses) != 1 || len(cr.IPAddresses) != 1 {
+		t.Errorf("SANs = %v %v %v", cr.DNSNames, cr.EmailAddresses, cr.IPAddresses)
+	}
+
+	// A request without SANs has no attributes at all.
+	der, err = CreateCertificateRequest(nil, &CertificateRequest{Subject: pkix.Name{CommonName: "bare"}}, signer)
+	if err != nil {
+		t.Fatalf("CreateCertificateRequest: %v", err)
+	}
+	cr, err = ParseCertificateRequest(der)
+	if err != nil {
+		t.Fatalf("ParseCertificateRequest: %v", err)
+	}
+	if len(cr.Extensions) != 0 || cr.CheckSignature() != nil {
+		t.Errorf("bare request: extensions %v, signature %v", cr.Extensions, cr.CheckSignature())
+	}
+}
+
+func TestCertificateRequestToCertificate(t *testing.T) {
+	caSigner := newTestSigner(t, 1)
+	caTmpl := caTemplate(1, "Root CA")
+	ca := mustCreate(t, caTmpl, caTmpl, publicKey(caSigner), caSigner)
+
+	der, err := CreateCertificateRequest(nil, &CertificateRequest{
+		Subject:  pkix.Name{CommonName: "node-6"},
+		DNSNames: []string{"node-6.internal"},
+	}, newTestSigner(t, 6))
+	if err != nil {
+		t.Fatalf("CreateCertificateRequest: %v", err)
+	}
+	cr, err := ParseCertificateRequest(der)
+	if err != nil || cr.CheckSignature() != nil {
+		t.Fatalf("request: %v / %v", err, cr.CheckSignature())
+	}
+
+	tmpl := leafTemplate(7, "")
+	tmpl.RawSubject = cr.RawSubject
+	tmpl.DNSNames = cr.DNSNames
+	cert := mustCreate(t, tmpl, ca, cr.PublicKey, caSigner)
+
+	if !bytes.Equal(cert.RawSubject, cr.RawSubject) || cert.Subject.CommonName != "node-6" {
+		t.Errorf("Subject = %v", cert.Subject)
+	}
+	if _, err := cert.Verify(VerifyOptions{Roots: poolOf(ca), CurrentTime: testNow}); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+}
+
+func TestCertificateRequestErrors(t *testing.T) {
+	signer := newTestSigner(t, 5)
+	if _, err := CreateCertificateRequest(nil, nil, signer); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("nil template error = %v", err)
+	}
+	if _, err := CreateCertificateRequest(nil, &CertificateRequest{DNSNames: []string{"é"}}, signer); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("non-ASCII DNS name error = %v", err)
+	}
+
+	der, err := CreateCertificateRequest(nil, &CertificateRequest{Subject: pkix.Name{CommonName: "x"}}, signer)
+	if err != nil {
+		t.Fatalf("CreateCertificateRequest: %v", err)
+	}
+	if _, err := ParseCertificateRequest(der[:len(der)-1]); !errors.Is(err, ErrMalformedCertificate) {
+		t.Errorf("truncated error = %v", err)
+	}
+
+	cr, err := ParseCertificateRequest(der)
+	if err != nil {
+		t.Fatalf("ParseCertificateRequest: %v", err)
+	}
+	cr.PublicKey = publicKey(newTestSigner(t, 6))
+	if err := cr.CheckSignature(); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("wrong key CheckSignature = %v", err)
+	}
+
+	// A certificate is not a request.
+	caTmpl := caTemplate(1, "Root CA")
+	cert := mustCreate(t, caTmpl, caTmpl, publicKey(signer), signer)
+	if _, err := ParseCertificateRequest(cert.Raw); err == nil {
+		t.Error("certificate parsed as a request")
+	}
+}
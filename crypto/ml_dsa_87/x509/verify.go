@@ -0,0 +1,178 @@
+package x509
+
+import (
+	"bytes"
+	"time"
+)
+
+// maxChainLength bounds chain building so a pool of cross-signed
+// intermediates cannot make Verify run away.
+const maxChainLength = 10
+
+// CertPool is a set of certificates, used for trust anchors and for
+// intermediates in [VerifyOptions]. The zero value is an empty pool.
+type CertPool struct {
+	certs []*Certificate
+}
+
+// NewCertPool returns an empty pool.
+func NewCertPool() *CertPool {
+	return &CertPool{}
+}
+
+// AddCert adds cert to the pool. Adding a certificate that is already
+// present is a no-op.
+func (p *CertPool) AddCert(cert *Certificate) {
+	if cert == nil || p.contains(cert) {
+		return
+	}
+	p.certs = append(p.certs, cert)
+}
+
+// Len returns the number of certificates in the pool.
+func (p *CertPool) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.certs)
+}
+
+func (p *CertPool) contains(cert *Certificate) bool {
+	if p == nil {
+		return false
+	}
+	for _, c := range p.certs {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+	return false
+}
+
+// issuersOf returns the pool's candidate issuers for child: those
+// whose subject matches child's issuer and, where both identifiers are
+// present, whose SubjectKeyId matches child's AuthorityKeyId.
+func (p *CertPool) issuersOf(child *Certificate) []*Certificate {
+	if p == nil {
+		return nil
+	}
+	var out []*Certificate
+	for _, c := range p.certs {
+		if !bytes.Equal(c.RawSubject, child.RawIssuer) {
+			continue
+		}
+		if len(c.SubjectKeyId) > 0 && len(child.AuthorityKeyId) > 0 &&
+			!bytes.Equal(c.SubjectKeyId, child.AuthorityKeyId) {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// VerifyOptions configures [Certificate.Verify].
+type VerifyOptions struct {
+	// Roots are the trust anchors. Verify fails without any.
+	Roots *CertPool
+	// Intermediates are untrusted certificates that may be used to
+	// link the leaf to a root.
+	Intermediates *CertPool
+	// CurrentTime is the time at which every certificate in the chain
+	// must be valid. The zero value means time.Now().
+	CurrentTime time.Time
+}
+
+// Verify builds a chain from c through opts.Intermediates to one of
+// opts.Roots and returns it leaf first. Every certificate in the chain,
+// roots included, must be within its validity period at
+// opts.CurrentTime and carry no unhandled critical extensions; every
+// issuer must be a CA allowed to sign certificates whose path length
+// constraint admits the chain below it.
+//
+// If no chain exists, the error describes why the last candidate was
+// rejected, or is [ErrUnknownAuthority] when there was none. A
+// certificate that is itself in opts.Roots verifies as a chain of one.
+func (c *Certificate) Verify(opts VerifyOptions) ([]*Certificate, error) {
+	if opts.Roots.Len() == 0 {
+		return nil, ErrUnknownAuthority
+	}
+	now := opts.CurrentTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	if err := c.checkUsable(now); err != nil {
+		return nil, err
+	}
+	if opts.Roots.contains(c) {
+		return []*Certificate{c}, nil
+	}
+	return buildChain([]*Certificate{c}, &opts, now)
+}
+
+// checkUsable applies the per-certificate checks that do not depend on
+// the certificate's position in the chain.
+func (c *Certificate) checkUsable(now time.Time) error {
+	if now.Before(c.NotBefore) || now.After(c.NotAfter) {
+		return ErrExpired
+	}
+	if len(c.UnhandledCriticalExtensions) > 0 {
+		return ErrUnhandledCriticalExtension
+	}
+	return nil
+}
+
+// checkIssuer checks that issuer may sign the last certificate of
+// chain, and that it did.
+func checkIssuer(chain []*Certificate, issuer *Certificate, now time.Time) error {
+	if err := issuer.checkUsable(now); err != nil {
+		return err
+	}
+	// Every certificate in chain except the leaf is an intermediate CA
+	// below issuer.
+	if issuer.BasicConstraintsValid && issuer.MaxPathLen >= 0 && len(chain)-1 > issuer.MaxPathLen {
+		return ErrPathLength
+	}
+	return chain[len(chain)-1].CheckSignatureFrom(issuer)
+}
+
+func buildChain(chain []*Certificate, opts *VerifyOptions, now time.Time) ([]*Certificate, error) {
+	child := chain[len(chain)-1]
+	err := ErrUnknownAuthority
+
+	for _, root := range opts.Roots.issuersOf(child) {
+		if e := checkIssuer(chain, root, now); e != nil {
+			err = e
+			continue
+		}
+		return append(chain[:len(chain):len(chain)], root), nil
+	}
+
+	if len(chain) >= maxChainLength {
+		return nil, err
+	}
+	for _, intermediate := range opts.Intermediates.issuersOf(child) {
+		if inChain(chain, intermediate) {
+			continue
+		}
+		if e := checkIssuer(chain, intermediate, now); e != nil {
+			err = e
+			continue
+		}
+		full, e := buildChain(append(chain[:len(chain):len(chain)], intermediate), opts, now)
+		if e == nil {
+			return full, nil
+		}
+		err = e
+	}
+	return nil, err
+}
+
+func inChain(chain []*Certificate, c *Certificate) bool {
+	for _, x := range chain {
+		if x.Equal(c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package x509
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+)
+
+// testPKI is a root → intermediate → leaf hierarchy.
+type testPKI struct {
+	rootSigner, intSigner *ml_dsa_87.CryptoSigner
+	root, intermediate    *Certificate
+	leaf                  *Certificate
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+	p := &testPKI{rootSigner: newTestSigner(t, 1), intSigner: newTestSigner(t, 2)}
+	rootTmpl := caTemplate(1, "Root CA")
+	p.root = mustCreate(t, rootTmpl, rootTmpl, publicKey(p.rootSigner), p.rootSigner)
+	p.intermediate = mustCreate(t, caTemplate(2, "Issuing CA"), p.root, publicKey(p.intSigner), p.rootSigner)
+	p.leaf = mustCreate(t, leafTemplate(3, "leaf"), p.intermediate, publicKey(newTestSigner(t, 3)), p.intSigner)
+	return p
+}
+
+func poolOf(certs ...*Certificate) *CertPool {
+	pool := NewCertPool()
+	for _, c := range certs {
+		pool.AddCert(c)
+	}
+	return pool
+}
+
+func TestVerifyChain(t *testing.T) {
+	p := newTestPKI(t)
+
+	chain, err := p.leaf.Verify(VerifyOptions{
+		Roots:         poolOf(p.root),
+		Intermediates: poolOf(p.intermediate),
+		CurrentTime:   testNow,
+	})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if len(chain) != 3 || !chain[0].Equal(p.leaf) || !chain[1].Equal(p.intermediate) || !chain[2].Equal(p.root) {
+		t.Fatalf("unexpected chain of length %d", len(chain))
+	}
+
+	// A root verifies as a chain of one.
+	chain, err = p.root.Verify(VerifyOptions{Roots: poolOf(p.root), CurrentTime: testNow})
+	if err != nil || len(chain) != 1 {
+		t.Fatalf("root Verify = %d, %v", len(chain), err)
+	}
+
+	// The intermediate can be a trust anchor too.
+	chain, err = p.leaf.Verify(VerifyOptions{Roots: poolOf(p.intermediate), CurrentTime: testNow})
+	if err != nil || len(chain) != 2 {
+		t.Fatalf("Verify against intermediate anchor = %d, %v", len(chain), err)
+	}
+}
+
+func TestVerifyFailures(t *testing.T) {
+	p := newTestPKI(t)
+	otherSigner := newTestSigner(t, 9)
+	otherRootTmpl := caTemplate(1, "Root CA") // same name, different key
+	otherRoot := mustCreate(t, otherRootTmpl, otherRootTmpl, publicKey(otherSigner), otherSigner)
+
+	tests := []struct {
+		name string
+		opts VerifyOptions
+		want error
+	}{
+		{"no_roots", VerifyOptions{CurrentTime: testNow}, ErrUnknownAuthority},
+		{"missing_intermediate", VerifyOptions{Roots: poolOf(p.root), CurrentTime: testNow}, ErrUnknownAuthority},
+		{"wrong_root_key", VerifyOptions{
+			Roots:         poolOf(otherRoot),
+			Intermediates: poolOf(p.intermediate),
+			CurrentTime:   testNow,
+		}, ErrUnknownAuthority},
+		{"leaf_not_yet_valid", VerifyOptions{
+			Roots:         poolOf(p.root),
+			Intermediates: poolOf(p.intermediate),
+			CurrentTime:   testNow.Add(-2 * time.Hour),
+		}, ErrExpired},
+		{"leaf_expired", VerifyOptions{
+			Roots:         poolOf(p.root),
+			Intermediates: poolOf(p.intermediate),
+			CurrentTime:   testNow.Add(31 * 24 * time.Hour),
+		}, ErrExpired},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := p.leaf.Verify(tc.opts); !errors.Is(err, tc.want) {
+				t.Errorf("error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsExpiredIssuer(t *testing.T) {
+	signer := newTestSigner(t, 1)
+	rootTmpl := caTemplate(1, "Root CA")
+	rootTmpl.NotAfter = testNow.Add(-time.Minute)
+	root := mustCreate(t, rootTmpl, rootTmpl, publicKey(signer), signer)
+	leafTmpl := leafTemplate(2, "leaf")
+	leafTmpl.NotBefore = testNow.Add(-2 * time.Hour)
+	leaf := mustCreate(t, leafTmpl, root, publicKey(newTestSigner(t, 2)), signer)
+
+	if _, err := leaf.Verify(VerifyOptions{Roots: poolOf(root), CurrentTime: testNow}); !errors.Is(err, ErrExpired) {
+		t.Errorf("error = %v, want %v", err, ErrExpired)
+	}
+}
+
+func TestVerifyRejectsNonCAIssuer(t *testing.T) {
+	rootSigner := newTestSigner(t, 1)
+	rootTmpl := caTemplate(1, "Root CA")
+	root := mustCreate(t, rootTmpl, rootTmpl, publicKey(rootSigner), rootSigner)
+
+	// An end-entity certificate used to sign another certificate.
+	eeSigner := newTestSigner(t, 2)
+	ee := mustCreate(t, leafTemplate(2, "ee"), root, publicKey(eeSigner), rootSigner)
+	forged := mustCreate(t, leafTemplate(3, "forged"), ee, publicKey(newTestSigner(t, 3)), eeSigner)
+
+	_, err := forged.Verify(VerifyOptions{Roots: poolOf(root), Intermediates: poolOf(ee), CurrentTime: testNow})
+	if !errors.Is(err, ErrNotCA) {
+		t.Errorf("error = %v, want %v", err, ErrNotCA)
+	}
+
+	// A CA whose keyUsage omits keyCertSign cannot issue either.
+	caSigner := newTestSigner(t, 4)
+	caTmpl := caTemplate(4, "CRL only")
+	caTmpl.KeyUsage = KEY_USAGE_CRL_SIGN
+	ca := mustCreate(t, caTmpl, root, publicKey(caSigner), rootSigner)
+	leaf := mustCreate(t, leafTemplate(5, "leaf"), ca, publicKey(newTestSigner(t, 5)), caSigner)
+
+	_, err = leaf.Verify(VerifyOptions{Roots: poolOf(root), Intermediates: poolOf(ca), CurrentTime: testNow})
+	if !errors.Is(err, ErrNotCA) {
+		t.Errorf("error = %v, want %v", err, ErrNotCA)
+	}
+}
+
+func TestVerifyPathLength(t *testing.T) {
+	rootSigner := newTestSigner(t, 1)
+	rootTmpl := caTemplate(1, "Root CA")
+	rootTmpl.MaxPathLen = 0
+	rootTmpl.MaxPathLenZero = true
+	root := mustCreate(t, rootTmpl, rootTmpl, publicKey(rootSigner), rootSigner)
+
+	intSigner := newTestSigner(t, 2)
+	intermediate := mustCreate(t, caTemplate(2, "Issuing CA"), root, publicKey(intSigner), rootSigner)
+	leaf := mustCreate(t, leafTemplate(3, "leaf"), intermediate, publicKey(newTestSigner(t, 3)), intSigner)
+	direct := mustCreate(t, leafTemplate(4, "direct"), root, publicKey(newTestSigner(t, 4)), rootSigner)
+
+	opts := VerifyOptions{Roots: poolOf(root), Intermediates: poolOf(intermediate), CurrentTime: testNow}
+	if _, err := leaf.Verify(opts); !errors.Is(err, ErrPathLength) {
+		t.Errorf("error = %v, want %v", err, ErrPathLength)
+	}
+	if _, err := direct.Verify(opts); err != nil {
+		t.Errorf("direct leaf under pathLen 0 root: %v", err)
+	}
+}
+
+func TestVerifyRejectsUnhandledCriticalExtension(t *testing.T) {
+	rootSigner := newTestSigner(t, 1)
+	rootTmpl := caTemplate(1, "Root CA")
+	root := mustCreate(t, rootTmpl, rootTmpl, publicKey(rootSigner), rootSigner)
+
+	tmpl := leafTemplate(2, "leaf")
+	tmpl.ExtraExtensions = []pkix.Extension{
+		{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}, Critical: true, Value: []byte{0x05, 0x00}},
+	}
+	leaf := mustCreate(t, tmpl, root, publicKey(newTestSigner(t, 2)), rootSigner)
+
+	if _, err := leaf.Verify(VerifyOptions{Roots: poolOf(root), CurrentTime: testNow}); !errors.Is(err, ErrUnhandledCriticalExtension) {
+		t.Errorf("error = %v, want %v", err, ErrUnhandledCriticalExtension)
+	}
+}
+
+func TestVerifyPicksMatchingIssuerKey(t *testing.T) {
+	// Two roots share a subject name; the AKI/SKI match and signature
+	// check together select the right one.
+	p := newTestPKI(t)
+	otherSigner := newTestSigner(t, 9)
+	otherRootTmpl := caTemplate(1, "Root CA")
+	otherRoot := mustCreate(t, otherRootTmpl, otherRootTmpl, publicKey(otherSigner), otherSigner)
+
+	chain, err := p.leaf.Verify(VerifyOptions{
+		Roots:         poolOf(otherRoot, p.root),
+		Intermediates: poolOf(p.intermediate),
+		CurrentTime:   testNow,
+	})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !chain[len(chain)-1].Equal(p.root) {
+		t.Error("chain ends at the wrong root")
+	}
+}
+
+func TestCertPool(t *testing.T) {
+	p := newTestPKI(t)
+	pool := NewCertPool()
+	pool.AddCert(p.root)
+	pool.AddCert(p.root)
+	pool.AddCert(nil)
+	if pool.Len() != 1 {
+		t.Errorf("Len = %d, want 1", pool.Len())
+	}
+	var nilPool *CertPool
+	if nilPool.Len() != 0 {
+		t.Error("nil pool has certificates")
+	}
+}
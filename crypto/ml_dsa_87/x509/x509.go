@@ -0,0 +1,409 @@
+package x509
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/url"
+	"time"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+)
+
+var (
+	// ErrMalformedCertificate is returned for DER that is not a
+	// well-formed certificate or certificate request.
+	ErrMalformedCertificate = errors.New("x509: malformed certificate")
+	// ErrUnsupportedAlgorithm is returned when a signature algorithm or
+	// public key is not ML-DSA-87.
+	ErrUnsupportedAlgorithm = errors.New("x509: algorithm is not ML-DSA-87")
+	// ErrInvalidTemplate is returned by [CreateCertificate] and
+	// [CreateCertificateRequest] for templates they cannot encode.
+	ErrInvalidTemplate = errors.New("x509: invalid template")
+	// ErrSignerMismatch is returned when the signer's public key is not
+	// the issuing certificate's public key.
+	ErrSignerMismatch = errors.New("x509: signer does not match parent public key")
+	// ErrInvalidSignature is returned when a signature does not verify.
+	ErrInvalidSignature = errors.New("x509: invalid signature")
+	// ErrExpired is returned when a certificate in the chain is outside
+	// its validity period.
+	ErrExpired = errors.New("x509: certificate has expired or is not yet valid")
+	// ErrUnknownAuthority is returned when no chain to a trust anchor
+	// exists.
+	ErrUnknownAuthority = errors.New("x509: certificate signed by unknown authority")
+	// ErrNotCA is returned when an issuing certificate is not allowed
+	// to sign certificates.
+	ErrNotCA = errors.New("x509: issuer is not a certificate authority")
+	// ErrPathLength is returned when a chain violates a basicConstraints
+	// path length.
+	ErrPathLength = errors.New("x509: too many intermediates for path length constraint")
+	// ErrUnhandledCriticalExtension is returned when a certificate in
+	// the chain carries a critical extension this package does not
+	// process.
+	ErrUnhandledCriticalExtension = errors.New("x509: unhandled critical extension")
+)
+
+var oidSignatureMLDSA87 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 19}
+
+// KeyUsage is the set of keyUsage bits (RFC 5280 §4.2.1.3) that apply
+// to an ML-DSA-87 key. The values match crypto/x509.KeyUsage.
+type KeyUsage int
+
+const (
+	KEY_USAGE_DIGITAL_SIGNATURE KeyUsage = 1 << 0
+	// KEY_USAGE_CONTENT_COMMITMENT is the bit RFC 5280 calls
+	// nonRepudiation.
+	KEY_USAGE_CONTENT_COMMITMENT KeyUsage = 1 << 1
+	KEY_USAGE_CERT_SIGN          KeyUsage = 1 << 5
+	KEY_USAGE_CRL_SIGN           KeyUsage = 1 << 6
+
+	validKeyUsages = KEY_USAGE_DIGITAL_SIGNATURE | KEY_USAGE_CONTENT_COMMITMENT |
+		KEY_USAGE_CERT_SIGN | KEY_USAGE_CRL_SIGN
+)
+
+// Certificate is a parsed X.509 certificate, or a template for
+// [CreateCertificate]. Fields prefixed Raw are set by
+// [ParseCertificate] only.
+type Certificate struct {
+	Raw                     []byte // complete DER certificate
+	RawTBSCertificate       []byte // DER TBSCertificate, the signed part
+	RawSubjectPublicKeyInfo []byte
+	RawSubject              []byte
+	RawIssuer               []byte
+
+	Signature []byte
+
+	// Version is 1, 2 or 3. Certificates created by this package are
+	// always version 3.
+	Version      int
+	SerialNumber *big.Int
+	Issuer       pkix.Name
+	Subject      pkix.Name
+	NotBefore    time.Time
+	NotAfter     time.Time
+	KeyUsage     KeyUsage
+
+	PublicKey *ml_dsa_87.CryptoPublicKey
+
+	// BasicConstraintsValid reports whether IsCA, MaxPathLen and
+	// MaxPathLenZero are meaningful.
+	BasicConstraintsValid bool
+	IsCA                  bool
+
+	// MaxPathLen is the basicConstraints pathLenConstraint. When
+	// parsing, -1 means the field was absent. In a template, -1 or 0
+	// with MaxPathLenZero unset leaves the field out; 0 with
+	// MaxPathLenZero set encodes an explicit zero.
+	MaxPathLen     int
+	MaxPathLenZero bool
+
+	SubjectKeyId   []byte
+	AuthorityKeyId []byte
+
+	// Subject alternative names.
+	DNSNames       []string
+	EmailAddresses []string
+	IPAddresses    []net.IP
+	URIs           []*url.URL
+
+	// Extensions holds every extension of a parsed certificate.
+	// ExtraExtensions is copied into a created certificate verbatim and
+	// replaces any extension the template would otherwise produce with
+	// the same OID.
+	Extensions      []pkix.Extension
+	ExtraExtensions []pkix.Extension
+
+	// UnhandledCriticalExtensions lists critical extensions that
+	// [ParseCertificate] did not process. [Certificate.Verify] rejects
+	// chains containing any.
+	UnhandledCriticalExtensions []asn1.ObjectIdentifier
+}
+
+type certificate struct {
+	Raw                asn1.RawContent
+	TBSCertificate     tbsCertificate
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+type tbsCertificate struct {
+	Raw                asn1.RawContent
+	Version            int `asn1:"optional,explicit,default:0,tag:0"`
+	SerialNumber       *big.Int
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Issuer             asn1.RawValue
+	Validity           validity
+	Subject            asn1.RawValue
+	PublicKey          asn1.RawValue
+	UniqueId           asn1.BitString   `asn1:"optional,tag:1"`
+	SubjectUniqueId    asn1.BitString   `asn1:"optional,tag:2"`
+	Extensions         []pkix.Extension `asn1:"omitempty,optional,explicit,tag:3"`
+}
+
+type validity struct {
+	NotBefore, NotAfter time.Time
+}
+
+func signatureAlgorithm() pkix.AlgorithmIdentifier {
+	return pkix.AlgorithmIdentifier{Algorithm: oidSignatureMLDSA87}
+}
+
+func checkSignatureAlgorithm(a pkix.AlgorithmIdentifier) error {
+	if !a.Algorithm.Equal(oidSignatureMLDSA87) {
+		return ErrUnsupportedAlgorithm
+	}
+	if len(a.Parameters.FullBytes) != 0 {
+		return ErrMalformedCertificate
+	}
+	return nil
+}
+
+// CreateCertificate creates a DER X.509 v3 certificate for pub from
+// template, issued by parent and signed by signer. For a self-signed
+// certificate pass the template as parent.
+//
+// The issuer name comes from parent; when parent has a
+// SubjectKeyId and is not the subject itself, it becomes the
+// certificate's AuthorityKeyId. A CA template without a SubjectKeyId
+// gets one derived from pub (RFC 7093 method 1: the leftmost 160 bits
+// of SHA-256 over the public key). If parent.PublicKey is set it must
+// be the signer's public key.
+//
+// rand is passed to [ml_dsa_87.CryptoSigner.SignMessage]; nil selects
+// crypto/rand.
+func CreateCertificate(rand io.Reader, template, parent *Certificate, pub *ml_dsa_87.CryptoPublicKey, signer *ml_dsa_87.CryptoSigner) ([]byte, error) {
+	if template == nil || parent == nil {
+		return nil, fmt.Errorf("%w: nil template or parent", ErrInvalidTemplate)
+	}
+	if pub == nil || signer == nil {
+		return nil, fmt.Errorf("%w: nil public key or signer", ErrInvalidTemplate)
+	}
+	if template.SerialNumber == nil || template.SerialNumber.Sign() < 0 {
+		return nil, fmt.Errorf("%w: serial number must be non-negative", ErrInvalidTemplate)
+	}
+	// RFC 5280 §4.1.2.2 caps serial numbers at 20 octets; a positive
+	// INTEGER gains a leading zero octet when its top bit is set.
+	if len(template.SerialNumber.Bytes()) > 20 ||
+		(len(template.SerialNumber.Bytes()) == 20 && template.SerialNumber.Bit(159) == 1) {
+		return nil, fmt.Errorf("%w: serial number longer than 20 octets", ErrInvalidTemplate)
+	}
+	if template.NotAfter.Before(template.NotBefore) {
+		return nil, fmt.Errorf("%w: NotAfter before NotBefore", ErrInvalidTemplate)
+	}
+	if template.KeyUsage&^validKeyUsages != 0 {
+		return nil, fmt.Errorf("%w: key usage not valid for ML-DSA-87", ErrInvalidTemplate)
+	}
+	if template.BasicConstraintsValid && !template.IsCA && template.MaxPathLen != -1 &&
+		(template.MaxPathLen != 0 || template.MaxPathLenZero) {
+		return nil, fmt.Errorf("%w: only CAs can set MaxPathLen", ErrInvalidTemplate)
+	}
+
+	signerPub, ok := signer.Public().(*ml_dsa_87.CryptoPublicKey)
+	if !ok {
+		//coverage:ignore
+		//rationale: ml_dsa_87.CryptoSigner.Public always returns *CryptoPublicKey
+		return nil, ErrSignerMismatch
+	}
+	if parent.PublicKey != nil && !parent.PublicKey.Equal(signerPub) {
+		return nil, ErrSignerMismatch
+	}
+
+	spki, err := ml_dsa_87.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		//coverage:ignore
+		//rationale: pub is non-nil and of a type MarshalPKIXPublicKey accepts
+		return nil, err
+	}
+	subject, err := marshalName(template.RawSubject, template.Subject)
+	if err != nil {
+		return nil, err
+	}
+	issuer, err := marshalName(parent.RawSubject, parent.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	subjectKeyId := template.SubjectKeyId
+	if len(subjectKeyId) == 0 && template.IsCA {
+		subjectKeyId = keyIdentifier(pub)
+	}
+	authorityKeyId := template.AuthorityKeyId
+	if !bytes.Equal(issuer, subject) && len(parent.SubjectKeyId) > 0 {
+		authorityKeyId = parent.SubjectKeyId
+	}
+
+	extensions, err := buildExtensions(template, isEmptyName(subject), subjectKeyId, authorityKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	tbs := tbsCertificate{
+		Version:            2,
+		SerialNumber:       template.SerialNumber,
+		SignatureAlgorithm: signatureAlgorithm(),
+		Issuer:             asn1.RawValue{FullBytes: issuer},
+		Validity: validity{
+			NotBefore: template.NotBefore.UTC().Truncate(time.Second),
+			NotAfter:  template.NotAfter.UTC().Truncate(time.Second),
+		},
+		Subject:    asn1.RawValue{FullBytes: subject},
+		PublicKey:  asn1.RawValue{FullBytes: spki},
+		Extensions: extensions,
+	}
+	tbsDER, err := asn1.Marshal(tbs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	tbs.Raw = tbsDER
+
+	signature, err := signer.SignMessage(rand, tbsDER, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(certificate{
+		TBSCertificate:     tbs,
+		SignatureAlgorithm: signatureAlgorithm(),
+		SignatureValue:     asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)},
+	})
+}
+
+// ParseCertificate parses a single DER certificate. Certificates that
+// are not ML-DSA-87 on both the signature and the subject key are
+// rejected with [ErrUnsupportedAlgorithm].
+func ParseCertificate(der []byte) (*Certificate, error) {
+	var cert certificate
+	rest, err := asn1.Unmarshal(der, &cert)
+	if err != nil || len(rest) != 0 {
+		return nil, ErrMalformedCertificate
+	}
+	tbs := &cert.TBSCertificate
+
+	if err := checkSignatureAlgorithm(cert.SignatureAlgorithm); err != nil {
+		return nil, err
+	}
+	if err := checkSignatureAlgorithm(tbs.SignatureAlgorithm); err != nil {
+		return nil, err
+	}
+	if tbs.Version < 0 || tbs.Version > 2 {
+		return nil, ErrMalformedCertificate
+	}
+	if len(tbs.Extensions) > 0 && tbs.Version != 2 {
+		return nil, ErrMalformedCertificate
+	}
+	if tbs.SerialNumber == nil {
+		//coverage:ignore
+		//rationale: asn1.Unmarshal always allocates a *big.Int for a present INTEGER
+		return nil, ErrMalformedCertificate
+	}
+
+	pub, err := parsePublicKey(tbs.PublicKey.FullBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Certificate{
+		Raw:                     cert.Raw,
+		RawTBSCertificate:       tbs.Raw,
+		RawSubjectPublicKeyInfo: tbs.PublicKey.FullBytes,
+		RawSubject:              tbs.Subject.FullBytes,
+		RawIssuer:               tbs.Issuer.FullBytes,
+		Signature:               cert.SignatureValue.RightAlign(),
+		Version:                 tbs.Version + 1,
+		SerialNumber:            tbs.SerialNumber,
+		NotBefore:               tbs.Validity.NotBefore,
+		NotAfter:                tbs.Validity.NotAfter,
+		PublicKey:               pub,
+		MaxPathLen:              -1,
+		Extensions:              tbs.Extensions,
+	}
+	if err := parseName(&c.Subject, c.RawSubject); err != nil {
+		return nil, err
+	}
+	if err := parseName(&c.Issuer, c.RawIssuer); err != nil {
+		return nil, err
+	}
+	if err := parseCertificateExtensions(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// CheckSignatureFrom verifies that parent signed c. parent must be a
+// CA (basicConstraints cA set) and, if it asserts key usages,
+// keyCertSign must be among them.
+func (c *Certificate) CheckSignatureFrom(parent *Certificate) error {
+	if !parent.BasicConstraintsValid || !parent.IsCA {
+		return ErrNotCA
+	}
+	if parent.KeyUsage != 0 && parent.KeyUsage&KEY_USAGE_CERT_SIGN == 0 {
+		return ErrNotCA
+	}
+	return checkSignature(parent.PublicKey, c.RawTBSCertificate, c.Signature)
+}
+
+// Equal reports whether c and other are the same DER certificate.
+func (c *Certificate) Equal(other *Certificate) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	return bytes.Equal(c.Raw, other.Raw)
+}
+
+// checkSignature verifies a pure ML-DSA-87 signature with an empty
+// context, as RFC 9881 specifies for certificates and requests.
+func checkSignature(pub *ml_dsa_87.CryptoPublicKey, signed, signature []byte) error {
+	if pub == nil || len(signature) != ml_dsa_87.CRYPTO_BYTES {
+		return ErrInvalidSignature
+	}
+	var sig [ml_dsa_87.CRYPTO_BYTES]uint8
+	copy(sig[:], signature)
+	pk := pub.Bytes()
+	if !ml_dsa_87.Verify(nil, signed, sig, &pk) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func parsePublicKey(spki []byte) (*ml_dsa_87.CryptoPublicKey, error) {
+	pub, err := ml_dsa_87.ParsePKIXPublicKey(spki)
+	switch {
+	case errors.Is(err, cryptoerrors.ErrUnsupportedAlgorithm):
+		return nil, ErrUnsupportedAlgorithm
+	case err != nil:
+		return nil, ErrMalformedCertificate
+	}
+	return pub, nil
+}
+
+func marshalName(raw []byte, name pkix.Name) ([]byte, error) {
+	if len(raw) > 0 {
+		return raw, nil
+	}
+	der, err := asn1.Marshal(name.ToRDNSequence())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	return der, nil
+}
+
+func parseName(name *pkix.Name, raw []byte) error {
+	var rdn pkix.RDNSequence
+	rest, err := asn1.Unmarshal(raw, &rdn)
+	if err != nil || len(rest) != 0 {
+		return ErrMalformedCertificate
+	}
+	name.FillFromRDNSequence(&rdn)
+	return nil
+}
+
+// isEmptyName reports whether der is an empty RDNSequence.
+func isEmptyName(der []byte) bool {
+	return bytes.Equal(der, []byte{0x30, 0x00})
+}
@@ -0,0 +1,350 @@
+package x509
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+)
+
+var testNow = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestSigner(t *testing.T, b byte) *ml_dsa_87.CryptoSigner {
+	t.Helper()
+	var seed [ml_dsa_87.SEED_BYTES]uint8
+	for i := range seed {
+		seed[i] = b
+	}
+	d, err := ml_dsa_87.NewMLDSA87FromSeed(seed)
+	if err != nil {
+		t.Fatalf("NewMLDSA87FromSeed: %v", err)
+	}
+	return ml_dsa_87.NewCryptoSigner(d)
+}
+
+func publicKey(s *ml_dsa_87.CryptoSigner) *ml_dsa_87.CryptoPublicKey {
+	return s.Public().(*ml_dsa_87.CryptoPublicKey)
+}
+
+func caTemplate(serial int64, cn string) *Certificate {
+	return &Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             testNow.Add(-24 * time.Hour),
+		NotAfter:              testNow.Add(365 * 24 * time.Hour),
+		KeyUsage:              KEY_USAGE_CERT_SIGN | KEY_USAGE_CRL_SIGN,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+}
+
+func leafTemplate(serial int64, cn string) *Certificate {
+	return &Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    testNow.Add(-time.Hour),
+		NotAfter:     testNow.Add(30 * 24 * time.Hour),
+		KeyUsage:     KEY_USAGE_DIGITAL_SIGNATURE,
+	}
+}
+
+func mustCreate(t *testing.T, template, parent *Certificate, pub *ml_dsa_87.CryptoPublicKey, signer *ml_dsa_87.CryptoSigner) *Certificate {
+	t.Helper()
+	der, err := CreateCertificate(nil, template, parent, pub, signer)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	cert, err := ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate: %v", err)
+	}
+	return cert
+}
+
+func TestCreateCertificateRoundTrip(t *testing.T) {
+	rootSigner := newTestSigner(t, 1)
+	root := mustCreate(t, caTemplate(1, "Root CA"), caTemplate(1, "Root CA"), publicKey(rootSigner), rootSigner)
+
+	leafSigner := newTestSigner(t, 2)
+	tmpl := leafTemplate(0x1234, "node-1")
+	tmpl.DNSNames = []string{"node-1.internal", "*.node-1.internal"}
+	tmpl.EmailAddresses = []string{"ops@example.org"}
+	tmpl.IPAddresses = []net.IP{net.ParseIP("10.1.2.3"), net.ParseIP("2001:db8::1")}
+	tmpl.URIs = []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/node-1"}}
+	leaf := mustCreate(t, tmpl, root, publicKey(leafSigner), rootSigner)
+
+	if leaf.Version != 3 {
+		t.Errorf("Version = %d, want 3", leaf.Version)
+	}
+	if leaf.SerialNumber.Cmp(big.NewInt(0x1234)) != 0 {
+		t.Errorf("SerialNumber = %v", leaf.SerialNumber)
+	}
+	if leaf.Subject.CommonName != "node-1" || leaf.Issuer.CommonName != "Root CA" {
+		t.Errorf("names = %q / %q", leaf.Subject.CommonName, leaf.Issuer.CommonName)
+	}
+	if !leaf.NotBefore.Equal(tmpl.NotBefore) || !leaf.NotAfter.Equal(tmpl.NotAfter) {
+		t.Errorf("validity = %v..%v", leaf.NotBefore, leaf.NotAfter)
+	}
+	if !leaf.PublicKey.Equal(publicKey(leafSigner)) {
+		t.Error("public key changed across round trip")
+	}
+	if leaf.KeyUsage != KEY_USAGE_DIGITAL_SIGNATURE {
+		t.Errorf("KeyUsage = %d", leaf.KeyUsage)
+	}
+	if leaf.BasicConstraintsValid || leaf.IsCA {
+		t.Error("leaf without basicConstraints parsed as having them")
+	}
+	if len(leaf.SubjectKeyId) != 0 {
+		t.Errorf("leaf SubjectKeyId = %x, want none", leaf.SubjectKeyId)
+	}
+	if !bytes.Equal(leaf.AuthorityKeyId, root.SubjectKeyId) {
+		t.Errorf("AuthorityKeyId = %x, want %x", leaf.AuthorityKeyId, root.SubjectKeyId)
+	}
+	if len(leaf.DNSNames) != 2 || leaf.DNSNames[1] != "*.node-1.internal" {
+		t.Errorf("DNSNames = %v", leaf.DNSNames)
+	}
+	if len(leaf.EmailAddresses) != 1 || leaf.EmailAddresses[0] != "ops@example.org" {
+		t.Errorf("EmailAddresses = %v", leaf.EmailAddresses)
+	}
+	if len(leaf.IPAddresses) != 2 || !leaf.IPAddresses[0].Equal(tmpl.IPAddresses[0]) ||
+		!leaf.IPAddresses[1].Equal(tmpl.IPAddresses[1]) {
+		t.Errorf("IPAddresses = %v", leaf.IPAddresses)
+	}
+	if len(leaf.URIs) != 1 || leaf.URIs[0].String() != "spiffe://example.org/node-1" {
+		t.Errorf("URIs = %v", leaf.URIs)
+	}
+	if err := leaf.CheckSignatureFrom(root); err != nil {
+		t.Errorf("CheckSignatureFrom: %v", err)
+	}
+
+	// The root is self-signed: no AKI, and a SKI derived from its key.
+	if !root.BasicConstraintsValid || !root.IsCA || root.MaxPathLen != -1 {
+		t.Errorf("root basicConstraints = %v %v %d", root.BasicConstraintsValid, root.IsCA, root.MaxPathLen)
+	}
+	if !bytes.Equal(root.SubjectKeyId, keyIdentifier(publicKey(rootSigner))) {
+		t.Errorf("root SubjectKeyId = %x", root.SubjectKeyId)
+	}
+	if len(root.AuthorityKeyId) != 0 {
+		t.Errorf("self-signed AuthorityKeyId = %x, want none", root.AuthorityKeyId)
+	}
+	if err := root.CheckSignatureFrom(root); err != nil {
+		t.Errorf("root CheckSignatureFrom(root): %v", err)
+	}
+}
+
+func TestCreateCertificateEncoding(t *testing.T) {
+	signer := newTestSigner(t, 1)
+	tmpl := caTemplate(1, "Root CA")
+	tmpl.MaxPathLen = 0
+	tmpl.MaxPathLenZero = true
+	cert := mustCreate(t, tmpl, tmpl, publicKey(signer), signer)
+
+	// Both AlgorithmIdentifiers are id-ml-dsa-87 with absent parameters.
+	algID := []byte{0x30, 0x0b, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x03, 0x13}
+	if n := bytes.Count(cert.Raw, algID); n != 3 {
+		t.Errorf("AlgorithmIdentifier appears %d times, want 3 (TBS, SPKI, outer)", n)
+	}
+	if len(cert.Signature) != ml_dsa_87.CRYPTO_BYTES {
+		t.Errorf("signature length %d", len(cert.Signature))
+	}
+
+	want := map[string]struct {
+		critical bool
+		value    []byte
+	}{
+		"2.5.29.19": {true, []byte{0x30, 0x06, 0x01, 0x01, 0xff, 0x02, 0x01, 0x00}},
+		"2.5.29.15": {true, []byte{0x03, 0x02, 0x01, 0x06}},
+	}
+	for _, ext := range cert.Extensions {
+		w, ok := want[ext.Id.String()]
+		if !ok {
+			continue
+		}
+		if ext.Critical != w.critical || !bytes.Equal(ext.Value, w.value) {
+			t.Errorf("%v = critical %v %x, want %v %x", ext.Id, ext.Critical, ext.Value, w.critical, w.value)
+		}
+	}
+	if !cert.MaxPathLenZero || cert.MaxPathLen != 0 {
+		t.Errorf("MaxPathLen = %d zero=%v", cert.MaxPathLen, cert.MaxPathLenZero)
+	}
+}
+
+func TestKeyUsageEncoding(t *testing.T) {
+	tests := []struct {
+		ku   KeyUsage
+		want []byte
+	}{
+		{KEY_USAGE_DIGITAL_SIGNATURE, []byte{0x03, 0x02, 0x07, 0x80}},
+		{KEY_USAGE_DIGITAL_SIGNATURE | KEY_USAGE_CONTENT_COMMITMENT, []byte{0x03, 0x02, 0x06, 0xc0}},
+		{KEY_USAGE_CERT_SIGN, []byte{0x03, 0x02, 0x02, 0x04}},
+		{KEY_USAGE_CERT_SIGN | KEY_USAGE_CRL_SIGN, []byte{0x03, 0x02, 0x01, 0x06}},
+	}
+	for _, tc := range tests {
+		der, err := asn1.Marshal(marshalKeyUsage(tc.ku))
+		if err != nil {
+			t.Fatalf("asn1.Marshal: %v", err)
+		}
+		if !bytes.Equal(der, tc.want) {
+			t.Errorf("KeyUsage %d = %x, want %x", tc.ku, der, tc.want)
+		}
+		var bs asn1.BitString
+		if _, err := asn1.Unmarshal(der, &bs); err != nil {
+			t.Fatalf("asn1.Unmarshal: %v", err)
+		}
+		if got := parseKeyUsage(bs); got != tc.ku {
+			t.Errorf("parseKeyUsage(%x) = %d, want %d", der, got, tc.ku)
+		}
+	}
+}
+
+func TestCreateCertificateEmptySubjectMakesSANCritical(t *testing.T) {
+	rootSigner := newTestSigner(t, 1)
+	root := mustCreate(t, caTemplate(1, "Root CA"), caTemplate(1, "Root CA"), publicKey(rootSigner), rootSigner)
+
+	tmpl := leafTemplate(2, "")
+	tmpl.DNSNames = []string{"svc.internal"}
+	leaf := mustCreate(t, tmpl, root, publicKey(newTestSigner(t, 2)), rootSigner)
+	for _, ext := range leaf.Extensions {
+		if ext.Id.Equal(oidExtensionSubjectAltName) && !ext.Critical {
+			t.Error("subjectAltName not critical for empty subject")
+		}
+	}
+	if len(leaf.UnhandledCriticalExtensions) != 0 {
+		t.Errorf("UnhandledCriticalExtensions = %v", leaf.UnhandledCriticalExtensions)
+	}
+}
+
+func TestCreateCertificateExtraExtensions(t *testing.T) {
+	signer := newTestSigner(t, 1)
+	custom := asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}
+	tmpl := caTemplate(1, "Root CA")
+	tmpl.SubjectKeyId = []byte{1, 2, 3}
+	tmpl.ExtraExtensions = []pkix.Extension{
+		{Id: custom, Critical: true, Value: []byte{0x05, 0x00}},
+		// Replaces the generated SKI.
+		{Id: oidExtensionSubjectKeyId, Value: []byte{0x04, 0x01, 0x09}},
+	}
+	cert := mustCreate(t, tmpl, tmpl, publicKey(signer), signer)
+
+	if !bytes.Equal(cert.SubjectKeyId, []byte{0x09}) {
+		t.Errorf("SubjectKeyId = %x, want 09", cert.SubjectKeyId)
+	}
+	if len(cert.UnhandledCriticalExtensions) != 1 || !cert.UnhandledCriticalExtensions[0].Equal(custom) {
+		t.Errorf("UnhandledCriticalExtensions = %v", cert.UnhandledCriticalExtensions)
+	}
+}
+
+func TestCreateCertificateErrors(t *testing.T) {
+	signer := newTestSigner(t, 1)
+	other := newTestSigner(t, 2)
+	pub := publicKey(signer)
+	root := mustCreate(t, caTemplate(1, "Root CA"), caTemplate(1, "Root CA"), pub, signer)
+
+	tooLong := new(big.Int).Lsh(big.NewInt(1), 159)
+	tests := []struct {
+		name   string
+		mutate func(*Certificate)
+		parent *Certificate
+		signer *ml_dsa_87.CryptoSigner
+		want   error
+	}{
+		{"nil_serial", func(c *Certificate) { c.SerialNumber = nil }, nil, signer, ErrInvalidTemplate},
+		{"negative_serial", func(c *Certificate) { c.SerialNumber = big.NewInt(-1) }, nil, signer, ErrInvalidTemplate},
+		{"serial_too_long", func(c *Certificate) { c.SerialNumber = tooLong }, nil, signer, ErrInvalidTemplate},
+		{"inverted_validity", func(c *Certificate) { c.NotAfter = c.NotBefore.Add(-time.Second) }, nil, signer, ErrInvalidTemplate},
+		{"key_encipherment", func(c *Certificate) { c.KeyUsage = 1 << 2 }, nil, signer, ErrInvalidTemplate},
+		{"leaf_max_path_len", func(c *Certificate) {
+			c.BasicConstraintsValid = true
+			c.MaxPathLen = 1
+		}, nil, signer, ErrInvalidTemplate},
+		{"non_ascii_dns", func(c *Certificate) { c.DNSNames = []string{"bücher.example"} }, nil, signer, ErrInvalidTemplate},
+		{"bad_ip", func(c *Certificate) { c.IPAddresses = []net.IP{{1, 2, 3}} }, nil, signer, ErrInvalidTemplate},
+		{"nil_uri", func(c *Certificate) { c.URIs = []*url.URL{nil} }, nil, signer, ErrInvalidTemplate},
+		{"signer_mismatch", func(c *Certificate) {}, root, other, ErrSignerMismatch},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := leafTemplate(2, "leaf")
+			tc.mutate(tmpl)
+			parent := tc.parent
+			if parent == nil {
+				parent = tmpl
+			}
+			if _, err := CreateCertificate(nil, tmpl, parent, pub, tc.signer); !errors.Is(err, tc.want) {
+				t.Errorf("error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	if _, err := CreateCertificate(nil, nil, root, pub, signer); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("nil template error = %v", err)
+	}
+	if _, err := CreateCertificate(nil, leafTemplate(2, "leaf"), root, nil, signer); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("nil public key error = %v", err)
+	}
+}
+
+func TestParseCertificateRejectsMalformed(t *testing.T) {
+	signer := newTestSigner(t, 1)
+	tmpl := caTemplate(1, "Root CA")
+	cert := mustCreate(t, tmpl, tmpl, publicKey(signer), signer)
+
+	wrongOuterAlg := bytes.Clone(cert.Raw)
+	// The outer AlgorithmIdentifier sits right after the TBSCertificate.
+	off := 4 + len(cert.RawTBSCertificate) + 12
+	wrongOuterAlg[off] = 0x12 // id-ml-dsa-65
+
+	wrongKeyAlg := bytes.Clone(cert.Raw)
+	spkiOff := bytes.Index(wrongKeyAlg, cert.RawSubjectPublicKeyInfo)
+	wrongKeyAlg[spkiOff+4+12] = 0x11 // id-ml-dsa-44
+
+	tests := []struct {
+		name string
+		der  []byte
+		want error
+	}{
+		{"empty", nil, ErrMalformedCertificate},
+		{"truncated", cert.Raw[:len(cert.Raw)-1], ErrMalformedCertificate},
+		{"trailing_data", append(bytes.Clone(cert.Raw), 0), ErrMalformedCertificate},
+		{"wrong_signature_algorithm", wrongOuterAlg, ErrUnsupportedAlgorithm},
+		{"wrong_key_algorithm", wrongKeyAlg, ErrUnsupportedAlgorithm},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ParseCertificate(tc.der); !errors.Is(err, tc.want) {
+				t.Errorf("error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckSignatureFromRejectsTampering(t *testing.T) {
+	rootSigner := newTestSigner(t, 1)
+	root := mustCreate(t, caTemplate(1, "Root CA"), caTemplate(1, "Root CA"), publicKey(rootSigner), rootSigner)
+	leaf := mustCreate(t, leafTemplate(2, "leaf"), root, publicKey(newTestSigner(t, 2)), rootSigner)
+
+	tampered := *leaf
+	tampered.RawTBSCertificate = bytes.Clone(leaf.RawTBSCertificate)
+	tampered.RawTBSCertificate[len(tampered.RawTBSCertificate)-1] ^= 1
+	if err := tampered.CheckSignatureFrom(root); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("tampered TBS error = %v", err)
+	}
+
+	tampered = *leaf
+	tampered.Signature = leaf.Signature[:100]
+	if err := tampered.CheckSignatureFrom(root); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("short signature error = %v", err)
+	}
+
+	// A leaf is not a CA, so it cannot have signed anything.
+	if err := root.CheckSignatureFrom(leaf); !errors.Is(err, ErrNotCA) {
+		t.Errorf("CheckSignatureFrom(leaf) error = %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+//go:build acvp
+
+package slhdsa
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// NIST ACVP test vector verification for SLH-DSA.
+//
+// These tests validate key generation and signature generation, both
+// deterministic and hedged, pure and pre-hash, against the official
+// NIST ACVP SLH-DSA-keyGen-FIPS205 and SLH-DSA-sigGen-FIPS205 vectors.
+// The vectors are for the parameter set named by ACVP_PARAMETER_SET
+// (default SLH-DSA-SHAKE-256s). Guarded by the "acvp" build tag so they
+// only run in CI or when explicitly requested.
+//
+// See .github/acvp/README.md for setup, local usage, and vector format details.
+
+func acvpVectorsDir(t *testing.T) string {
+	t.Helper()
+	dir := os.Getenv("ACVP_VECTORS_DIR")
+	if dir == "" {
+		t.Skip("ACVP_VECTORS_DIR not set; skipping ACVP tests. See acvp_test.go for instructions.")
+	}
+	return dir
+}
+
+// acvpParameterSet returns the parameter set named by
+// ACVP_PARAMETER_SET, which must match the --parameter-set the vectors
+// were merged for.
+func acvpParameterSet(t *testing.T) *ParameterSet {
+	t.Helper()
+	name := os.Getenv("ACVP_PARAMETER_SET")
+	if name == "" {
+		return SHAKE_256s
+	}
+	p := ParameterSetByName(name)
+	if p == nil {
+		t.Fatalf("Unknown ACVP_PARAMETER_SET %q", name)
+	}
+	return p
+}
+
+type acvpKeyGenVector struct {
+	TcID   int    `json:"tcId"`
+	SKSeed string `json:"skSeed"`
+	SKPrf  string `json:"skPrf"`
+	PKSeed string `json:"pkSeed"`
+	PK     string `json:"pk"`
+	SK     string `json:"sk"`
+}
+
+type acvpSigGenVector struct {
+	TcID                 int    `json:"tcId"`
+	SK                   string `json:"sk"`
+	Message              string `json:"message"`
+	Context              string `json:"context"`
+	HashAlg              string `json:"hashAlg,omitempty"`
+	AdditionalRandomness string `json:"additionalRandomness,omitempty"`
+	Signature            string `json:"signature"`
+}
+
+// acvpHashAlgs maps ACVP hashAlg names to PreHashFunction values.
+var acvpHashAlgs = map[string]PreHashFunction{
+	"SHA2-224":     SHA2_224,
+	"SHA2-256":     SHA2_256,
+	"SHA2-384":     SHA2_384,
+	"SHA2-512":     SHA2_512,
+	"SHA2-512/224": SHA2_512_224,
+	"SHA2-512/256": SHA2_512_256,
+	"SHA3-224":     SHA3_224,
+	"SHA3-256":     SHA3_256,
+	"SHA3-384":     SHA3_384,
+	"SHA3-512":     SHA3_512,
+	"SHAKE-128":    SHAKE_128,
+	"SHAKE-256":    SHAKE_256,
+}
+
+func decodeHex(t *testing.T, name, s string) []uint8 {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("Invalid %s hex: %v", name, err)
+	}
+	return b
+}
+
+func readVectors(t *testing.T, name string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(acvpVectorsDir(t), name))
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("Failed to parse %s: %v", name, err)
+	}
+}
+
+// TestACVPKeyGen verifies that slh_keygen_internal on (SK.seed, SK.prf,
+// PK.seed) produces byte-exact matches against NIST ACVP expected keys.
+func TestACVPKeyGen(t *testing.T) {
+	p := acvpParameterSet(t)
+	var vectors []acvpKeyGenVector
+	readVectors(t, "keygen.json", &vectors)
+	if len(vectors) == 0 {
+		t.Fatal("No keygen test vectors found")
+	}
+	t.Logf("Running %d ACVP keygen test vectors for %v", len(vectors), p)
+
+	for _, vec := range vectors {
+		t.Run(fmt.Sprintf("tc%d", vec.TcID), func(t *testing.T) {
+			var seed []uint8
+			seed = append(seed, decodeHex(t, "skSeed", vec.SKSeed)...)
+			seed = append(seed, decodeHex(t, "skPrf", vec.SKPrf)...)
+			seed = append(seed, decodeHex(t, "pkSeed", vec.PKSeed)...)
+
+			s, err := NewSLHDSAFromSeed(p, seed)
+			if err != nil {
+				t.Fatalf("NewSLHDSAFromSeed: %v", err)
+			}
+			if want := decodeHex(t, "pk", vec.PK); !bytes.Equal(s.GetPK(), want) {
+				t.Errorf("Public key mismatch\n  got:  %x\n  want: %x", s.GetPK(), want)
+			}
+			if want := decodeHex(t, "sk", vec.SK); !bytes.Equal(s.GetSK(), want) {
+				t.Errorf("Secret key mismatch")
+			}
+		})
+	}
+}
+
+// TestACVPSigGen verifies pure SLH-DSA signatures against the
+// external-interface ACVP vectors. Deterministic vectors sign with
+// opt_rand = PK.seed; hedged vectors supply opt_rand as
+// additionalRandomness.
+func TestACVPSigGen(t *testing.T) {
+	runACVPSigGen(t, "siggen.json", func(t *testing.T, vec acvpSigGenVector, ctx, msg []uint8) ([]uint8, []uint8) {
+		pre, err := purePrefix(ctx)
+		if err != nil {
+			t.Fatalf("purePrefix: %v", err)
+		}
+		return pre, msg
+	})
+}
+
+// TestACVPSigGenPrehash is [TestACVPSigGen] for HashSLH-DSA. The ACVP
+// prompt carries the full message; the test computes PH(M) itself, as
+// a [SLHDSA.SignPrehash] caller would.
+func TestACVPSigGenPrehash(t *testing.T) {
+	runACVPSigGen(t, "siggen_prehash.json", func(t *testing.T, vec acvpSigGenVector, ctx, msg []uint8) ([]uint8, []uint8) {
+		ph, ok := acvpHashAlgs[vec.HashAlg]
+		if !ok {
+			t.Fatalf("Unsupported hashAlg %q", vec.HashAlg)
+		}
+		digest := preHashDigest(t, ph, msg)
+		pre, err := preHashPrefix(ctx, ph, digest)
+		if err != nil {
+			t.Fatalf("preHashPrefix: %v", err)
+		}
+		return pre, digest
+	})
+}
+
+func runACVPSigGen(t *testing.T, file string, prepare func(*testing.T, acvpSigGenVector, []uint8, []uint8) ([]uint8, []uint8)) {
+	p := acvpParameterSet(t)
+	var vectors []acvpSigGenVector
+	readVectors(t, file, &vectors)
+	if len(vectors) == 0 {
+		t.Fatalf("No test vectors found in %s", file)
+	}
+	t.Logf("Running %d ACVP vectors from %s for %v", len(vectors), file, p)
+
+	for _, vec := range vectors {
+		t.Run(fmt.Sprintf("tc%d", vec.TcID), func(t *testing.T) {
+			s, err := NewSLHDSAFromSecretKey(p, decodeHex(t, "sk", vec.SK))
+			if err != nil {
+				t.Fatalf("NewSLHDSAFromSecretKey: %v", err)
+			}
+			pre, m := prepare(t, vec, decodeHex(t, "context", vec.Context), decodeHex(t, "message", vec.Message))
+
+			var addrnd []uint8 // nil: deterministic, opt_rand = PK.seed
+			if vec.AdditionalRandomness != "" {
+				addrnd = decodeHex(t, "additionalRandomness", vec.AdditionalRandomness)
+			}
+			sig, err := s.signWithRandomness(pre, m, addrnd)
+			if err != nil {
+				t.Fatalf("signWithRandomness: %v", err)
+			}
+			want := decodeHex(t, "signature", vec.Signature)
+			if !bytes.Equal(sig, want) {
+				t.Errorf("Signature mismatch\n  got:  %x...\n  want: %x...", sig[:32], want[:min(32, len(want))])
+			}
+			if err := verify(p, pre, m, sig, s.GetPK()); err != nil {
+				t.Errorf("Generated signature failed verification: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Package slhdsa implements SLH-DSA, the stateless hash-based digital
+// signature algorithm standardised in FIPS 205.
+//
+// All twelve FIPS 205 parameter sets are provided, from [SHA2_128s] to
+// [SHAKE_256f]; every function takes the [ParameterSet] to use. The
+// SHA2 sets are built on crypto/sha256 and crypto/sha512. The WOTS+,
+// FORS and hypertree code is shared with crypto/sphincsplus_256s,
+// which remains the round-3 SPHINCS+ SHAKE-256s-robust submission used
+// by QRL wallets today. The two are not interchangeable: FIPS 205 uses
+// the simple tweakable hash and prefixes every message with a domain
+// separator, so keys and signatures from one never verify under the
+// other, even for [SHAKE_256s].
+//
+// # Parameter Sets
+//
+// The "s" sets have smaller signatures, the "f" sets sign faster:
+//
+//	Set          Public key  Secret key  Signature
+//	*_128s               32          64      7,856
+//	*_128f               32          64     17,088
+//	*_192s               48          96     16,224
+//	*_192f               48          96     35,664
+//	*_256s               64         128     29,792
+//	*_256f               64         128     49,856
+//
+// The key-generation seed (SK.seed || SK.prf || PK.seed) is 3n bytes:
+// 48, 72 or 96. [ParameterSets] lists every set and
+// [ParameterSetByName] looks one up by its FIPS 205 name.
+//
+// # Pure and Pre-hash Modes
+//
+// [SLHDSA.Sign] signs `0x00 || len(ctx) || ctx || M`, where ctx is an
+// application context string of at most 255 bytes. [SLHDSA.SignPrehash]
+// implements HashSLH-DSA, signing `0x01 || len(ctx) || ctx || OID || PH(M)`
+// for a digest the caller computed with one of the [PreHashFunction]
+// values. Signatures from one mode never verify under the other.
+//
+// # Hedged and Deterministic Signing
+//
+// Sign and SignPrehash are hedged: opt_rand is drawn from crypto/rand.
+// [SLHDSA.SignDeterministic] and [SLHDSA.SignPrehashDeterministic] use
+// opt_rand = PK.seed as FIPS 205 §9.2 allows, giving reproducible
+// signatures. Verification is the same for both.
+//
+// # Thread Safety
+//
+// An SLHDSA instance may sign from several goroutines at once, but
+// Zeroize must not run concurrently with any other method. The
+// package-level Verify functions are safe for concurrent use.
+//
+// # Example Usage
+//
+//	s, err := slhdsa.New(slhdsa.SHAKE_256s)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer s.Zeroize()
+//
+//	ctx := []byte("my-application")
+//	signature, err := s.Sign(ctx, message)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	valid := slhdsa.Verify(slhdsa.SHAKE_256s, ctx, message, signature, s.GetPK())
+package slhdsa
@@ -0,0 +1,55 @@
+package slhdsa_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/theQRL/go-qrllib/crypto/slhdsa"
+)
+
+// Example demonstrates pure SLH-DSA signing and verification.
+func Example() {
+	s, err := slhdsa.New(slhdsa.SHAKE_256s)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer s.Zeroize()
+
+	ctx := []byte("my-application")
+	message := []byte("Hello, FIPS 205!")
+	signature, err := s.Sign(ctx, message)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	valid := slhdsa.Verify(slhdsa.SHAKE_256s, ctx, message, signature, s.GetPK())
+	fmt.Println("Signature size:", len(signature))
+	fmt.Println("Signature valid:", valid)
+	// Output:
+	// Signature size: 29792
+	// Signature valid: true
+}
+
+// ExampleSLHDSA_SignPrehash demonstrates HashSLH-DSA over a SHA-256
+// digest.
+func ExampleSLHDSA_SignPrehash() {
+	s, err := slhdsa.New(slhdsa.SHAKE_256s)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer s.Zeroize()
+
+	digest := sha256.Sum256([]byte("a large document"))
+	signature, err := s.SignPrehash(nil, slhdsa.SHA2_256, digest[:])
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	valid := slhdsa.VerifyPrehash(slhdsa.SHAKE_256s, nil, slhdsa.SHA2_256, digest[:], signature, s.GetPK())
+	fmt.Println("Signature valid:", valid)
+	// Output: Signature valid: true
+}
@@ -0,0 +1,156 @@
+package slhdsa
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/theQRL/go-qrllib/crypto/internal/prehash"
+)
+
+// Known answers for every parameter set with seed 00 01 .. (3n-1).
+// They were produced by an independent implementation written from
+// FIPS 205 and pin the tweakable hashes, the address layout (including
+// the compressed SHA2 form) and the message framing. Signatures are
+// deterministic pure signatures of katMessage under katContext,
+// compared by their SHA-256.
+const (
+	katMessage = "SLH-DSA known-answer test"
+	katContext = "go-qrllib"
+)
+
+var katVectors = []struct {
+	p      *ParameterSet
+	pk     string
+	sigSum string
+}{
+	{SHA2_128s, "202122232425262728292a2b2c2d2e2f990ce6298792b128846a8e4a3a68954c",
+		"c73d722ebc32bb0618252d4411722bbfa805d690551733de5dfdb07664b22552"},
+	{SHAKE_128s, "202122232425262728292a2b2c2d2e2f89fd81fdbb5b94129b14761bdc6bf682",
+		"b639ad862fe6ebfa5fab68ed58492d5313a6990630efa4ec615e7b7d8906f1bc"},
+	{SHA2_128f, "202122232425262728292a2b2c2d2e2f3b56e816847f000386aeec2e2bb9e1b5",
+		"d0384c40453976ece1c6f7d5115d39ee750c2e49c3b3d90c887fac42a56d81da"},
+	{SHAKE_128f, "202122232425262728292a2b2c2d2e2fa90e4715b9a925c332801767fd786371",
+		"c348409ba2ffa5037d5b0377ac28363add97aa7aa9024c63ea1efc1348a61276"},
+	{SHA2_192s, "303132333435363738393a3b3c3d3e3f4041424344454647b6f282ce116ff59bce2d9fc4a67c6031dabdce326c34f541",
+		"71222e6ae70d9accb18fb58a2508055597d47e87596a1f80d06ca45555b23b26"},
+	{SHAKE_192s, "303132333435363738393a3b3c3d3e3f4041424344454647eb247f955d8eca24a5860536c56b2c4d1e8d8e835eb27d2d",
+		"5c8f2c194d249a659a19880c543dc06b7cc2968b8e31ec061248f901d67c3331"},
+	{SHA2_192f, "303132333435363738393a3b3c3d3e3f40414243444546479236ccebbb3a90ac2452dd89de49dab1340ec02419a2870e",
+		"8a2f2e4ce5a3f5f7a063e173bb7ce055bd066272f99d67e914fa8e8fe1395bd9"},
+	{SHAKE_192f, "303132333435363738393a3b3c3d3e3f40414243444546473f01b06bebed020a459696868d115fe8507ded8dc08e825d",
+		"3f5943b1f3f87398af25bc26f0bd01910b6171f8638db6fe603bf47dcbaccfd4"},
+	{SHA2_256s, "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5fda7163e601352515bc0f06f9f4f44be71a5a65ee9dca5575cf4a7b6d4a87d6e2",
+		"10341f9422f84ecc68815b83a304ca4ebc223fa27ed2384bb71589930b9133d8"},
+	{SHAKE_256s, "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f27ea444dbc8ca9c169fd484b9e977eb77a4f233550757e025cf180ede7e8839f",
+		"3899698c1fdbeb0c7bd6d61b0bef8430f9d74de69f498a1e563a5831723deaad"},
+	{SHA2_256f, "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f42cffe64ddbd6731063752684df77c8b58c225dc6b491208916b654ea1393176",
+		"8a0e750463817c47029c976c618b5f76e8285750e4e22690615a5108c750f918"},
+	{SHAKE_256f, "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f818d7e76beef979b5bbf9161fdefa21bd0fe0bfe19157a5711a8de8a8f6878e6",
+		"d13f057e1797091bea8f45c1bfe0a76f4303ee635a7005ce1ffabb9f89082437"},
+}
+
+func katKeyFor(t *testing.T, p *ParameterSet) *SLHDSA {
+	t.Helper()
+	seed := make([]uint8, p.SeedSize())
+	for i := range seed {
+		seed[i] = uint8(i)
+	}
+	s, err := NewSLHDSAFromSeed(p, seed)
+	if err != nil {
+		t.Fatalf("NewSLHDSAFromSeed: %v", err)
+	}
+	return s
+}
+
+func katKey(t *testing.T) *SLHDSA {
+	t.Helper()
+	return katKeyFor(t, SHAKE_256s)
+}
+
+func TestKATCoversAllParameterSets(t *testing.T) {
+	if len(katVectors) != len(ParameterSets()) {
+		t.Fatalf("%d KAT vectors for %d parameter sets", len(katVectors), len(ParameterSets()))
+	}
+	for i, p := range ParameterSets() {
+		if katVectors[i].p != p {
+			t.Errorf("katVectors[%d] is %v, want %v", i, katVectors[i].p, p)
+		}
+	}
+}
+
+func TestKATKeyGen(t *testing.T) {
+	for _, tc := range katVectors {
+		t.Run(tc.p.String(), func(t *testing.T) {
+			s := katKeyFor(t, tc.p)
+			if got := hex.EncodeToString(s.GetPK()); got != tc.pk {
+				t.Errorf("pk = %s, want %s", got, tc.pk)
+			}
+			sk := s.GetSK()
+			if !bytes.Equal(sk[:tc.p.SeedSize()], s.GetSeed()) || !bytes.Equal(sk[tc.p.SeedSize()-tc.p.PublicKeySize()/2:], s.GetPK()) {
+				t.Error("sk is not SK.seed || SK.prf || PK.seed || PK.root")
+			}
+		})
+	}
+}
+
+func TestKATSign(t *testing.T) {
+	for _, tc := range katVectors {
+		t.Run(tc.p.String(), func(t *testing.T) {
+			s := katKeyFor(t, tc.p)
+			sig, err := s.SignDeterministic([]byte(katContext), []byte(katMessage))
+			if err != nil {
+				t.Fatalf("SignDeterministic: %v", err)
+			}
+			sum := sha256.Sum256(sig)
+			if got := hex.EncodeToString(sum[:]); got != tc.sigSum {
+				t.Errorf("SHA-256(sig) = %s, want %s", got, tc.sigSum)
+			}
+			if err := VerifyDetailed(tc.p, []byte(katContext), []byte(katMessage), sig, s.GetPK()); err != nil {
+				t.Errorf("VerifyDetailed: %v", err)
+			}
+		})
+	}
+}
+
+// TestKATSignModes covers hedged and pre-hash signing for
+// SLH-DSA-SHAKE-256s.
+func TestKATSignModes(t *testing.T) {
+	s := katKey(t)
+	msg := []byte(katMessage)
+	ctx := []byte(katContext)
+	addrnd := bytes.Repeat([]byte{0xaa}, 32)
+
+	pureEmpty, _ := purePrefix(nil)
+	digest256 := sha256.Sum256(msg)
+	pre256, _ := prehash.Prefix(ctx, SHA2_256, digest256[:])
+	digestShake := preHashDigest(t, SHAKE_256, msg)
+	preShake, _ := prehash.Prefix(ctx, SHAKE_256, digestShake)
+
+	tests := []struct {
+		name   string
+		pre, m []uint8
+		addrnd []uint8
+		want   string
+	}{
+		{"pure_hedged_empty_ctx", pureEmpty, msg, addrnd, "b4b6dcc9cbe35f79e3a3bb6a1e8d4d717bf39493b7621f2d4e719d87e14f7f40"},
+		{"prehash_sha2_256", pre256, digest256[:], nil, "95dff17e4b8cbaaac2fd388de179d97604d2e4da7afd0808dab60cd6671ee6c6"},
+		{"prehash_shake_256", preShake, digestShake, nil, "960d284f53d2e6c3d4146b3e793d3eb74dc5e0bdf344f75d5bf847580f56642b"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sig, err := s.signWithRandomness(tc.pre, tc.m, tc.addrnd)
+			if err != nil {
+				t.Fatalf("signWithRandomness: %v", err)
+			}
+			sum := sha256.Sum256(sig)
+			if got := hex.EncodeToString(sum[:]); got != tc.want {
+				t.Errorf("SHA-256(sig) = %s, want %s", got, tc.want)
+			}
+			if err := verify(SHAKE_256s, tc.pre, tc.m, sig, s.GetPK()); err != nil {
+				t.Errorf("verify: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package slhdsa
+
+import "github.com/theQRL/go-qrllib/crypto/internal/sphincs"
+
+// ParameterSet identifies one of the FIPS 205 parameter sets (§11).
+// Keys and signatures are only meaningful together with the set they
+// were made under; the sets are distinguished by pointer identity.
+type ParameterSet struct {
+	name string
+	core *sphincs.Params
+}
+
+// The twelve FIPS 205 parameter sets (Table 2). The "s" sets have
+// smaller signatures and the "f" sets faster signing; the number is
+// the NIST security category's bit strength. SHA2 sets use SHA-256 and
+// SHA-512 as FIPS 205 §11.2 specifies, SHAKE sets SHAKE256.
+var (
+	// SHA2_128s is SLH-DSA-SHA2-128s: n = 16, h = 63, d = 7, a = 12, k = 14.
+	SHA2_128s = &ParameterSet{"SLH-DSA-SHA2-128s", sphincs.SHA2_128s}
+	// SHAKE_128s is SLH-DSA-SHAKE-128s: n = 16, h = 63, d = 7, a = 12, k = 14.
+	SHAKE_128s = &ParameterSet{"SLH-DSA-SHAKE-128s", sphincs.SHAKE_128s}
+	// SHA2_128f is SLH-DSA-SHA2-128f: n = 16, h = 66, d = 22, a = 6, k = 33.
+	SHA2_128f = &ParameterSet{"SLH-DSA-SHA2-128f", sphincs.SHA2_128f}
+	// SHAKE_128f is SLH-DSA-SHAKE-128f: n = 16, h = 66, d = 22, a = 6, k = 33.
+	SHAKE_128f = &ParameterSet{"SLH-DSA-SHAKE-128f", sphincs.SHAKE_128f}
+	// SHA2_192s is SLH-DSA-SHA2-192s: n = 24, h = 63, d = 7, a = 14, k = 17.
+	SHA2_192s = &ParameterSet{"SLH-DSA-SHA2-192s", sphincs.SHA2_192s}
+	// SHAKE_192s is SLH-DSA-SHAKE-192s: n = 24, h = 63, d = 7, a = 14, k = 17.
+	SHAKE_192s = &ParameterSet{"SLH-DSA-SHAKE-192s", sphincs.SHAKE_192s}
+	// SHA2_192f is SLH-DSA-SHA2-192f: n = 24, h = 66, d = 22, a = 8, k = 33.
+	SHA2_192f = &ParameterSet{"SLH-DSA-SHA2-192f", sphincs.SHA2_192f}
+	// SHAKE_192f is SLH-DSA-SHAKE-192f: n = 24, h = 66, d = 22, a = 8, k = 33.
+	SHAKE_192f = &ParameterSet{"SLH-DSA-SHAKE-192f", sphincs.SHAKE_192f}
+	// SHA2_256s is SLH-DSA-SHA2-256s: n = 32, h = 64, d = 8, a = 14, k = 22.
+	SHA2_256s = &ParameterSet{"SLH-DSA-SHA2-256s", sphincs.SHA2_256s}
+	// SHAKE_256s is SLH-DSA-SHAKE-256s: n = 32, h = 64, d = 8, a = 14, k = 22.
+	SHAKE_256s = &ParameterSet{"SLH-DSA-SHAKE-256s", sphincs.SHAKE_256s}
+	// SHA2_256f is SLH-DSA-SHA2-256f: n = 32, h = 68, d = 17, a = 9, k = 35.
+	SHA2_256f = &ParameterSet{"SLH-DSA-SHA2-256f", sphincs.SHA2_256f}
+	// SHAKE_256f is SLH-DSA-SHAKE-256f: n = 32, h = 68, d = 17, a = 9, k = 35.
+	SHAKE_256f = &ParameterSet{"SLH-DSA-SHAKE-256f", sphincs.SHAKE_256f}
+)
+
+// ParameterSets returns every supported parameter set in FIPS 205
+// Table 2 order.
+func ParameterSets() []*ParameterSet {
+	return []*ParameterSet{
+		SHA2_128s, SHAKE_128s, SHA2_128f, SHAKE_128f,
+		SHA2_192s, SHAKE_192s, SHA2_192f, SHAKE_192f,
+		SHA2_256s, SHAKE_256s, SHA2_256f, SHAKE_256f,
+	}
+}
+
+// ParameterSetByName returns the parameter set whose String is name,
+// or nil if there is none.
+func ParameterSetByName(name string) *ParameterSet {
+	for _, p := range ParameterSets() {
+		if p.name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// String returns the FIPS 205 name of p, e.g. "SLH-DSA-SHAKE-256s".
+func (p *ParameterSet) String() string {
+	return p.name
+}
+
+// PublicKeySize returns the length of an encoded public key,
+// PK.seed || PK.root.
+func (p *ParameterSet) PublicKeySize() int {
+	return p.core.PKBytes
+}
+
+// SecretKeySize returns the length of an encoded secret key,
+// SK.seed || SK.prf || PK.seed || PK.root.
+func (p *ParameterSet) SecretKeySize() int {
+	return p.core.SKBytes
+}
+
+// SignatureSize returns the length of a signature.
+func (p *ParameterSet) SignatureSize() int {
+	return p.core.Bytes
+}
+
+// SeedSize returns the length of a key-generation seed,
+// SK.seed || SK.prf || PK.seed.
+func (p *ParameterSet) SeedSize() int {
+	return p.core.SeedBytes
+}
+
+func (p *ParameterSet) isValid() bool {
+	for _, q := range ParameterSets() {
+		if p == q {
+			return true
+		}
+	}
+	return false
+}
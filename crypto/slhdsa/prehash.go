@@ -0,0 +1,48 @@
+package slhdsa
+
+import "github.com/theQRL/go-qrllib/crypto/internal/prehash"
+
+// PreHashFunction selects the hash or XOF used to pre-hash the message
+// for HashSLH-DSA (FIPS 205 §10.2.2). The caller computes the digest; the
+// library only needs the function's identity to embed its DER-encoded
+// OID in the signed message representative and to check the digest
+// length.
+//
+// The zero value is deliberately invalid so an unset field cannot
+// silently select a hash function. The table is shared with the other
+// pre-hash scheme in this module, so the same constants select the same
+// function in both.
+type PreHashFunction = prehash.Function
+
+const (
+	// SHA2_224 — SHA-224 (FIPS 180-4), 28-byte digest.
+	SHA2_224 = prehash.SHA2_224
+	// SHA2_256 — SHA-256 (FIPS 180-4), 32-byte digest.
+	SHA2_256 = prehash.SHA2_256
+	// SHA2_384 — SHA-384 (FIPS 180-4), 48-byte digest.
+	SHA2_384 = prehash.SHA2_384
+	// SHA2_512 — SHA-512 (FIPS 180-4), 64-byte digest.
+	SHA2_512 = prehash.SHA2_512
+	// SHA2_512_224 — SHA-512/224 (FIPS 180-4), 28-byte digest.
+	SHA2_512_224 = prehash.SHA2_512_224
+	// SHA2_512_256 — SHA-512/256 (FIPS 180-4), 32-byte digest.
+	SHA2_512_256 = prehash.SHA2_512_256
+	// SHA3_224 — SHA3-224 (FIPS 202), 28-byte digest.
+	SHA3_224 = prehash.SHA3_224
+	// SHA3_256 — SHA3-256 (FIPS 202), 32-byte digest.
+	SHA3_256 = prehash.SHA3_256
+	// SHA3_384 — SHA3-384 (FIPS 202), 48-byte digest.
+	SHA3_384 = prehash.SHA3_384
+	// SHA3_512 — SHA3-512 (FIPS 202), 64-byte digest.
+	SHA3_512 = prehash.SHA3_512
+	// SHAKE_128 — SHAKE128 (FIPS 202) with a 256-bit output, as fixed
+	// by FIPS 205 §10.2.2.
+	SHAKE_128 = prehash.SHAKE_128
+	// SHAKE_256 — SHAKE256 (FIPS 202) with a 512-bit output, as fixed
+	// by FIPS 205 §10.2.2.
+	SHAKE_256 = prehash.SHAKE_256
+)
+
+// PRE_HASH_OID_BYTES is the length of the DER-encoded OID that
+// HashSLH-DSA places between the context and the digest.
+const PRE_HASH_OID_BYTES = prehash.OID_BYTES
@@ -0,0 +1,142 @@
+package slhdsa
+
+import (
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"errors"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+// preHashDigest computes the HashSLH-DSA digest of msg under ph using
+// the standard library, mirroring what a caller would do before
+// SignPrehash.
+func preHashDigest(t *testing.T, ph PreHashFunction, msg []byte) []byte {
+	t.Helper()
+	switch ph {
+	case SHA2_224:
+		s := sha256.Sum224(msg)
+		return s[:]
+	case SHA2_256:
+		s := sha256.Sum256(msg)
+		return s[:]
+	case SHA2_384:
+		s := sha512.Sum384(msg)
+		return s[:]
+	case SHA2_512:
+		s := sha512.Sum512(msg)
+		return s[:]
+	case SHA2_512_224:
+		s := sha512.Sum512_224(msg)
+		return s[:]
+	case SHA2_512_256:
+		s := sha512.Sum512_256(msg)
+		return s[:]
+	case SHA3_224:
+		s := sha3.Sum224(msg)
+		return s[:]
+	case SHA3_256:
+		s := sha3.Sum256(msg)
+		return s[:]
+	case SHA3_384:
+		s := sha3.Sum384(msg)
+		return s[:]
+	case SHA3_512:
+		s := sha3.Sum512(msg)
+		return s[:]
+	case SHAKE_128:
+		return sha3.SumSHAKE128(msg, 32)
+	case SHAKE_256:
+		return sha3.SumSHAKE256(msg, 64)
+	}
+	t.Fatalf("unsupported pre-hash function %v", ph)
+	return nil
+}
+
+var allPreHashFunctions = []PreHashFunction{
+	SHA2_224, SHA2_256, SHA2_384, SHA2_512, SHA2_512_224, SHA2_512_256,
+	SHA3_224, SHA3_256, SHA3_384, SHA3_512, SHAKE_128, SHAKE_256,
+}
+
+func TestPreHashFunctionDigestSize(t *testing.T) {
+	for _, ph := range allPreHashFunctions {
+		if !ph.IsValid() {
+			t.Errorf("%v should be valid", ph)
+		}
+		if got, want := ph.DigestSize(), len(preHashDigest(t, ph, nil)); got != want {
+			t.Errorf("%v.DigestSize() = %d, want %d", ph, got, want)
+		}
+	}
+	for _, ph := range []PreHashFunction{0, SHAKE_256 + 1} {
+		if ph.IsValid() {
+			t.Errorf("%v should be invalid", ph)
+		}
+		if ph.DigestSize() != 0 {
+			t.Errorf("%v.DigestSize() = %d, want 0", ph, ph.DigestSize())
+		}
+	}
+}
+
+func TestSignPrehashVerifyPrehash(t *testing.T) {
+	s, err := New(SHAKE_256s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Zeroize()
+	pk := s.GetPK()
+	ctx := []byte("document-signing")
+	digest := preHashDigest(t, SHA2_256, []byte("a multi-gigabyte file, hashed elsewhere"))
+
+	sig, err := s.SignPrehash(ctx, SHA2_256, digest)
+	if err != nil {
+		t.Fatalf("SignPrehash: %v", err)
+	}
+	if !VerifyPrehash(SHAKE_256s, ctx, SHA2_256, digest, sig, pk) {
+		t.Fatal("VerifyPrehash rejected a valid signature")
+	}
+	if VerifyPrehash(SHAKE_256s, []byte("other"), SHA2_256, digest, sig, pk) {
+		t.Error("VerifyPrehash accepted a signature under the wrong context")
+	}
+	// The OID binds the signature to its hash function, even when two
+	// functions share a digest size.
+	for _, ph := range []PreHashFunction{SHA2_512_256, SHA3_256, SHAKE_128} {
+		if VerifyPrehash(SHAKE_256s, ctx, ph, digest, sig, pk) {
+			t.Errorf("signature made with SHA2_256 verified as %v", ph)
+		}
+	}
+	// The pure and pre-hash modes are domain-separated.
+	if Verify(SHAKE_256s, ctx, digest, sig, pk) {
+		t.Error("pure Verify accepted a HashSLH-DSA signature")
+	}
+}
+
+func TestSignPrehashErrors(t *testing.T) {
+	s := &SLHDSA{params: SHAKE_256s, sk: make([]uint8, SHAKE_256s.SecretKeySize())}
+	digest := preHashDigest(t, SHA2_256, []byte("msg"))
+
+	tests := []struct {
+		name   string
+		ctx    []byte
+		ph     PreHashFunction
+		digest []byte
+		want   error
+	}{
+		{"context too long", make([]byte, 256), SHA2_256, digest, cryptoerrors.ErrInvalidContext},
+		{"zero hash function", nil, 0, digest, cryptoerrors.ErrInvalidHashFunction},
+		{"unknown hash function", nil, SHAKE_256 + 1, digest, cryptoerrors.ErrInvalidHashFunction},
+		{"short digest", nil, SHA2_256, digest[:31], cryptoerrors.ErrInvalidLength},
+		{"digest for another function", nil, SHA2_512, digest, cryptoerrors.ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.SignPrehash(tt.ctx, tt.ph, tt.digest); !errors.Is(err, tt.want) {
+				t.Errorf("SignPrehash error = %v, want %v", err, tt.want)
+			}
+			if _, err := s.SignPrehashDeterministic(tt.ctx, tt.ph, tt.digest); !errors.Is(err, tt.want) {
+				t.Errorf("SignPrehashDeterministic error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package slhdsa
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"runtime"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/crypto/internal/prehash"
+	"github.com/theQRL/go-qrllib/crypto/internal/sphincs"
+)
+
+// SLHDSA holds an SLH-DSA key pair for one parameter set.
+type SLHDSA struct {
+	params *ParameterSet
+	pk     []uint8
+
+	// sk is SK.seed || SK.prf || PK.seed || PK.root. Zeroize wipes it
+	// and sets it to nil.
+	sk []uint8
+}
+
+// New generates a key pair for p from crypto/rand (FIPS 205 Algorithm
+// 21, slh_keygen).
+func New(p *ParameterSet) (*SLHDSA, error) {
+	if !p.isValid() {
+		return nil, cryptoerrors.ErrUnsupportedAlgorithm
+	}
+	seed := make([]uint8, p.SeedSize())
+	defer zeroBytes(seed)
+	if _, err := rand.Read(seed); err != nil {
+		//coverage:ignore
+		//rationale: crypto/rand.Read only fails if system entropy source is broken
+		return nil, cryptoerrors.ErrSeedGeneration
+	}
+	return NewSLHDSAFromSeed(p, seed)
+}
+
+// NewSLHDSAFromSeed derives the key pair for p from seed, which is
+// SK.seed || SK.prf || PK.seed as passed to slh_keygen_internal (FIPS
+// 205 Algorithm 18). The ACVP keyGen vectors supply these three values.
+func NewSLHDSAFromSeed(p *ParameterSet, seed []uint8) (*SLHDSA, error) {
+	if !p.isValid() {
+		return nil, cryptoerrors.ErrUnsupportedAlgorithm
+	}
+	if len(seed) != p.SeedSize() {
+		return nil, cryptoerrors.ErrInvalidSeed
+	}
+	s := &SLHDSA{params: p, pk: make([]uint8, p.PublicKeySize()), sk: make([]uint8, p.SecretKeySize())}
+	sphincs.SeedKeypair(p.core, s.pk, s.sk, seed, true, 0)
+	return s, nil
+}
+
+// NewSLHDSAFromSecretKey wraps an encoded secret key for p. PK.root is
+// recomputed from the seeds and must match the stored value, so a
+// corrupted or mismatched key is rejected with
+// [cryptoerrors.ErrInvalidSecretKey] rather than producing signatures
+// that never verify.
+func NewSLHDSAFromSecretKey(p *ParameterSet, sk []uint8) (*SLHDSA, error) {
+	if !p.isValid() {
+		return nil, cryptoerrors.ErrUnsupportedAlgorithm
+	}
+	if len(sk) != p.SecretKeySize() {
+		return nil, cryptoerrors.ErrInvalidSecretKey
+	}
+	s, err := NewSLHDSAFromSeed(p, sk[:p.SeedSize()])
+	if err != nil {
+		//coverage:ignore
+		//rationale: p and the seed length were checked above
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(s.sk, sk) != 1 {
+		s.Zeroize()
+		return nil, cryptoerrors.ErrInvalidSecretKey
+	}
+	return s, nil
+}
+
+// ParameterSet returns the parameter set s was created for.
+func (s *SLHDSA) ParameterSet() *ParameterSet {
+	return s.params
+}
+
+// GetPK returns a copy of the public key, PK.seed || PK.root.
+func (s *SLHDSA) GetPK() []uint8 {
+	return append([]uint8(nil), s.pk...)
+}
+
+// GetSK returns a copy of the secret key,
+// SK.seed || SK.prf || PK.seed || PK.root, or nil after Zeroize.
+func (s *SLHDSA) GetSK() []uint8 {
+	return append([]uint8(nil), s.sk...)
+}
+
+// GetSeed returns a copy of the key-generation seed,
+// SK.seed || SK.prf || PK.seed, which NewSLHDSAFromSeed accepts, or
+// nil after Zeroize.
+func (s *SLHDSA) GetSeed() []uint8 {
+	if s.sk == nil {
+		return nil
+	}
+	return append([]uint8(nil), s.sk[:s.params.SeedSize()]...)
+}
+
+// Sign returns a pure SLH-DSA signature of message under the context
+// string ctx (FIPS 205 Algorithm 22). ctx may be at most 255 bytes;
+// the same ctx must be passed to [Verify].
+//
+// Signing is hedged: opt_rand is drawn from crypto/rand, so repeated
+// calls give different signatures that all verify.
+func (s *SLHDSA) Sign(ctx, message []uint8) ([]uint8, error) {
+	pre, err := purePrefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.signHedged(pre, message)
+}
+
+// SignDeterministic is [SLHDSA.Sign] with opt_rand = PK.seed, the
+// deterministic variant of FIPS 205 §9.2. The same key, ctx and
+// message always give the same signature. Prefer Sign unless
+// reproducibility is itself a requirement.
+func (s *SLHDSA) SignDeterministic(ctx, message []uint8) ([]uint8, error) {
+	pre, err := purePrefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.signWithRandomness(pre, message, nil)
+}
+
+// SignPrehash returns a HashSLH-DSA signature (FIPS 205 Algorithm 23)
+// over a digest the caller has already computed with ph. digest must
+// be exactly ph.DigestSize() bytes: ErrInvalidLength is returned
+// otherwise, ErrInvalidHashFunction for an unsupported ph and
+// ErrInvalidContext for an oversized ctx.
+//
+// HashSLH-DSA signatures are domain-separated from pure ones by their
+// leading 0x01 byte and only verify under [VerifyPrehash]. Signing is
+// hedged, as with [SLHDSA.Sign].
+func (s *SLHDSA) SignPrehash(ctx []uint8, ph PreHashFunction, digest []uint8) ([]uint8, error) {
+	pre, err := prehash.Prefix(ctx, ph, digest)
+	if err != nil {
+		return nil, err
+	}
+	return s.signHedged(pre, digest)
+}
+
+// SignPrehashDeterministic is [SLHDSA.SignPrehash] with
+// opt_rand = PK.seed.
+func (s *SLHDSA) SignPrehashDeterministic(ctx []uint8, ph PreHashFunction, digest []uint8) ([]uint8, error) {
+	pre, err := prehash.Prefix(ctx, ph, digest)
+	if err != nil {
+		return nil, err
+	}
+	return s.signWithRandomness(pre, digest, nil)
+}
+
+func (s *SLHDSA) signHedged(pre, m []uint8) ([]uint8, error) {
+	if s.sk == nil {
+		return nil, cryptoerrors.ErrSecretKeyZeroized
+	}
+	addrnd := make([]uint8, s.params.core.N)
+	if _, err := rand.Read(addrnd); err != nil {
+		//coverage:ignore
+		//rationale: crypto/rand.Read only fails if system entropy source is broken
+		return nil, cryptoerrors.ErrSeedGeneration
+	}
+	return s.signWithRandomness(pre, m, addrnd)
+}
+
+// signWithRandomness signs pre || m with opt_rand = addrnd, or PK.seed
+// if addrnd is nil. The ACVP tests drive it with the vectors'
+// additionalRandomness.
+func (s *SLHDSA) signWithRandomness(pre, m, addrnd []uint8) ([]uint8, error) {
+	if s.sk == nil {
+		return nil, cryptoerrors.ErrSecretKeyZeroized
+	}
+	n := s.params.core.N
+	if addrnd == nil {
+		addrnd = s.sk[2*n : 3*n]
+	}
+	sig := make([]uint8, s.params.SignatureSize())
+	sphincs.Sign(s.params.core, sig, pre, m, s.sk, addrnd, true, 0)
+	return sig, nil
+}
+
+// Verify reports whether signature is a valid pure SLH-DSA signature
+// of message under pk and ctx for parameter set p (FIPS 205 Algorithm
+// 24). Use [VerifyDetailed] to learn why a signature was rejected.
+func Verify(p *ParameterSet, ctx, message, signature, pk []uint8) bool {
+	return VerifyDetailed(p, ctx, message, signature, pk) == nil
+}
+
+// VerifyDetailed is [Verify] returning the reason for a rejection
+// instead of false: [cryptoerrors.ErrUnsupportedAlgorithm] for an
+// unknown p, [cryptoerrors.ErrPublicKeyNil] or
+// [cryptoerrors.ErrInvalidPublicKey] for a missing or wrongly sized pk,
+// [cryptoerrors.ErrInvalidContext] if ctx exceeds 255 bytes,
+// [cryptoerrors.ErrInvalidSignatureSize] for a wrongly sized signature
+// and [cryptoerrors.ErrInvalidSignature] if the signature does not
+// verify.
+func VerifyDetailed(p *ParameterSet, ctx, message, signature, pk []uint8) error {
+	pre, err := purePrefix(ctx)
+	if err != nil {
+		return err
+	}
+	return verify(p, pre, message, signature, pk)
+}
+
+// VerifyPrehash checks a HashSLH-DSA signature produced by
+// [SLHDSA.SignPrehash] against digest, which must have been computed
+// with ph over the original message (FIPS 205 Algorithm 25). Returns
+// false if ph is unsupported, the digest length does not match ph, or
+// ctx exceeds 255 bytes.
+func VerifyPrehash(p *ParameterSet, ctx []uint8, ph PreHashFunction, digest, signature, pk []uint8) bool {
+	pre, err := prehash.Prefix(ctx, ph, digest)
+	if err != nil {
+		return false
+	}
+	return verify(p, pre, digest, signature, pk) == nil
+}
+
+func verify(p *ParameterSet, pre, m, signature, pk []uint8) error {
+	if !p.isValid() {
+		return cryptoerrors.ErrUnsupportedAlgorithm
+	}
+	if pk == nil {
+		return cryptoerrors.ErrPublicKeyNil
+	}
+	if len(pk) != p.PublicKeySize() {
+		return cryptoerrors.ErrInvalidPublicKey
+	}
+	if len(signature) != p.SignatureSize() {
+		return cryptoerrors.ErrInvalidSignatureSize
+	}
+	if !sphincs.Verify(p.core, signature, pre, m, pk, true) {
+		return cryptoerrors.ErrInvalidSignature
+	}
+	return nil
+}
+
+// purePrefix builds the pure SLH-DSA prefix `0x00 || len(ctx) || ctx`
+// that precedes the message (FIPS 205 Algorithm 22).
+func purePrefix(ctx []uint8) ([]uint8, error) {
+	if len(ctx) > 255 {
+		return nil, cryptoerrors.ErrInvalidContext
+	}
+	pre := make([]uint8, 2+len(ctx))
+	pre[1] = uint8(len(ctx))
+	copy(pre[2:], ctx)
+	return pre, nil
+}
+
+// Zeroize wipes the secret key. The public key stays available;
+// signing afterwards returns [cryptoerrors.ErrSecretKeyZeroized].
+func (s *SLHDSA) Zeroize() {
+	zeroBytes(s.sk)
+	s.sk = nil
+}
+
+// zeroBytes overwrites b with zeros. runtime.KeepAlive prevents the compiler
+// from eliding the writes as a dead store.
+func zeroBytes(b []uint8) {
+	for i := range b {
+		b[i] = 0
+	}
+	runtime.KeepAlive(&b)
+}
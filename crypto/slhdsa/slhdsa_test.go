@@ -0,0 +1,176 @@
+package slhdsa
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+func TestParameterSetSizes(t *testing.T) {
+	// FIPS 205 Table 2.
+	tests := []struct {
+		p       *ParameterSet
+		name    string
+		pk, sig int
+	}{
+		{SHA2_128s, "SLH-DSA-SHA2-128s", 32, 7856},
+		{SHAKE_128s, "SLH-DSA-SHAKE-128s", 32, 7856},
+		{SHA2_128f, "SLH-DSA-SHA2-128f", 32, 17088},
+		{SHAKE_128f, "SLH-DSA-SHAKE-128f", 32, 17088},
+		{SHA2_192s, "SLH-DSA-SHA2-192s", 48, 16224},
+		{SHAKE_192s, "SLH-DSA-SHAKE-192s", 48, 16224},
+		{SHA2_192f, "SLH-DSA-SHA2-192f", 48, 35664},
+		{SHAKE_192f, "SLH-DSA-SHAKE-192f", 48, 35664},
+		{SHA2_256s, "SLH-DSA-SHA2-256s", 64, 29792},
+		{SHAKE_256s, "SLH-DSA-SHAKE-256s", 64, 29792},
+		{SHA2_256f, "SLH-DSA-SHA2-256f", 64, 49856},
+		{SHAKE_256f, "SLH-DSA-SHAKE-256f", 64, 49856},
+	}
+	if len(tests) != len(ParameterSets()) {
+		t.Fatalf("ParameterSets() has %d sets, want %d", len(ParameterSets()), len(tests))
+	}
+	for i, tc := range tests {
+		p := tc.p
+		if ParameterSets()[i] != p {
+			t.Errorf("ParameterSets()[%d] = %v, want %v", i, ParameterSets()[i], p)
+		}
+		if p.String() != tc.name || ParameterSetByName(tc.name) != p {
+			t.Errorf("String() = %q, want %q", p.String(), tc.name)
+		}
+		if p.PublicKeySize() != tc.pk || p.SecretKeySize() != 2*tc.pk || p.SignatureSize() != tc.sig || p.SeedSize() != 3*tc.pk/2 {
+			t.Errorf("%v sizes = %d/%d/%d/%d", p, p.PublicKeySize(), p.SecretKeySize(), p.SignatureSize(), p.SeedSize())
+		}
+	}
+	if ParameterSetByName("SLH-DSA-SHAKE-512s") != nil {
+		t.Error("ParameterSetByName accepted an unknown name")
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	s, err := New(SHAKE_256s)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer s.Zeroize()
+	pk := s.GetPK()
+	ctx := []byte("context")
+	msg := []byte("message")
+
+	sig, err := s.Sign(ctx, msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if !Verify(SHAKE_256s, ctx, msg, sig, pk) {
+		t.Fatal("Verify rejected a valid signature")
+	}
+
+	tampered := bytes.Clone(sig)
+	tampered[len(tampered)-1] ^= 1
+	tests := []struct {
+		name          string
+		p             *ParameterSet
+		ctx, msg, sig []uint8
+		pk            []uint8
+		want          error
+	}{
+		{"unknown_parameter_set", &ParameterSet{}, ctx, msg, sig, pk, cryptoerrors.ErrUnsupportedAlgorithm},
+		{"nil_pk", SHAKE_256s, ctx, msg, sig, nil, cryptoerrors.ErrPublicKeyNil},
+		{"short_pk", SHAKE_256s, ctx, msg, sig, pk[1:], cryptoerrors.ErrInvalidPublicKey},
+		{"long_ctx", SHAKE_256s, make([]uint8, 256), msg, sig, pk, cryptoerrors.ErrInvalidContext},
+		{"short_sig", SHAKE_256s, ctx, msg, sig[1:], pk, cryptoerrors.ErrInvalidSignatureSize},
+		{"wrong_ctx", SHAKE_256s, []uint8("other"), msg, sig, pk, cryptoerrors.ErrInvalidSignature},
+		{"empty_ctx", SHAKE_256s, nil, msg, sig, pk, cryptoerrors.ErrInvalidSignature},
+		{"wrong_msg", SHAKE_256s, ctx, []uint8("other"), sig, pk, cryptoerrors.ErrInvalidSignature},
+		{"tampered_sig", SHAKE_256s, ctx, msg, tampered, pk, cryptoerrors.ErrInvalidSignature},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := VerifyDetailed(tc.p, tc.ctx, tc.msg, tc.sig, tc.pk); !errors.Is(err, tc.want) {
+				t.Errorf("VerifyDetailed error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestConstructorErrors(t *testing.T) {
+	seed := make([]uint8, SHAKE_256s.SeedSize())
+	if _, err := New(nil); !errors.Is(err, cryptoerrors.ErrUnsupportedAlgorithm) {
+		t.Errorf("New(nil) error = %v", err)
+	}
+	if _, err := NewSLHDSAFromSeed(&ParameterSet{}, seed); !errors.Is(err, cryptoerrors.ErrUnsupportedAlgorithm) {
+		t.Errorf("NewSLHDSAFromSeed(unknown set) error = %v", err)
+	}
+	if _, err := NewSLHDSAFromSeed(SHAKE_256s, seed[1:]); !errors.Is(err, cryptoerrors.ErrInvalidSeed) {
+		t.Errorf("NewSLHDSAFromSeed(short seed) error = %v", err)
+	}
+	if _, err := NewSLHDSAFromSecretKey(nil, nil); !errors.Is(err, cryptoerrors.ErrUnsupportedAlgorithm) {
+		t.Errorf("NewSLHDSAFromSecretKey(nil) error = %v", err)
+	}
+	if _, err := NewSLHDSAFromSecretKey(SHAKE_256s, seed); !errors.Is(err, cryptoerrors.ErrInvalidSecretKey) {
+		t.Errorf("NewSLHDSAFromSecretKey(short sk) error = %v", err)
+	}
+}
+
+func TestNewSLHDSAFromSecretKey(t *testing.T) {
+	s := katKey(t)
+	sk := s.GetSK()
+
+	got, err := NewSLHDSAFromSecretKey(SHAKE_256s, sk)
+	if err != nil {
+		t.Fatalf("NewSLHDSAFromSecretKey: %v", err)
+	}
+	if !bytes.Equal(got.GetPK(), s.GetPK()) || got.ParameterSet() != SHAKE_256s {
+		t.Error("key changed across NewSLHDSAFromSecretKey")
+	}
+
+	// A PK.root that does not belong to the seeds is rejected.
+	sk[len(sk)-1] ^= 1
+	if _, err := NewSLHDSAFromSecretKey(SHAKE_256s, sk); !errors.Is(err, cryptoerrors.ErrInvalidSecretKey) {
+		t.Errorf("mismatched PK.root error = %v", err)
+	}
+}
+
+func TestZeroize(t *testing.T) {
+	s := katKey(t)
+	sk := s.sk
+	s.Zeroize()
+
+	for _, b := range sk {
+		if b != 0 {
+			t.Fatal("Zeroize left secret key bytes in place")
+		}
+	}
+	if s.GetSK() != nil || s.GetSeed() != nil {
+		t.Error("secret key still readable after Zeroize")
+	}
+	if len(s.GetPK()) != SHAKE_256s.PublicKeySize() {
+		t.Error("public key lost after Zeroize")
+	}
+	if _, err := s.Sign(nil, []uint8("m")); !errors.Is(err, cryptoerrors.ErrSecretKeyZeroized) {
+		t.Errorf("Sign after Zeroize error = %v", err)
+	}
+	if _, err := s.SignDeterministic(nil, []uint8("m")); !errors.Is(err, cryptoerrors.ErrSecretKeyZeroized) {
+		t.Errorf("SignDeterministic after Zeroize error = %v", err)
+	}
+}
+
+func TestCrossParameterSet(t *testing.T) {
+	s := katKeyFor(t, SHA2_128f)
+	msg := []uint8("message")
+	sig, err := s.Sign(nil, msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if err := VerifyDetailed(SHA2_128f, nil, msg, sig, s.GetPK()); err != nil {
+		t.Fatalf("VerifyDetailed: %v", err)
+	}
+	// Same sizes, other hash family: the signature must not verify.
+	if err := VerifyDetailed(SHAKE_128f, nil, msg, sig, s.GetPK()); !errors.Is(err, cryptoerrors.ErrInvalidSignature) {
+		t.Errorf("SHAKE_128f error = %v, want ErrInvalidSignature", err)
+	}
+	if err := VerifyDetailed(SHA2_192f, nil, msg, sig, s.GetPK()); !errors.Is(err, cryptoerrors.ErrInvalidPublicKey) {
+		t.Errorf("SHA2_192f error = %v, want ErrInvalidPublicKey", err)
+	}
+}
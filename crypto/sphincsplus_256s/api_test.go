@@ -39,7 +39,7 @@ func TestCryptoSignKeypair(t *testing.T) {
 	var seed [96]byte
 	copy(seed[:], unSizedSeed)
 
-	if err = cryptoSignKeypair(pk, sk, seed); err != nil {
+	if err = cryptoSignKeypair(pk, sk, seed, false, 0); err != nil {
 		t.Error(err)
 	}
 	if hex.EncodeToString(pk) != expectedPK {
@@ -52,11 +52,11 @@ func TestCryptoSignKeypair(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	sm, _ := cryptoSign(m, sk, genOptRandFunc)
+	sm, _ := cryptoSign(m, sk, genOptRandFunc, false, 0)
 	if hex.EncodeToString(sm) != expectedSM {
 		t.Error("sm mismatch")
 	}
-	if cryptoSignOpen(m, sm, pk) != true {
+	if cryptoSignOpen(m, sm, pk, false) != true {
 		t.Error("cryptoSignOpen failed")
 	}
 }
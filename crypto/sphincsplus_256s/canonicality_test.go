@@ -366,7 +366,7 @@ func TestCanonicalitySignatureSize(t *testing.T) {
 	for _, size := range wrongSizes {
 		t.Run("wrong_size", func(t *testing.T) {
 			wrongSig := make([]byte, size)
-			if cryptoSignVerify(wrongSig, msg, pk[:]) {
+			if cryptoSignVerify(wrongSig, msg, pk[:], false) {
 				t.Errorf("Signature of size %d (expected %d) should not verify", size, params.SPX_BYTES)
 			}
 		})
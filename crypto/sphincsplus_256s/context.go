@@ -1,8 +1,8 @@
 package sphincsplus_256s
 
-import "github.com/theQRL/go-qrllib/crypto/sphincsplus_256s/params"
+import "github.com/theQRL/go-qrllib/crypto/internal/sphincs"
 
-type SPXCtx struct {
-	PubSeed [params.SPX_N]byte
-	SkSeed  [params.SPX_N]byte
-}
+// SPXCtx carries the public and secret seeds the hash functions are
+// keyed with. Simple is unset for [SphincsPlus256s], which uses the
+// robust tweakable hash, and set for [SphincsPlus256sSimple].
+type SPXCtx = sphincs.Ctx
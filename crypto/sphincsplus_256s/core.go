@@ -0,0 +1,83 @@
+package sphincsplus_256s
+
+import "github.com/theQRL/go-qrllib/crypto/internal/sphincs"
+
+// The WOTS+, FORS and hypertree code lives in crypto/internal/sphincs,
+// where crypto/slhdsa shares it. The exported building blocks below
+// keep their original names here.
+
+const (
+	SPX_ADDR_TYPE_WOTS     = sphincs.SPX_ADDR_TYPE_WOTS
+	SPX_ADDR_TYPE_WOTSPK   = sphincs.SPX_ADDR_TYPE_WOTSPK
+	SPX_ADDR_TYPE_HASHTREE = sphincs.SPX_ADDR_TYPE_HASHTREE
+	SPX_ADDR_TYPE_FORSTREE = sphincs.SPX_ADDR_TYPE_FORSTREE
+	SPX_ADDR_TYPE_FORSPK   = sphincs.SPX_ADDR_TYPE_FORSPK
+	SPX_ADDR_TYPE_WOTSPRF  = sphincs.SPX_ADDR_TYPE_WOTSPRF
+	SPX_ADDR_TYPE_FORSPRF  = sphincs.SPX_ADDR_TYPE_FORSPRF
+)
+
+var (
+	SPX_TREE_BITS  = sphincs.SHAKE_256s.TreeBits
+	SPX_TREE_BYTES = sphincs.SHAKE_256s.TreeBytes
+	SPX_LEAF_BITS  = sphincs.SHAKE_256s.LeafBits
+	SPX_LEAF_BYTES = sphincs.SHAKE_256s.LeafBytes
+	SPX_DGST_BYTES = sphincs.SHAKE_256s.DgstBytes
+)
+
+// LeafInfoX1 is the state WotsGenLeafX1 uses while generating leaves.
+type LeafInfoX1 = sphincs.LeafInfoX1
+
+// GenChain performs the hash chain operation as in WOTS+
+func GenChain(out, in []byte, start, steps uint, ctx *SPXCtx, addr *[8]uint32) {
+	sphincs.GenChain(out, in, start, steps, ctx, addr)
+}
+
+// BaseW interprets a byte array as integers in base w
+func BaseW(output []uint8, outLen int, input []byte) {
+	sphincs.BaseW(output, outLen, input)
+}
+
+// WotsChecksum computes the WOTS+ checksum over a base_w message
+func WotsChecksum(csumBaseW []uint8, msgBaseW []uint8) {
+	sphincs.WotsChecksum(csumBaseW, msgBaseW, sphincs.SHAKE_256s)
+}
+
+// WotsPKFromSig computes the WOTS public key from a signature and message
+func WotsPKFromSig(pk, sig, msg []byte, ctx *SPXCtx, addr *[8]uint32) {
+	sphincs.WotsPKFromSig(pk, sig, msg, ctx, addr)
+}
+
+func MerkleSign(sig []byte, root []byte, ctx *SPXCtx, wotsAddr, treeAddr *[8]uint32, idxLeaf uint32) {
+	sphincs.MerkleSign(sig, root, ctx, wotsAddr, treeAddr, idxLeaf)
+}
+
+// MerkleGenRoot computes the root of the top hypertree layer, the
+// PK.root of a key pair. Setting ctx.Workers above one generates the
+// leaves on that many goroutines; the root is the same.
+func MerkleGenRoot(root []byte, ctx *SPXCtx) {
+	sphincs.MerkleGenRoot(root, ctx)
+}
+
+func InitializeLeafInfoX1(info *LeafInfoX1, addr *[8]uint32, stepBuffer []uint8) {
+	sphincs.InitializeLeafInfoX1(info, addr, stepBuffer)
+}
+
+func WotsGenLeafX1(dest []byte, ctx *SPXCtx, leafIdx uint32, vInfo any) {
+	sphincs.WotsGenLeafX1(dest, ctx, leafIdx, vInfo)
+}
+
+func UllToBytes(out []byte, outLen int, in uint64) {
+	sphincs.UllToBytes(out, outLen, in)
+}
+
+func U32ToBytes(out []byte, in uint32) {
+	sphincs.U32ToBytes(out, in)
+}
+
+func BytesToUll(in []byte, inLen int) uint64 {
+	return sphincs.BytesToUll(in, inLen)
+}
+
+func Uint32SliceToBytes(in []uint32) []byte {
+	return sphincs.Uint32SliceToBytes(in)
+}
@@ -1,11 +1,13 @@
 // Package sphincsplus_256s implements the pre-FIPS SPHINCS+-256s
-// SHAKE-256s-robust digital signature algorithm.
+// SHAKE-256s-robust digital signature algorithm, and its
+// SHAKE-256s-simple counterpart.
 //
 // NIST later standardised the stateless hash-based signature family as
 // SLH-DSA in FIPS 205. This package is retained as the SPHINCS+ submission
-// variant used by go-qrllib today; callers that need strict FIPS 205
-// compliance should wait for the QRL-selected SLH-DSA parameter set and
-// corresponding implementation update.
+// variant used by go-qrllib today; callers that need FIPS 205 should use
+// crypto/slhdsa. Both packages share the WOTS+, FORS and hypertree code in
+// crypto/internal/sphincs, but their keys and signatures are not
+// interchangeable.
 //
 // SPHINCS+ is a stateless hash-based signature scheme that provides conservative
 // post-quantum security based solely on the security of hash functions, without
@@ -42,6 +44,24 @@
 // but Sign and SignAttached should not be called concurrently on the same instance.
 // The package-level Verify and Open functions are safe for concurrent use.
 //
+// # Simple Variant
+//
+// [SphincsPlus256sSimple] is the SHAKE-256s-simple instantiation of the
+// same parameter set. Its tweakable hash omits the robust bitmask, which
+// makes it about twice as fast; it is the variant FIPS 205 retained.
+// Simple keys have their own type, [SimplePublicKey], and are verified
+// with [VerifySimple] and [OpenSimple] only, so a robust key or
+// signature cannot be checked against the simple construction by
+// accident.
+//
+// # Parallel Signing
+//
+// Signing is dominated by generating the leaves of the FORS trees and
+// the hypertree layers. [SphincsPlus256s.SetWorkers] spreads that work
+// over several goroutines, and [NewSphincsPlus256sFromSeedWithWorkers]
+// does the same for key generation. The output is byte-identical to
+// the serial path.
+//
 // # Randomized vs Deterministic Signing
 //
 // By default, SPHINCS+ uses randomized signing which provides additional
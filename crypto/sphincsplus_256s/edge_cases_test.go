@@ -349,7 +349,7 @@ func TestEdgeCaseInvalidSignatureSize(t *testing.T) {
 
 	// Signature that is not exactly SPX_BYTES
 	shortSig := make([]byte, params.SPX_BYTES-1)
-	if cryptoSignVerify(shortSig, msg, pk[:]) {
+	if cryptoSignVerify(shortSig, msg, pk[:], false) {
 		t.Error("cryptoSignVerify should return false for signature with wrong size")
 	}
 }
@@ -369,7 +369,7 @@ func TestEdgeCaseInvalidSealedMessageSize(t *testing.T) {
 	// Attached signature message that is too short
 	shortSealed := make([]byte, params.SPX_BYTES-1)
 	m := make([]byte, 100)
-	if cryptoSignOpen(m, shortSealed, pk[:]) {
+	if cryptoSignOpen(m, shortSealed, pk[:], false) {
 		t.Error("cryptoSignOpen should return false for message with wrong size")
 	}
 }
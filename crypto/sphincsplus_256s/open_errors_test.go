@@ -90,3 +90,21 @@ func TestOpen_HappyPath_ReturnsMessageAndNilError(t *testing.T) {
 		t.Errorf("Open(valid) recovered %q; want %q", string(msg), "test message")
 	}
 }
+
+func TestVerifyDetailed(t *testing.T) {
+	spx, sealed := openFixture(t)
+	pk := spx.GetPK()
+	var sig [params.SPX_BYTES]uint8
+	copy(sig[:], sealed)
+	msg := sealed[params.SPX_BYTES:]
+
+	if err := VerifyDetailed(msg, sig, &pk); err != nil {
+		t.Fatalf("VerifyDetailed(valid) err = %v; want nil", err)
+	}
+	if err := VerifyDetailed(msg, sig, nil); !errors.Is(err, cryptoerrors.ErrPublicKeyNil) {
+		t.Errorf("VerifyDetailed(nil pk) err = %v; want ErrPublicKeyNil", err)
+	}
+	if err := VerifyDetailed([]byte("other"), sig, &pk); !errors.Is(err, cryptoerrors.ErrInvalidSignature) {
+		t.Errorf("VerifyDetailed(wrong message) err = %v; want ErrInvalidSignature", err)
+	}
+}
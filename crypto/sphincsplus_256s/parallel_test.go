@@ -0,0 +1,111 @@
+package sphincsplus_256s
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/theQRL/go-qrllib/crypto/sphincsplus_256s/params"
+)
+
+// TestParallelMatchesKAT checks that key generation and signing with
+// several workers reproduce the reference known answers exactly.
+func TestParallelMatchesKAT(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping slow SPHINCS+ test in short mode")
+	}
+	unSizedSeed, err := hex.DecodeString(expectedSK[:2*CRYPTO_SEEDBYTES])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var seed [CRYPTO_SEEDBYTES]uint8
+	copy(seed[:], unSizedSeed)
+	m, err := hex.DecodeString(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 3 is not a power of two, 1000 exceeds the leaves of every tree.
+	for _, workers := range []int{3, 1000} {
+		pk := make([]byte, params.SPX_PK_BYTES)
+		sk := make([]byte, params.SPX_SK_BYTES)
+		if err := cryptoSignKeypair(pk, sk, seed, false, workers); err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(pk) != expectedPK || hex.EncodeToString(sk) != expectedSK {
+			t.Errorf("workers=%d: key pair differs from the serial one", workers)
+		}
+		sm, err := cryptoSign(m, sk, genOptRandFunc, false, workers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(sm) != expectedSM {
+			t.Errorf("workers=%d: signature differs from the serial one", workers)
+		}
+	}
+}
+
+func TestSetWorkers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping slow SPHINCS+ test in short mode")
+	}
+	var seed [CRYPTO_SEEDBYTES]uint8
+	serial, err := NewSphincsPlus256sFromSeed(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parallel, err := NewSphincsPlus256sFromSeedWithWorkers(seed, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serial.GetPK() != parallel.GetPK() || serial.GetSK() != parallel.GetSK() {
+		t.Fatal("NewSphincsPlus256sFromSeedWithWorkers changed the key pair")
+	}
+
+	fixedOptrand := func(buf []byte) error {
+		for i := range buf {
+			buf[i] = 0x42
+		}
+		return nil
+	}
+	serial.SetGenerateOptRand(fixedOptrand)
+	parallel.SetGenerateOptRand(fixedOptrand)
+	parallel.SetVerifyAfterSign(true)
+
+	msg := []byte("parallel signing")
+	want, err := serial.Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := parallel.Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Error("parallel Sign differs from serial Sign")
+	}
+	out, err := NewCryptoSigner(parallel).SignMessage(nil, msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, want[:]) {
+		t.Error("parallel SignMessage differs from serial Sign")
+	}
+}
+
+func TestMerkleGenRootWorkers(t *testing.T) {
+	ctx := &SPXCtx{}
+	for i := range ctx.PubSeed {
+		ctx.PubSeed[i] = uint8(i)
+		ctx.SkSeed[i] = uint8(0xff - i)
+	}
+	want := make([]byte, params.SPX_N)
+	MerkleGenRoot(want, ctx)
+
+	ctx.Workers = 5
+	got := make([]byte, params.SPX_N)
+	MerkleGenRoot(got, ctx)
+	if !bytes.Equal(got, want) {
+		t.Errorf("MerkleGenRoot with workers = %x, want %x", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+package sphincsplus_256s
+
+import (
+	"crypto"
+	"crypto/subtle"
+	"errors"
+	"io"
+
+	"github.com/theQRL/go-qrllib/crypto/sphincsplus_256s/params"
+)
+
+var errUnsupportedSignerOpts = errors.New("sphincsplus_256s: opts.HashFunc() must be 0; pre-hashed signing is not supported")
+
+// CryptoPublicKey wraps the SPHINCS+-256s public key for crypto.PublicKey compatibility.
+type CryptoPublicKey struct {
+	key [params.SPX_PK_BYTES]uint8
+}
+
+// NewCryptoPublicKey wraps a SPHINCS+-256s public key. Returns nil if
+// pk is nil.
+func NewCryptoPublicKey(pk *[params.SPX_PK_BYTES]uint8) *CryptoPublicKey {
+	if pk == nil {
+		return nil
+	}
+	return &CryptoPublicKey{key: *pk}
+}
+
+func (pk *CryptoPublicKey) Equal(x crypto.PublicKey) bool {
+	other, ok := x.(*CryptoPublicKey)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare(pk.key[:], other.key[:]) == 1
+}
+
+// Bytes returns a copy of the raw public key bytes.
+func (pk *CryptoPublicKey) Bytes() [params.SPX_PK_BYTES]uint8 {
+	return pk.key
+}
+
+// CryptoSigner wraps a SphincsPlus256s instance to implement
+// crypto.Signer and crypto.MessageSigner.
+type CryptoSigner struct {
+	s *SphincsPlus256s
+}
+
+// NewCryptoSigner returns a crypto.MessageSigner backed by the given SphincsPlus256s instance.
+func NewCryptoSigner(s *SphincsPlus256s) *CryptoSigner {
+	return &CryptoSigner{s: s}
+}
+
+func (c *CryptoSigner) Public() crypto.PublicKey {
+	return &CryptoPublicKey{key: c.s.GetPK()}
+}
+
+// Sign implements crypto.Signer. SPHINCS+ signs whole messages, so
+// digest is the message itself, not a hash of it; Sign behaves exactly
+// like [CryptoSigner.SignMessage].
+func (c *CryptoSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return c.SignMessage(rand, digest, opts)
+}
+
+// SignMessage implements crypto.MessageSigner and returns a detached
+// signature over msg, as [SphincsPlus256s.Sign] does. SPHINCS+ has no
+// signing context, so opts only has to be nil or report a zero
+// HashFunc (such as the crypto.Hash(0) passed by crypto.SignMessage).
+//
+// The rand parameter, when non-nil, supplies the per-signature
+// optrand; when nil, the instance's own generator (crypto/rand) is
+// used. A constant reader makes signing deterministic.
+func (c *CryptoSigner) SignMessage(rand io.Reader, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts != nil && opts.HashFunc() != 0 {
+		return nil, errUnsupportedSignerOpts
+	}
+	generateOptRand := c.s.generateOptRand
+	if rand != nil {
+		generateOptRand = func(optRand []byte) error {
+			_, err := io.ReadFull(rand, optRand)
+			return err
+		}
+	}
+
+	sig := make([]byte, params.SPX_BYTES)
+	if err := cryptoSignSignature(sig, msg, c.s.sk[:], generateOptRand, false, c.s.workers); err != nil {
+		return nil, err
+	}
+	if err := c.s.checkSignature(sig, msg); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
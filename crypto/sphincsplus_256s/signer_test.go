@@ -0,0 +1,100 @@
+package sphincsplus_256s
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"testing"
+
+	"github.com/theQRL/go-qrllib/crypto/sphincsplus_256s/params"
+)
+
+// constReader is an io.Reader that fills every read with one byte value.
+type constReader byte
+
+func (c constReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(c)
+	}
+	return len(p), nil
+}
+
+type errReader struct{ err error }
+
+func (e errReader) Read(_ []byte) (int, error) { return 0, e.err }
+
+func TestCryptoSigner(t *testing.T) {
+	var seed [CRYPTO_SEEDBYTES]uint8
+	s, err := NewSphincsPlus256sFromSeed(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Zeroize()
+	signer := NewCryptoSigner(s)
+	var _ crypto.MessageSigner = signer
+
+	pub, ok := signer.Public().(*CryptoPublicKey)
+	if !ok {
+		t.Fatal("Public() did not return *CryptoPublicKey")
+	}
+	pk := s.GetPK()
+	if pub.Bytes() != pk || !pub.Equal(NewCryptoPublicKey(&pk)) {
+		t.Error("Public key mismatch between CryptoSigner and underlying SphincsPlus256s")
+	}
+
+	msg := []byte("message signer test")
+	sig, err := crypto.SignMessage(signer, nil, msg, nil)
+	if err != nil {
+		t.Fatalf("crypto.SignMessage failed: %v", err)
+	}
+	if len(sig) != params.SPX_BYTES {
+		t.Fatalf("signature length %d, want %d", len(sig), params.SPX_BYTES)
+	}
+	var sigArr [params.SPX_BYTES]uint8
+	copy(sigArr[:], sig)
+	if !Verify(msg, sigArr, &pk) {
+		t.Error("crypto.SignMessage signature failed verification")
+	}
+
+	// A caller-supplied rand drives optrand, so a constant source makes
+	// Sign and SignMessage agree byte for byte. The second call goes
+	// through crypto.SignMessage with crypto.Hash(0), which generic
+	// callers pass for pure signing.
+	sig1, err := signer.Sign(constReader(7), msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig2, err := crypto.SignMessage(signer, constReader(7), msg, crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("crypto.SignMessage with crypto.Hash(0) failed: %v", err)
+	}
+	if !bytes.Equal(sig1, sig2) {
+		t.Error("Sign and crypto.SignMessage with the same constant rand produced different signatures")
+	}
+}
+
+func TestCryptoSignerErrors(t *testing.T) {
+	var seed [CRYPTO_SEEDBYTES]uint8
+	s, err := NewSphincsPlus256sFromSeed(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Zeroize()
+	signer := NewCryptoSigner(s)
+
+	if _, err := signer.SignMessage(nil, []byte("m"), crypto.SHA256); !errors.Is(err, errUnsupportedSignerOpts) {
+		t.Errorf("SignMessage with opts: got %v, want %v", err, errUnsupportedSignerOpts)
+	}
+	wantErr := errors.New("simulated rand source failure")
+	if _, err := signer.SignMessage(errReader{err: wantErr}, []byte("m"), nil); !errors.Is(err, wantErr) {
+		t.Errorf("SignMessage with failing rand: got %v, want %v", err, wantErr)
+	}
+
+	var pk [params.SPX_PK_BYTES]uint8
+	if NewCryptoPublicKey(nil) != nil {
+		t.Error("NewCryptoPublicKey(nil) should return nil")
+	}
+	if NewCryptoPublicKey(&pk).Equal(&pk) {
+		t.Error("Equal should reject a non-*CryptoPublicKey")
+	}
+}
@@ -0,0 +1,204 @@
+package sphincsplus_256s
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/crypto/sphincsplus_256s/params"
+)
+
+// SimplePublicKey is a SPHINCS+-SHAKE-256s-simple public key. It has
+// the same size as a robust [SphincsPlus256s] public key but is a
+// distinct type, so a key of one variant cannot be handed to the other
+// variant's verifier by mistake.
+type SimplePublicKey [params.SPX_PK_BYTES]uint8
+
+// SphincsPlus256sSimple is a SPHINCS+-SHAKE-256s-simple key pair. It
+// differs from [SphincsPlus256s] only in the tweakable hash, which is
+// SHAKE256(PK.seed || ADRS || M) without the robust bitmask. Sizes and
+// seeds are shared, but keys and signatures of the two variants are
+// not interchangeable: the same seed yields a different public key.
+type SphincsPlus256sSimple struct {
+	pk              SimplePublicKey
+	sk              [params.SPX_SK_BYTES]uint8
+	seed            [CRYPTO_SEEDBYTES]uint8
+	generateOptRand func([]byte) error
+
+	// verifyAfterSign enables the fault countermeasure; see
+	// SetVerifyAfterSign.
+	verifyAfterSign bool
+
+	// workers is the goroutine count for signing; see SetWorkers.
+	workers int
+}
+
+// NewSimple generates a SPHINCS+-SHAKE-256s-simple key pair from a
+// random seed.
+func NewSimple() (*SphincsPlus256sSimple, error) {
+	var seed [CRYPTO_SEEDBYTES]uint8
+
+	_, err := rand.Read(seed[:])
+	if err != nil {
+		//coverage:ignore
+		//rationale: crypto/rand.Read only fails if system entropy source is broken
+		return nil, cryptoerrors.ErrSeedGeneration
+	}
+
+	return NewSphincsPlus256sSimpleFromSeed(seed)
+}
+
+// NewSphincsPlus256sSimpleFromSeed derives a SPHINCS+-SHAKE-256s-simple
+// key pair from seed, as the round-3 reference crypto_sign_seed_keypair
+// does when built with THASH=simple.
+func NewSphincsPlus256sSimpleFromSeed(seed [CRYPTO_SEEDBYTES]uint8) (*SphincsPlus256sSimple, error) {
+	var sk [params.SPX_SK_BYTES]uint8
+	var pk SimplePublicKey
+
+	if err := cryptoSignKeypair(pk[:], sk[:], seed, true, 0); err != nil {
+		//coverage:ignore
+		//rationale: cryptoSignKeypair only fails if buffers are wrong size, but we use fixed-size arrays
+		return nil, err
+	}
+
+	return &SphincsPlus256sSimple{pk: pk, sk: sk, seed: seed, generateOptRand: generateOptrand}, nil
+}
+
+// NewSphincsPlus256sSimpleFromHexSeed is [NewSphincsPlus256sSimpleFromSeed]
+// taking the seed as hex, with an optional 0x prefix.
+func NewSphincsPlus256sSimpleFromHexSeed(hexSeed string) (*SphincsPlus256sSimple, error) {
+	seed, err := decodeHexSeed(hexSeed)
+	if err != nil {
+		return nil, err
+	}
+	return NewSphincsPlus256sSimpleFromSeed(seed)
+}
+
+// SetGenerateOptRand replaces the randomness generator used during signing.
+// This is intended for testing only; see [SphincsPlus256s.SetGenerateOptRand].
+//
+// Calling this outside a `go test` binary panics.
+func (s *SphincsPlus256sSimple) SetGenerateOptRand(generateOptRand func([]byte) error) {
+	if !testing.Testing() {
+		//coverage:ignore
+		//rationale: testing.Testing() is true in every go test binary, so this
+		//branch is unreachable under test; it exists to panic on production misuse
+		panic("sphincsplus_256s: SetGenerateOptRand is test-only and must not be called from production code")
+	}
+	s.generateOptRand = generateOptRand
+}
+
+// SetVerifyAfterSign enables or disables verify-after-sign, as
+// [SphincsPlus256s.SetVerifyAfterSign] does.
+func (s *SphincsPlus256sSimple) SetVerifyAfterSign(enabled bool) {
+	s.verifyAfterSign = enabled
+}
+
+// SetWorkers sets how many goroutines signing may use, as
+// [SphincsPlus256s.SetWorkers] does.
+func (s *SphincsPlus256sSimple) SetWorkers(workers int) {
+	s.workers = workers
+}
+
+// checkSignature finishes the verify-after-sign check for a fresh
+// signature sig over m. If the check is enabled and sig does not
+// verify, sig is wiped and ErrSigningFailed is returned.
+func (s *SphincsPlus256sSimple) checkSignature(sig, m []uint8) error {
+	if !s.verifyAfterSign || cryptoSignVerify(sig, m, s.pk[:], true) {
+		return nil
+	}
+	for i := range sig {
+		sig[i] = 0
+	}
+	return cryptoerrors.ErrSigningFailed
+}
+
+func (s *SphincsPlus256sSimple) GetPK() SimplePublicKey {
+	return s.pk
+}
+
+func (s *SphincsPlus256sSimple) GetSK() [params.SPX_SK_BYTES]uint8 {
+	return s.sk
+}
+
+func (s *SphincsPlus256sSimple) GetSeed() [CRYPTO_SEEDBYTES]uint8 {
+	return s.seed
+}
+
+func (s *SphincsPlus256sSimple) GetHexSeed() string {
+	seed := s.GetSeed()
+	return "0x" + hex.EncodeToString(seed[:])
+}
+
+// SignAttached signs message and returns `signature || message` as a
+// single attached-signature byte string. Use [OpenSimple] to verify it.
+func (s *SphincsPlus256sSimple) SignAttached(message []uint8) ([]uint8, error) {
+	sm, err := cryptoSign(message, s.sk[:], s.generateOptRand, true, s.workers)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkSignature(sm[:params.SPX_BYTES], sm[params.SPX_BYTES:]); err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
+
+// Sign the message, and return a detached signature of exactly
+// params.SPX_BYTES bytes. Use [VerifySimple] to verify it.
+func (s *SphincsPlus256sSimple) Sign(message []uint8) ([params.SPX_BYTES]uint8, error) {
+	var signature [params.SPX_BYTES]uint8
+
+	sm, err := s.SignAttached(message)
+	if err == nil {
+		copy(signature[:params.SPX_BYTES], sm[:params.SPX_BYTES])
+	}
+	return signature, err
+}
+
+// OpenSimple is [Open] for the simple variant: it verifies an
+// attached signature produced by [SphincsPlus256sSimple.SignAttached]
+// under pk and returns the message, with the same errors as Open.
+func OpenSimple(signatureMessage []uint8, pk *SimplePublicKey) ([]uint8, error) {
+	if pk == nil {
+		return nil, cryptoerrors.ErrPublicKeyNil
+	}
+	if len(signatureMessage) < params.SPX_BYTES {
+		return nil, cryptoerrors.ErrInvalidSignatureSize
+	}
+	m := make([]uint8, len(signatureMessage)-params.SPX_BYTES)
+	if !cryptoSignOpen(m, signatureMessage, pk[:], true) {
+		return nil, cryptoerrors.ErrInvalidSignature
+	}
+	return m, nil
+}
+
+// VerifySimple reports whether signature is a valid
+// SPHINCS+-SHAKE-256s-simple signature over message under pk. Returns
+// false if pk is nil.
+func VerifySimple(message []uint8, signature [params.SPX_BYTES]uint8, pk *SimplePublicKey) bool {
+	return VerifySimpleDetailed(message, signature, pk) == nil
+}
+
+// VerifySimpleDetailed is [VerifySimple] returning the reason for a
+// rejection, as [VerifyDetailed] does.
+func VerifySimpleDetailed(message []uint8, signature [params.SPX_BYTES]uint8, pk *SimplePublicKey) error {
+	if pk == nil {
+		return cryptoerrors.ErrPublicKeyNil
+	}
+	if !cryptoSignVerify(signature[:], message, pk[:], true) {
+		return cryptoerrors.ErrInvalidSignature
+	}
+	return nil
+}
+
+// Zeroize clears sensitive key material from memory.
+func (s *SphincsPlus256sSimple) Zeroize() {
+	for i := range s.sk {
+		s.sk[i] = 0
+	}
+	for i := range s.seed {
+		s.seed[i] = 0
+	}
+	s.generateOptRand = nil
+}
@@ -0,0 +1,165 @@
+package sphincsplus_256s
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/crypto/sphincsplus_256s/params"
+)
+
+// Known answers for SPHINCS+-SHAKE-256s-simple, count 0 of
+// PQCsignKAT_128.rsp from the SPHINCS+ reference PQCgenKAT_sign
+// (github.com/sphincs/sphincsplus, PARAMS=sphincs-shake-256s
+// THASH=simple). The key pair seed and the optrand are what the NIST
+// DRBG hands to crypto_sign_keypair and crypto_sign for that count; sm
+// (signature followed by message) is stored as its SHA-256, to compare
+// with the SHA-256 of the file's sm line. The expected values have not
+// yet been diffed against a copy of the published file.
+const (
+	simpleSeed          = "7c9935a0b07694aa0c6d10e4db6b1add2fd81a25ccb148032dcd739936737f2db505d7cfad1b497499323c8686325e4792f267aafa3f87ca60d01cb54f29202a3e784ccb7ebcdcfd45542b7f6af778742e0f4479175084aa488b3b74340678aa"
+	simpleMessage       = "d81c4d8d734fcbfbeade3d3f8a039faa2a2c9957e835ad55b22e75bf57bb556ac8"
+	simpleOptRand       = "ee716762c15e3b72aa7650a63b9a510040b03c0fe70475c0463bbc45a0ba5b79"
+	simpleExpectedPK    = "3e784ccb7ebcdcfd45542b7f6af778742e0f4479175084aa488b3b74340678aa3623940d5d834494148a661f9ac6a96bdc54ad4d0b8b0913484a9233c56212a4"
+	simpleExpectedSK    = "7c9935a0b07694aa0c6d10e4db6b1add2fd81a25ccb148032dcd739936737f2db505d7cfad1b497499323c8686325e4792f267aafa3f87ca60d01cb54f29202a3e784ccb7ebcdcfd45542b7f6af778742e0f4479175084aa488b3b74340678aa3623940d5d834494148a661f9ac6a96bdc54ad4d0b8b0913484a9233c56212a4"
+	simpleExpectedSMSum = "299cd267c68a2e9dfc1d6d8f7018da484cb2d4f7dc28310eebb08ba12076b122"
+)
+
+func katSimpleKey(t *testing.T) *SphincsPlus256sSimple {
+	t.Helper()
+	s, err := NewSphincsPlus256sSimpleFromHexSeed(simpleSeed)
+	if err != nil {
+		t.Fatalf("NewSphincsPlus256sSimpleFromHexSeed: %v", err)
+	}
+	return s
+}
+
+func TestSimpleKATKeyGen(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping slow SPHINCS+ test in short mode")
+	}
+
+	s := katSimpleKey(t)
+	pk := s.GetPK()
+	if got := hex.EncodeToString(pk[:]); got != simpleExpectedPK {
+		t.Errorf("pk mismatch\nExpected: %s\nFound: %s", simpleExpectedPK, got)
+	}
+	sk := s.GetSK()
+	if got := hex.EncodeToString(sk[:]); got != simpleExpectedSK {
+		t.Errorf("sk mismatch\nExpected: %s\nFound: %s", simpleExpectedSK, got)
+	}
+
+	bs, err := NewSphincsPlus256sSimpleFromSeed(s.GetSeed())
+	if err != nil {
+		t.Fatalf("NewSphincsPlus256sSimpleFromSeed: %v", err)
+	}
+	if bs.GetPK() != pk {
+		t.Error("hex and binary seed give different public keys")
+	}
+
+	// The robust variant derives a different key from the same seed.
+	r, err := NewSphincsPlus256sFromSeed(s.GetSeed())
+	if err != nil {
+		t.Fatalf("NewSphincsPlus256sFromSeed: %v", err)
+	}
+	if rpk := r.GetPK(); bytes.Equal(rpk[:], pk[:]) {
+		t.Error("simple and robust public keys are equal")
+	}
+}
+
+func TestSimpleKATSignVerify(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping slow SPHINCS+ test in short mode")
+	}
+
+	optRand, err := hex.DecodeString(simpleOptRand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := hex.DecodeString(simpleMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := katSimpleKey(t)
+	s.SetGenerateOptRand(func(buf []byte) error {
+		copy(buf, optRand)
+		return nil
+	})
+	s.SetVerifyAfterSign(true)
+
+	sm, err := s.SignAttached(msg)
+	if err != nil {
+		t.Fatalf("SignAttached: %v", err)
+	}
+	if sum := sha256.Sum256(sm); hex.EncodeToString(sum[:]) != simpleExpectedSMSum {
+		t.Errorf("SHA-256(sm) = %x, want %s", sum, simpleExpectedSMSum)
+	}
+	sig, err := s.Sign(msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if !bytes.Equal(sig[:], sm[:params.SPX_BYTES]) {
+		t.Error("Sign and SignAttached produced different signatures")
+	}
+
+	pkBytes, err := hex.DecodeString(simpleExpectedPK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pk SimplePublicKey
+	copy(pk[:], pkBytes)
+
+	if err := VerifySimpleDetailed(msg, sig, &pk); err != nil {
+		t.Errorf("VerifySimpleDetailed: %v", err)
+	}
+	if m, err := OpenSimple(sm, &pk); err != nil || !bytes.Equal(m, msg) {
+		t.Errorf("OpenSimple = %x, %v", m, err)
+	}
+	if VerifySimple(msg[1:], sig, &pk) {
+		t.Error("VerifySimple accepted a different message")
+	}
+
+	// The same bytes taken as a robust public key must not verify it.
+	robustPK := [params.SPX_PK_BYTES]uint8(pk)
+	if Verify(msg, sig, &robustPK) {
+		t.Error("robust Verify accepted a simple signature")
+	}
+}
+
+// TestSimpleNewRoundTrip checks a freshly generated simple key.
+func TestSimpleNewRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping slow SPHINCS+ test in short mode")
+	}
+
+	s, err := NewSimple()
+	if err != nil {
+		t.Fatalf("NewSimple: %v", err)
+	}
+	msg := []uint8("fresh key")
+	sig, err := s.Sign(msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	pk := s.GetPK()
+	if !VerifySimple(msg, sig, &pk) {
+		t.Error("VerifySimple rejected a signature from NewSimple")
+	}
+}
+
+func TestSimpleNilPublicKey(t *testing.T) {
+	var sig [params.SPX_BYTES]uint8
+	if err := VerifySimpleDetailed(nil, sig, nil); !errors.Is(err, cryptoerrors.ErrPublicKeyNil) {
+		t.Errorf("VerifySimpleDetailed(nil pk) = %v, want ErrPublicKeyNil", err)
+	}
+	if _, err := OpenSimple(sig[:], nil); !errors.Is(err, cryptoerrors.ErrPublicKeyNil) {
+		t.Errorf("OpenSimple(nil pk) = %v, want ErrPublicKeyNil", err)
+	}
+	var pk SimplePublicKey
+	if _, err := OpenSimple(sig[:10], &pk); !errors.Is(err, cryptoerrors.ErrInvalidSignatureSize) {
+		t.Errorf("OpenSimple(short) = %v, want ErrInvalidSignatureSize", err)
+	}
+}
@@ -15,6 +15,13 @@ type SphincsPlus256s struct {
 	sk              [params.SPX_SK_BYTES]uint8
 	seed            [CRYPTO_SEEDBYTES]uint8
 	generateOptRand func([]byte) error
+
+	// verifyAfterSign enables the fault countermeasure; see
+	// SetVerifyAfterSign.
+	verifyAfterSign bool
+
+	// workers is the goroutine count for signing; see SetWorkers.
+	workers int
 }
 
 func New() (*SphincsPlus256s, error) {
@@ -29,29 +36,49 @@ func New() (*SphincsPlus256s, error) {
 		return nil, cryptoerrors.ErrSeedGeneration
 	}
 
-	if err := cryptoSignKeypair(pk[:], sk[:], seed); err != nil {
+	if err := cryptoSignKeypair(pk[:], sk[:], seed, false, 0); err != nil {
 		//coverage:ignore
 		//rationale: cryptoSignKeypair only fails if buffers are wrong size, but we use fixed-size arrays
 		return nil, err
 	}
 
-	return &SphincsPlus256s{pk, sk, seed, generateOptrand}, nil
+	return &SphincsPlus256s{pk: pk, sk: sk, seed: seed, generateOptRand: generateOptrand}, nil
 }
 
 func NewSphincsPlus256sFromSeed(seed [CRYPTO_SEEDBYTES]uint8) (*SphincsPlus256s, error) {
+	return NewSphincsPlus256sFromSeedWithWorkers(seed, 0)
+}
+
+// NewSphincsPlus256sFromSeedWithWorkers is [NewSphincsPlus256sFromSeed]
+// computing the top hypertree layer on up to workers goroutines, and
+// returns an instance that signs with the same worker count (see
+// [SphincsPlus256s.SetWorkers]). The keys are identical to those of
+// NewSphincsPlus256sFromSeed.
+func NewSphincsPlus256sFromSeedWithWorkers(seed [CRYPTO_SEEDBYTES]uint8, workers int) (*SphincsPlus256s, error) {
 	var sk [params.SPX_SK_BYTES]uint8
 	var pk [params.SPX_PK_BYTES]uint8
 
-	if err := cryptoSignKeypair(pk[:], sk[:], seed); err != nil {
+	if err := cryptoSignKeypair(pk[:], sk[:], seed, false, workers); err != nil {
 		//coverage:ignore
 		//rationale: cryptoSignKeypair only fails if buffers are wrong size, but we use fixed-size arrays
 		return nil, err
 	}
 
-	return &SphincsPlus256s{pk, sk, seed, generateOptrand}, nil
+	return &SphincsPlus256s{pk: pk, sk: sk, seed: seed, generateOptRand: generateOptrand, workers: workers}, nil
 }
 
 func NewSphincsPlus256sFromHexSeed(hexSeed string) (*SphincsPlus256s, error) {
+	seed, err := decodeHexSeed(hexSeed)
+	if err != nil {
+		return nil, err
+	}
+	return NewSphincsPlus256sFromSeed(seed)
+}
+
+// decodeHexSeed parses a CRYPTO_SEEDBYTES hex seed with an optional 0x
+// prefix.
+func decodeHexSeed(hexSeed string) ([CRYPTO_SEEDBYTES]uint8, error) {
+	var seed [CRYPTO_SEEDBYTES]uint8
 	if strings.HasPrefix(hexSeed, "0x") || strings.HasPrefix(hexSeed, "0X") {
 		hexSeed = hexSeed[2:]
 	}
@@ -59,14 +86,13 @@ func NewSphincsPlus256sFromHexSeed(hexSeed string) (*SphincsPlus256s, error) {
 	if err != nil {
 		// hex.DecodeString's error echoes input characters; return the
 		// sanitized sentinel instead.
-		return nil, cryptoerrors.ErrInvalidHexSeed
+		return seed, cryptoerrors.ErrInvalidHexSeed
 	}
 	if len(unsizedSeed) != CRYPTO_SEEDBYTES {
-		return nil, cryptoerrors.ErrInvalidSeed
+		return seed, cryptoerrors.ErrInvalidSeed
 	}
-	var seed [CRYPTO_SEEDBYTES]uint8
 	copy(seed[:], unsizedSeed)
-	return NewSphincsPlus256sFromSeed(seed)
+	return seed, nil
 }
 
 // SetGenerateOptRand replaces the randomness generator used during signing.
@@ -87,6 +113,48 @@ func (s *SphincsPlus256s) SetGenerateOptRand(generateOptRand func([]byte) error)
 	s.generateOptRand = generateOptRand
 }
 
+// SetVerifyAfterSign enables or disables verify-after-sign, a
+// countermeasure against fault injection during signing. When enabled,
+// every signature s produces (Sign, SignAttached and the crypto.Signer
+// path) is verified under s's own public key before it is returned. A
+// signature that does not verify is wiped and
+// [cryptoerrors.ErrSigningFailed] is returned instead: a faulted
+// SPHINCS+ signature can reveal a WOTS+ or FORS secret for a second,
+// different message, which is enough to forge.
+//
+// The check adds one verification (a small fraction of signing time)
+// and is off by default. SetVerifyAfterSign must not be called
+// concurrently with signing on the same instance.
+func (s *SphincsPlus256s) SetVerifyAfterSign(enabled bool) {
+	s.verifyAfterSign = enabled
+}
+
+// SetWorkers sets how many goroutines signing may use. The FORS trees
+// and every hypertree layer are split into subtrees whose leaves are
+// generated in parallel; with workers below 2 (the default) signing
+// stays on the calling goroutine. Signatures are byte-identical to the
+// serial ones for the same optrand, so the setting only affects speed.
+// A value around runtime.GOMAXPROCS(0) is a reasonable choice.
+//
+// SetWorkers must not be called concurrently with signing on the same
+// instance.
+func (s *SphincsPlus256s) SetWorkers(workers int) {
+	s.workers = workers
+}
+
+// checkSignature finishes the verify-after-sign check for a fresh
+// signature sig over m. If the check is enabled and sig does not
+// verify, sig is wiped and ErrSigningFailed is returned.
+func (s *SphincsPlus256s) checkSignature(sig, m []uint8) error {
+	if !s.verifyAfterSign || cryptoSignVerify(sig, m, s.pk[:], false) {
+		return nil
+	}
+	for i := range sig {
+		sig[i] = 0
+	}
+	return cryptoerrors.ErrSigningFailed
+}
+
 func (s *SphincsPlus256s) GetPK() [params.SPX_PK_BYTES]uint8 {
 	return s.pk
 }
@@ -114,7 +182,14 @@ func (s *SphincsPlus256s) GetHexSeed() string {
 // embedded in the result in the clear. Renamed during
 // TOB-QRLLIB-12 to remove the misleading AEAD-style connotation.
 func (s *SphincsPlus256s) SignAttached(message []uint8) ([]uint8, error) {
-	return cryptoSign(message, s.sk[:], s.generateOptRand)
+	sm, err := cryptoSign(message, s.sk[:], s.generateOptRand, false, s.workers)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkSignature(sm[:params.SPX_BYTES], sm[params.SPX_BYTES:]); err != nil {
+		return nil, err
+	}
+	return sm, nil
 }
 
 // Sign the message, and return a detached signature. SPHINCS+-256s
@@ -122,7 +197,7 @@ func (s *SphincsPlus256s) SignAttached(message []uint8) ([]uint8, error) {
 func (s *SphincsPlus256s) Sign(message []uint8) ([params.SPX_BYTES]uint8, error) {
 	var signature [params.SPX_BYTES]uint8
 
-	sm, err := cryptoSign(message, s.sk[:], s.generateOptRand)
+	sm, err := s.SignAttached(message)
 	if err == nil {
 		copy(signature[:params.SPX_BYTES], sm[:params.SPX_BYTES])
 	}
@@ -152,7 +227,7 @@ func Open(signatureMessage []uint8, pk *[params.SPX_PK_BYTES]uint8) ([]uint8, er
 		return nil, cryptoerrors.ErrInvalidSignatureSize
 	}
 	m := make([]uint8, len(signatureMessage)-params.SPX_BYTES)
-	if !cryptoSignOpen(m, signatureMessage, pk[:]) {
+	if !cryptoSignOpen(m, signatureMessage, pk[:], false) {
 		return nil, cryptoerrors.ErrInvalidSignature
 	}
 	return m, nil
@@ -160,12 +235,25 @@ func Open(signatureMessage []uint8, pk *[params.SPX_PK_BYTES]uint8) ([]uint8, er
 
 // Verify reports whether signature is a valid SPHINCS+ signature over
 // message under pk. Returns false if pk is nil rather than panicking.
-// (TOB-QRLLIB-11)
+// (TOB-QRLLIB-11) Use [VerifyDetailed] to learn why a signature was
+// rejected.
 func Verify(message []uint8, signature [params.SPX_BYTES]uint8, pk *[params.SPX_PK_BYTES]uint8) bool {
+	return VerifyDetailed(message, signature, pk) == nil
+}
+
+// VerifyDetailed is [Verify] returning the reason for a rejection
+// instead of false: [cryptoerrors.ErrPublicKeyNil] if pk is nil and
+// [cryptoerrors.ErrInvalidSignature] if the signature does not verify.
+// SPHINCS+ signatures have no internal encoding that can be rejected
+// before the hypertree root is recomputed, so there is no finer reason.
+func VerifyDetailed(message []uint8, signature [params.SPX_BYTES]uint8, pk *[params.SPX_PK_BYTES]uint8) error {
 	if pk == nil {
-		return false
+		return cryptoerrors.ErrPublicKeyNil
+	}
+	if !cryptoSignVerify(signature[:], message, pk[:], false) {
+		return cryptoerrors.ErrInvalidSignature
 	}
-	return cryptoSignVerify(signature[:], message, pk[:])
+	return nil
 }
 
 // ExtractMessage extracts message from Signature attached with message.
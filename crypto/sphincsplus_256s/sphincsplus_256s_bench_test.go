@@ -1,6 +1,9 @@
 package sphincsplus_256s
 
-import "testing"
+import (
+	"runtime"
+	"testing"
+)
 
 func BenchmarkKeyGeneration(b *testing.B) {
 	b.ResetTimer()
@@ -29,6 +32,24 @@ func BenchmarkSign(b *testing.B) {
 	}
 }
 
+func BenchmarkSignParallel(b *testing.B) {
+	spx, err := New()
+	if err != nil {
+		b.Fatal(err)
+	}
+	spx.SetWorkers(runtime.GOMAXPROCS(0))
+
+	msg := []byte("benchmark message for signing")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := spx.Sign(msg)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func BenchmarkVerify(b *testing.B) {
 	spx, err := New()
 	if err != nil {
@@ -0,0 +1,75 @@
+package sphincsplus_256s
+
+import (
+	"errors"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/crypto/sphincsplus_256s/params"
+)
+
+func isZero(b []uint8) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestVerifyAfterSign(t *testing.T) {
+	var seed [CRYPTO_SEEDBYTES]uint8
+	s, err := NewSphincsPlus256sFromSeed(seed)
+	if err != nil {
+		t.Fatalf("NewSphincsPlus256sFromSeed: %v", err)
+	}
+	s.SetVerifyAfterSign(true)
+	msg := []byte("verify after sign")
+
+	sig, err := s.Sign(msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	pk := s.GetPK()
+	if !Verify(msg, sig, &pk) {
+		t.Fatal("signature does not verify")
+	}
+
+	// A public key that no longer matches the secret key stands in for
+	// a fault: every fresh signature then fails the check.
+	s.pk[0] ^= 1
+
+	sig, err = s.Sign(msg)
+	if !errors.Is(err, cryptoerrors.ErrSigningFailed) {
+		t.Errorf("Sign error = %v, want ErrSigningFailed", err)
+	}
+	if !isZero(sig[:]) {
+		t.Error("Sign returned signature bytes after a failed check")
+	}
+	if sm, err := s.SignAttached(msg); !errors.Is(err, cryptoerrors.ErrSigningFailed) || sm != nil {
+		t.Errorf("SignAttached = %d bytes, %v; want nil, ErrSigningFailed", len(sm), err)
+	}
+	out, err := NewCryptoSigner(s).SignMessage(nil, msg, nil)
+	if !errors.Is(err, cryptoerrors.ErrSigningFailed) || out != nil {
+		t.Errorf("SignMessage = %d bytes, %v; want nil, ErrSigningFailed", len(out), err)
+	}
+
+	s.SetVerifyAfterSign(false)
+	if _, err := s.Sign(msg); err != nil {
+		t.Errorf("Sign with the check disabled: %v", err)
+	}
+}
+
+func TestCheckSignatureWipes(t *testing.T) {
+	s := &SphincsPlus256s{verifyAfterSign: true}
+	sig := make([]uint8, params.SPX_BYTES)
+	for i := range sig {
+		sig[i] = 0xaa
+	}
+	if err := s.checkSignature(sig, []byte("m")); !errors.Is(err, cryptoerrors.ErrSigningFailed) {
+		t.Fatalf("checkSignature error = %v, want ErrSigningFailed", err)
+	}
+	if !isZero(sig) {
+		t.Error("checkSignature left signature bytes in place")
+	}
+}
@@ -10,6 +10,14 @@ const (
 
 const (
 	MaxHeight = 30 // MaxHeight set to 30, as lastNode datatype is uint32 anything more than height 30 will result into overflow
+
+	// MaxMTHeight is the largest total XMSS^MT height, the tallest
+	// RFC 8391 parameter set. Each layer is still bounded by MaxHeight.
+	MaxMTHeight = 60
+
+	// MinMTLayerHeight is the smallest XMSS^MT layer height. Smaller
+	// layers cannot hold a BDS state.
+	MinMTLayerHeight = 4
 )
 
 // SeedSize is the required length in bytes of the caller-supplied seed
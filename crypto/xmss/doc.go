@@ -82,6 +82,23 @@
 //   - SHAKE_128: SHAKE128 based (legacy QRL extension)
 //   - SHAKE_256: SHAKE256 based
 //
+// # XMSS^MT
+//
+// [XMSSMT] is the RFC 8391 multi-tree variant: a hypertree of total
+// height h split into d layers of XMSS trees of height h/d, where each
+// tree's root is signed by a leaf of the tree above. It signs up to 2^h
+// messages (h ≤ [MaxMTHeight], so 2^60) while key generation only
+// builds one tree per layer, and each layer keeps its own BDS state
+// plus the state of its next tree. Keys are created with
+// [InitializeMultiTree] (QRL 48-byte seed) or
+// [InitializeMultiTreeFromExpandedSeed] (RFC 8391 96-byte seed) and
+// verified with [VerifyMT], which takes h and d explicitly because
+// signature sizes do not identify them. The RFC 8391 OIDs are in the
+// [rfc8391] sub-package.
+//
+// Everything in this documentation about index persistence and
+// concurrency applies to XMSSMT unchanged; its index is a uint64.
+//
 // # Thread Safety
 //
 // XMSS is NOT thread-safe. Never call Sign from multiple goroutines on the
@@ -121,6 +138,16 @@
 //	// Only now safe to use
 //	broadcast(signature)
 //
+// # Key Generation
+//
+// InitializeTree builds the whole tree on one goroutine, which takes
+// minutes for tall trees. [InitializeTreeContext] and
+// [InitializeTreeFromExpandedSeedContext] build the same key byte for
+// byte, but generate the WOTS+ leaves on several goroutines. They
+// report progress, stop when their context is cancelled, and can
+// write checkpoints that a later call resumes from after a crash; see
+// [KeyGenOptions].
+//
 // # Index Management
 //
 // The current index can be retrieved and set:
@@ -132,8 +159,7 @@
 //
 // # Recovery and BDS State
 //
-// XMSS uses BDS state to speed up signing. This state is not persisted or
-// serialized by the library. Recovery requires:
+// XMSS uses BDS state to speed up signing. Recovery requires:
 //  1. Securely storing the seed (or extended seed) once, and
 //  2. Persisting the last used index after each signature.
 //
@@ -141,6 +167,19 @@
 // to advance the BDS state to the last used index. This can be O(Δ) in the
 // number of skipped indices, so persist frequently and avoid large gaps.
 //
+// Rebuilding and replaying take minutes for tall trees. To restart
+// instantly, persist [XMSS.MarshalBinary], which encodes the seed, secret
+// key and full BDS state under a MAC keyed from the secret key, and
+// restore it with [XMSS.UnmarshalBinary]. The encoding is as secret as
+// the seed. A snapshot older than the persisted index is stale: restore
+// with [XMSS.UnmarshalBinaryAtLeast] and the persisted index, which
+// rejects it with ErrOTSIndexRewind, then fall back to the seed and
+// SetIndex.
+//
+// The [statestore] sub-package implements this pattern: its SafeSigner
+// persists reserved index ranges through a pluggable StateStore before
+// signing, and resumes past the last reservation after a crash.
+//
 // # Verification
 //
 // Signature verification is stateless and safe:
@@ -149,4 +188,5 @@
 //	valid := xmss.Verify(hashFunc, message, signature, pk)
 //
 // [rfc8391]: https://pkg.go.dev/github.com/theQRL/go-qrllib/crypto/xmss/rfc8391
+// [statestore]: https://pkg.go.dev/github.com/theQRL/go-qrllib/crypto/xmss/statestore
 package xmss
@@ -437,6 +437,53 @@ func TestEdgeCaseVerifyCustomWOTSParam(t *testing.T) {
 	}
 }
 
+// TestVerifyDetailed checks that each rejection reason is reported
+func TestVerifyDetailed(t *testing.T) {
+	seed := make([]byte, 48)
+	xmss, _ := InitializeTree(4, SHAKE_128, seed)
+
+	msg := []byte("test message")
+	sig, err := xmss.Sign(msg)
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	pk := append(xmss.GetRoot(), xmss.GetPKSeed()...)
+	hf := xmss.GetHashFunction()
+
+	if err := VerifyDetailed(hf, msg, sig, pk); err != nil {
+		t.Fatalf("VerifyDetailed(valid) = %v", err)
+	}
+
+	tampered := append([]byte(nil), sig...)
+	tampered[len(tampered)-1] ^= 0x01
+
+	tests := []struct {
+		name string
+		msg  []byte
+		sig  []byte
+		pk   []byte
+		want error
+	}{
+		{"short_signature", msg, sig[:len(sig)-1], pk, cryptoerrors.ErrInvalidSignatureSize},
+		{"oversized_signature", msg, append(append([]byte(nil), sig...), make([]byte, 32*32)...), pk, cryptoerrors.ErrInvalidSignatureSize},
+		// One auth-path node short is height 3, which XMSS does not allow.
+		{"odd_height", msg, sig[:len(sig)-32], pk, cryptoerrors.ErrInvalidHeight},
+		{"short_pk", msg, sig, pk[:16], cryptoerrors.ErrInvalidPublicKey},
+		{"wrong_message", []byte("other"), sig, pk, cryptoerrors.ErrInvalidSignature},
+		{"tampered_signature", msg, tampered, pk, cryptoerrors.ErrInvalidSignature},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := VerifyDetailed(hf, tc.msg, tc.sig, tc.pk); !errors.Is(err, tc.want) {
+				t.Errorf("VerifyDetailed error = %v, want %v", err, tc.want)
+			}
+			if Verify(hf, tc.msg, tc.sig, tc.pk) {
+				t.Error("Verify accepted a signature VerifyDetailed rejects")
+			}
+		})
+	}
+}
+
 // TestEdgeCaseExhaustedTree tests signing when all signatures have been used
 func TestEdgeCaseExhaustedTree(t *testing.T) {
 	seed := make([]byte, 48)
@@ -52,9 +52,22 @@
 // implemented. Calling [NewKeyPair] / [UnmarshalPublicKey] with one
 // of those OIDs returns [ErrUnsupportedParameterSet].
 //
-// QRL's pre-standardisation SHAKE_128 hash variant is not part of
-// RFC 8391 and has no OID; this package will not produce or consume
-// SHAKE_128 keys. Use the parent [xmss] package directly for those.
+// For single-tree XMSS, QRL's SHAKE_128 hash variant has no OID here;
+// this package will not produce or consume SHAKE_128 XMSS keys. Use
+// the parent [xmss] package directly for those.
+//
+// # XMSS^MT
+//
+// The sixteen n=32 XMSS^MT parameter sets of RFC 8391 §5.4,
+// XMSSMT-SHA2_{20/2,20/4,40/2,40/4,40/8,60/3,60/6,60/12}_256 (OIDs
+// 0x00000001–0x00000008) and their SHAKE counterparts (OIDs
+// 0x00000011–0x00000018), are available through [MTParameterSet],
+// [NewMTKeyPair], [MarshalMTPublicKey], [UnmarshalMTPublicKey] and
+// [VerifyMT]. As in RFC 8391 and the reference xmssmt_parse_oid, the
+// SHAKE sets hash with SHAKE128 and so map to [xmss.SHAKE_128]. XMSS
+// and XMSS^MT OIDs are separate registries whose numbers overlap, so
+// they have separate types and functions; the public-key layout is the
+// same.
 //
 // # Bidirectional cross-verify
 //
@@ -0,0 +1,164 @@
+package rfc8391
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/theQRL/go-qrllib/crypto/xmss"
+)
+
+var allMTParameterSets = []MTParameterSet{
+	XMSSMT_SHA2_20_2_256, XMSSMT_SHA2_20_4_256, XMSSMT_SHA2_40_2_256, XMSSMT_SHA2_40_4_256,
+	XMSSMT_SHA2_40_8_256, XMSSMT_SHA2_60_3_256, XMSSMT_SHA2_60_6_256, XMSSMT_SHA2_60_12_256,
+	XMSSMT_SHAKE_20_2_256, XMSSMT_SHAKE_20_4_256, XMSSMT_SHAKE_40_2_256, XMSSMT_SHAKE_40_4_256,
+	XMSSMT_SHAKE_40_8_256, XMSSMT_SHAKE_60_3_256, XMSSMT_SHAKE_60_6_256, XMSSMT_SHAKE_60_12_256,
+}
+
+func TestMTParameterSet_Attributes(t *testing.T) {
+	cases := []struct {
+		p             MTParameterSet
+		name          string
+		height, layer uint8
+		hash          xmss.HashFunction
+	}{
+		{XMSSMT_SHA2_20_2_256, "XMSSMT-SHA2_20/2_256", 20, 2, xmss.SHA2_256},
+		{XMSSMT_SHA2_40_8_256, "XMSSMT-SHA2_40/8_256", 40, 8, xmss.SHA2_256},
+		{XMSSMT_SHA2_60_12_256, "XMSSMT-SHA2_60/12_256", 60, 12, xmss.SHA2_256},
+		{XMSSMT_SHAKE_20_4_256, "XMSSMT-SHAKE_20/4_256", 20, 4, xmss.SHAKE_128},
+		{XMSSMT_SHAKE_60_3_256, "XMSSMT-SHAKE_60/3_256", 60, 3, xmss.SHAKE_128},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.p.String(); got != c.name {
+				t.Errorf("String() = %q; want %q", got, c.name)
+			}
+			h, err := c.p.Height()
+			if err != nil || h != c.height {
+				t.Errorf("Height() = (%d, %v); want %d", h, err, c.height)
+			}
+			d, err := c.p.Layers()
+			if err != nil || d != c.layer {
+				t.Errorf("Layers() = (%d, %v); want %d", d, err, c.layer)
+			}
+			hf, err := c.p.HashFunction()
+			if err != nil || hf != c.hash {
+				t.Errorf("HashFunction() = (%s, %v); want %s", hf, err, c.hash)
+			}
+		})
+	}
+}
+
+func TestMTParameterSet_RejectsUnsupported(t *testing.T) {
+	for _, p := range []MTParameterSet{
+		0x00000000, // not assigned
+		0x00000009, // XMSSMT-SHA2_20/2_512
+		0x00000019, // XMSSMT-SHAKE_20/2_512
+		0xdeadbeef,
+	} {
+		if p.IsSupported() {
+			t.Errorf("MTParameterSet(0x%08x).IsSupported() = true; want false", uint32(p))
+		}
+		if _, err := p.Height(); !errors.Is(err, ErrUnsupportedParameterSet) {
+			t.Errorf("Height(0x%08x) err = %v", uint32(p), err)
+		}
+		if _, err := p.Layers(); !errors.Is(err, ErrUnsupportedParameterSet) {
+			t.Errorf("Layers(0x%08x) err = %v", uint32(p), err)
+		}
+		if _, err := p.HashFunction(); !errors.Is(err, ErrUnsupportedParameterSet) {
+			t.Errorf("HashFunction(0x%08x) err = %v", uint32(p), err)
+		}
+		seed := fixedSeed96(0)
+		if _, err := NewMTKeyPair(p, &seed); !errors.Is(err, ErrUnsupportedParameterSet) {
+			t.Errorf("NewMTKeyPair(0x%08x) err = %v", uint32(p), err)
+		}
+	}
+}
+
+func TestInferMTParameterSet_AllSupported(t *testing.T) {
+	for _, p := range allMTParameterSets {
+		h, _ := p.Height()
+		d, _ := p.Layers()
+		hf, _ := p.HashFunction()
+		got, err := inferMTParameterSet(hf, h, d)
+		if err != nil || got != p {
+			t.Errorf("inferMTParameterSet(%s, %d, %d) = (%s, %v); want %s", hf, h, d, got, err, p)
+		}
+	}
+	// The n=32 SHAKE sets use SHAKE128, so SHAKE256 keys have no OID.
+	if _, err := inferMTParameterSet(xmss.SHAKE_256, 20, 4); !errors.Is(err, ErrUnsupportedParameterSet) {
+		t.Errorf("SHAKE_256: err = %v; want ErrUnsupportedParameterSet", err)
+	}
+	if _, err := inferMTParameterSet(xmss.SHA2_256, 12, 3); !errors.Is(err, ErrUnsupportedParameterSet) {
+		t.Errorf("12/3: err = %v; want ErrUnsupportedParameterSet", err)
+	}
+}
+
+// TestMTRoundTrip uses the 5-high-layer sets, which are the only ones
+// whose key generation is fast enough for a unit test.
+func TestMTRoundTrip(t *testing.T) {
+	for _, p := range []MTParameterSet{XMSSMT_SHA2_20_4_256, XMSSMT_SHAKE_20_4_256} {
+		t.Run(p.String(), func(t *testing.T) {
+			seed := fixedSeed96(0x55)
+			key, err := NewMTKeyPair(p, &seed)
+			if err != nil {
+				t.Fatalf("NewMTKeyPair: %v", err)
+			}
+			rfcPK, err := MarshalMTPublicKey(key)
+			if err != nil {
+				t.Fatalf("MarshalMTPublicKey: %v", err)
+			}
+			gotP, root, pubSeed, err := UnmarshalMTPublicKey(rfcPK)
+			if err != nil || gotP != p {
+				t.Fatalf("UnmarshalMTPublicKey = (%s, %v); want %s", gotP, err, p)
+			}
+			if !bytes.Equal(root[:], key.GetRoot()) || !bytes.Equal(pubSeed[:], key.GetPKSeed()) {
+				t.Error("unmarshalled root || pub_seed differs from the key")
+			}
+
+			msg := []byte("rfc8391 XMSS^MT round-trip")
+			// Cross the first bottom-tree boundary.
+			if err := key.SetIndex(31); err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 2; i++ {
+				sig, err := key.Sign(msg)
+				if err != nil {
+					t.Fatalf("Sign: %v", err)
+				}
+				ok, err := VerifyMT(msg, sig, rfcPK)
+				if err != nil || !ok {
+					t.Fatalf("VerifyMT = (%v, %v); want (true, nil)", ok, err)
+				}
+				sig[len(sig)-1] ^= 0xff
+				if ok, _ := VerifyMT(msg, sig, rfcPK); ok {
+					t.Fatal("VerifyMT accepted a tampered signature")
+				}
+			}
+		})
+	}
+}
+
+func TestUnmarshalMTPublicKey_Rejects(t *testing.T) {
+	if _, _, _, err := UnmarshalMTPublicKey(make([]byte, PublicKeySize-1)); !errors.Is(err, ErrInvalidPublicKeyLength) {
+		t.Errorf("short pk: err = %v; want ErrInvalidPublicKeyLength", err)
+	}
+	pk := make([]byte, PublicKeySize)
+	pk[3] = 0x09 // XMSSMT-SHA2_20/2_512
+	if _, _, _, err := UnmarshalMTPublicKey(pk); !errors.Is(err, ErrUnsupportedParameterSet) {
+		t.Errorf("n=64 OID: err = %v; want ErrUnsupportedParameterSet", err)
+	}
+	if ok, err := VerifyMT([]byte("msg"), []byte("sig"), pk); ok || !errors.Is(err, ErrUnsupportedParameterSet) {
+		t.Errorf("VerifyMT(n=64 OID) = (%v, %v)", ok, err)
+	}
+}
+
+func TestMarshalMTPublicKey_RejectsNonRFCParameterSet(t *testing.T) {
+	key, err := xmss.InitializeMultiTree(8, 2, xmss.SHAKE_256, make([]uint8, xmss.SeedSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MarshalMTPublicKey(key); !errors.Is(err, ErrUnsupportedParameterSet) {
+		t.Errorf("MarshalMTPublicKey(8/2) err = %v; want ErrUnsupportedParameterSet", err)
+	}
+}
@@ -0,0 +1,46 @@
+// Package statestore persists XMSS signer state so that a one-time
+// index is never used twice, including across crashes and power loss.
+//
+// The xmss package leaves persisting the index to the caller: after
+// every Sign, GetIndex must reach durable storage before the signature
+// is released. [SafeSigner] does that for an [xmss.XMSS] key or a
+// legacy XMSSWallet, using any [StateStore]; [FileStore] is a
+// file-backed one.
+//
+// # Index reservation
+//
+// Rather than storing after every signature, SafeSigner stores a
+// reservation: the end of a range of indices it may sign with. A
+// signature is only produced with an index below a reservation that
+// Store has already made durable. After a crash, [NewSafeSigner]
+// resumes at the end of the stored reservation, so indices of the last
+// range that were never used are skipped and none is reused. A larger
+// reservation means fewer fsyncs and more indices lost per crash.
+//
+// # Usage
+//
+//	tree, err := xmss.InitializeTree(height, xmss.SHAKE_256, seed)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer tree.Zeroize()
+//
+//	signer, err := statestore.NewSafeSigner(tree, statestore.NewFileStore(statePath), 16)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	signer.SetLowCapacityWarning(1000, func(remaining uint32) {
+//	    log.Printf("XMSS key has %d signatures left, rotate it", remaining)
+//	})
+//
+//	// The signature is safe to broadcast as soon as Sign returns.
+//	signature, err := signer.Sign(message)
+//
+// On restart, rebuild the key from its seed and call NewSafeSigner with
+// the same store; it advances the key with SetIndex, which is O(Δ) in
+// the skipped indices.
+//
+// A store must only ever be used by one SafeSigner at a time, and
+// restoring a store or its key from a backup reintroduces index reuse
+// exactly as restoring a bare index does.
+package statestore
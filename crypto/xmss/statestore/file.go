@@ -0,0 +1,147 @@
+package statestore
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// FileStore is a [StateStore] backed by a file, which is never
+// overwritten in place. Each Store writes the new record to a
+// write-ahead journal next to the file and fsyncs it, then writes a
+// temporary copy, fsyncs it and renames it over the file, fsyncing
+// the directory after each step. A crash at any point
+// leaves either the journal or the file holding the new record, or
+// both holding the old one, and Load takes the newer of the two.
+//
+// The journal is path+".journal" and the temporary file path+".tmp".
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore returns a FileStore keeping its state at path. The
+// directory must exist; the file is created by the first Store.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (f *FileStore) journalPath() string {
+	return f.path + ".journal"
+}
+
+func (f *FileStore) tmpPath() string {
+	return f.path + ".tmp"
+}
+
+// Load returns the stored state. A journal record that a crash left
+// behind is honoured if it is intact and ahead of the file; a torn
+// journal record is ignored, since the Store that wrote it never
+// returned and so nothing was signed under it.
+func (f *FileStore) Load() (State, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	main, mainErr := readRecord(f.path)
+	journal, journalErr := readRecord(f.journalPath())
+
+	if journalErr == nil {
+		if mainErr != nil {
+			return journal, nil
+		}
+		if !bytes.Equal(main.Key, journal.Key) {
+			return State{}, ErrCorruptState
+		}
+		if journal.Reserved > main.Reserved {
+			return journal, nil
+		}
+		return main, nil
+	}
+	if !errors.Is(journalErr, fs.ErrNotExist) && !errors.Is(journalErr, ErrCorruptState) {
+		return State{}, journalErr
+	}
+
+	if errors.Is(mainErr, fs.ErrNotExist) {
+		return State{}, ErrNoState
+	}
+	return main, mainErr
+}
+
+// Store durably records s, as described on [FileStore].
+func (f *FileStore) Store(s State) error {
+	rec, err := encodeState(s)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	dir := filepath.Dir(f.path)
+	if err := writeFileSync(f.journalPath(), rec); err != nil {
+		return err
+	}
+	if err := syncDir(dir); err != nil {
+		return err
+	}
+	if err := writeFileSync(f.tmpPath(), rec); err != nil {
+		return err
+	}
+	if err := os.Rename(f.tmpPath(), f.path); err != nil {
+		return err
+	}
+	if err := syncDir(dir); err != nil {
+		return err
+	}
+	// The record is durable in the file now. A journal that fails to
+	// go away holds the same record and is harmless to Load.
+	_ = os.Remove(f.journalPath())
+	return nil
+}
+
+func readRecord(path string) (State, error) {
+	rec, err := os.ReadFile(path)
+	if err != nil {
+		return State{}, err
+	}
+	return decodeState(rec)
+}
+
+func writeFileSync(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// syncDir makes a rename or create in dir durable. Windows cannot
+// fsync a directory, and NTFS journals the metadata itself.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		//coverage:ignore
+		//rationale: CI runs on Linux only
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := d.Sync(); err != nil {
+		_ = d.Close()
+		return err
+	}
+	return d.Close()
+}
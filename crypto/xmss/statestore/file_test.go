@@ -0,0 +1,150 @@
+package statestore
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestFileStore(t *testing.T) *FileStore {
+	t.Helper()
+	return NewFileStore(filepath.Join(t.TempDir(), "xmss.state"))
+}
+
+func writeRecord(t *testing.T, path string, s State) {
+	t.Helper()
+	rec, err := encodeState(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, rec, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func rec(t *testing.T, key []byte, reserved uint32) []byte {
+	t.Helper()
+	r, err := encodeState(State{Key: key, Reserved: reserved})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestEncodeDecodeState(t *testing.T) {
+	s := State{Key: []byte("root"), Reserved: 0x01020304}
+	rec, err := encodeState(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := decodeState(rec)
+	if err != nil || !bytes.Equal(got.Key, s.Key) || got.Reserved != s.Reserved {
+		t.Fatalf("decodeState = (%+v, %v); want %+v", got, err, s)
+	}
+
+	for i := range rec {
+		flipped := append([]byte(nil), rec...)
+		flipped[i] ^= 1
+		if _, err := decodeState(flipped); !errors.Is(err, ErrCorruptState) {
+			t.Errorf("bit flip at byte %d: err = %v; want ErrCorruptState", i, err)
+		}
+	}
+	for n := 0; n < len(rec); n++ {
+		if _, err := decodeState(rec[:n]); !errors.Is(err, ErrCorruptState) {
+			t.Errorf("record torn at %d bytes: err = %v; want ErrCorruptState", n, err)
+		}
+	}
+
+	if _, err := encodeState(State{Key: make([]byte, maxKeySize+1)}); !errors.Is(err, errKeyTooLong) {
+		t.Errorf("long key: err = %v; want errKeyTooLong", err)
+	}
+}
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	f := newTestFileStore(t)
+	if _, err := f.Load(); !errors.Is(err, ErrNoState) {
+		t.Fatalf("empty store: err = %v; want ErrNoState", err)
+	}
+	for _, reserved := range []uint32{0, 16, 32} {
+		if err := f.Store(State{Key: []byte("root"), Reserved: reserved}); err != nil {
+			t.Fatal(err)
+		}
+		got, err := f.Load()
+		if err != nil || got.Reserved != reserved || string(got.Key) != "root" {
+			t.Fatalf("Load = (%+v, %v); want Reserved %d", got, err, reserved)
+		}
+	}
+	for _, p := range []string{f.journalPath(), f.tmpPath()} {
+		if _, err := os.Stat(p); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s left behind after Store: %v", filepath.Base(p), err)
+		}
+	}
+}
+
+// TestFileStoreJournalRecovery covers the states a crash inside Store
+// can leave on disk.
+func TestFileStoreJournalRecovery(t *testing.T) {
+	key := []byte("root")
+	tests := []struct {
+		name          string
+		main, journal []byte // nil: absent
+		want          uint32
+		wantErr       error
+	}{
+		{"crash before rename, first store", nil, rec(t, key, 16), 16, nil},
+		{"crash before rename", rec(t, key, 16), rec(t, key, 32), 32, nil},
+		{"crash before journal removal", rec(t, key, 32), rec(t, key, 32), 32, nil},
+		{"stale journal", rec(t, key, 48), rec(t, key, 32), 48, nil},
+		{"torn journal", rec(t, key, 16), rec(t, key, 32)[:20], 16, nil},
+		{"torn journal, first store", nil, rec(t, key, 16)[:20], 0, ErrNoState},
+		{"corrupt file, intact journal", []byte("garbage"), rec(t, key, 32), 32, nil},
+		{"corrupt file and journal", []byte("garbage"), []byte("garbage"), 0, ErrCorruptState},
+		{"corrupt file", []byte("garbage"), nil, 0, ErrCorruptState},
+		{"journal for another key", rec(t, key, 16), rec(t, []byte("other"), 32), 0, ErrCorruptState},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestFileStore(t)
+			if tt.main != nil {
+				if err := os.WriteFile(f.path, tt.main, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.journal != nil {
+				if err := os.WriteFile(f.journalPath(), tt.journal, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := f.Load()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Load err = %v; want %v", err, tt.wantErr)
+			}
+			if err == nil && got.Reserved != tt.want {
+				t.Errorf("Load Reserved = %d; want %d", got.Reserved, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileStoreStoreAfterRecovery(t *testing.T) {
+	f := newTestFileStore(t)
+	writeRecord(t, f.path, State{Key: []byte("root"), Reserved: 16})
+	writeRecord(t, f.journalPath(), State{Key: []byte("root"), Reserved: 32})
+
+	if err := f.Store(State{Key: []byte("root"), Reserved: 48}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := f.Load()
+	if err != nil || got.Reserved != 48 {
+		t.Fatalf("Load = (%+v, %v); want Reserved 48", got, err)
+	}
+}
+
+func TestFileStoreStoreFailsWithoutDirectory(t *testing.T) {
+	f := NewFileStore(filepath.Join(t.TempDir(), "missing", "xmss.state"))
+	if err := f.Store(State{Key: []byte("root")}); err == nil {
+		t.Fatal("Store into a missing directory succeeded")
+	}
+}
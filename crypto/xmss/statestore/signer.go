@@ -0,0 +1,189 @@
+package statestore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/crypto/xmss"
+)
+
+// ErrInvalidReservation is returned by [NewSafeSigner] for a
+// reservation size of zero.
+var ErrInvalidReservation = errors.New("statestore: reservation size must be at least 1")
+
+// Signer is the stateful XMSS signer a [SafeSigner] wraps. Both
+// [xmss.XMSS] and the legacy wallet's XMSSWallet implement it.
+type Signer interface {
+	Sign(message []uint8) ([]uint8, error)
+	GetIndex() uint32
+	SetIndex(newIndex uint32) error
+	GetHeight() xmss.Height
+	GetRoot() []uint8
+}
+
+// SafeSigner signs with an XMSS key whose index is kept in a
+// [StateStore]. Before an index is used, a range of indices starting
+// at it is reserved by a durable Store; Sign does not produce a
+// signature until that Store has returned. After a crash the signer is
+// reopened at the end of the last reservation, skipping any indices
+// of that range that were never used, so an index is never signed
+// with twice.
+//
+// The reservation size trades durability writes against indices lost
+// on a crash: 1 stores before every signature and loses none.
+//
+// SafeSigner is safe for concurrent use. The wrapped Signer must not
+// be used directly while a SafeSigner owns it.
+type SafeSigner struct {
+	mu       sync.Mutex
+	signer   Signer
+	store    StateStore
+	key      []byte
+	capacity uint32
+	reserve  uint32
+
+	// next is the next index to sign with and reserved the end of the
+	// durable reservation; next <= reserved <= capacity.
+	next     uint32
+	reserved uint32
+
+	lowCapacityThreshold uint32
+	onLowCapacity        func(remaining uint32)
+}
+
+// NewSafeSigner wraps signer with the state in store, reserving
+// reserve indices per Store.
+//
+// If store holds state for signer's key, signer is advanced with
+// SetIndex to the end of the stored reservation; SetIndex is O(Δ) in
+// the skipped indices, as described in the xmss package. If store is
+// empty, the current index of signer is stored. State for a different
+// key returns [ErrKeyMismatch], and a stored reservation behind the
+// signer's index is moved up to it rather than trusted.
+func NewSafeSigner(signer Signer, store StateStore, reserve uint32) (*SafeSigner, error) {
+	if reserve == 0 {
+		return nil, ErrInvalidReservation
+	}
+
+	s := &SafeSigner{
+		signer:   signer,
+		store:    store,
+		key:      signer.GetRoot(),
+		capacity: uint32(1) << signer.GetHeight(),
+		reserve:  reserve,
+	}
+
+	state, err := store.Load()
+	switch {
+	case errors.Is(err, ErrNoState):
+		state = State{Key: s.key, Reserved: signer.GetIndex()}
+		if err := store.Store(state); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case !bytes.Equal(state.Key, s.key):
+		return nil, ErrKeyMismatch
+	}
+
+	if state.Reserved > s.capacity {
+		return nil, ErrCorruptState
+	}
+	if idx := signer.GetIndex(); idx > state.Reserved {
+		state.Reserved = idx
+		if err := store.Store(state); err != nil {
+			return nil, err
+		}
+	}
+
+	s.next = state.Reserved
+	s.reserved = state.Reserved
+	if err := s.syncSigner(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// syncSigner moves the wrapped signer up to next. At capacity there is
+// no index to move to and Sign refuses to sign instead.
+func (s *SafeSigner) syncSigner() error {
+	if s.next < s.capacity && s.signer.GetIndex() < s.next {
+		return s.signer.SetIndex(s.next)
+	}
+	return nil
+}
+
+// Sign reserves the next index durably if it is not already reserved,
+// then signs message with it. If the reservation cannot be stored, no
+// signature is produced and the store's error is returned. As with
+// [xmss.XMSS.Sign], an index is consumed even if signing then fails.
+//
+// Once the key is exhausted Sign returns an error wrapping
+// [cryptoerrors.ErrSigningFailed] and [cryptoerrors.ErrOTSIndexTooHigh].
+func (s *SafeSigner) Sign(message []uint8) ([]uint8, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// An index the signer reached on its own is never handed out again.
+	if idx := s.signer.GetIndex(); idx > s.next {
+		s.next = idx
+	}
+	if s.next >= s.capacity {
+		return nil, fmt.Errorf("%w: %w", cryptoerrors.ErrSigningFailed, cryptoerrors.ErrOTSIndexTooHigh)
+	}
+
+	if s.next >= s.reserved {
+		reserved := s.capacity
+		if s.capacity-s.next > s.reserve {
+			reserved = s.next + s.reserve
+		}
+		if err := s.store.Store(State{Key: s.key, Reserved: reserved}); err != nil {
+			return nil, err
+		}
+		s.reserved = reserved
+	}
+
+	if err := s.syncSigner(); err != nil {
+		return nil, err
+	}
+	sig, err := s.signer.Sign(message)
+	s.next++
+	if err != nil {
+		return nil, err
+	}
+
+	if s.onLowCapacity != nil && s.capacity-s.next <= s.lowCapacityThreshold {
+		s.onLowCapacity(s.capacity - s.next)
+	}
+	return sig, nil
+}
+
+// Index returns the next index Sign will use.
+func (s *SafeSigner) Index() uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.next
+}
+
+// Remaining returns how many signatures the key can still make.
+// Indices reserved but unused at a crash are lost, so after a restart
+// this can drop by up to the reservation size.
+func (s *SafeSigner) Remaining() uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.capacity - s.next
+}
+
+// SetLowCapacityWarning arranges for fn to be called, with the
+// remaining signature count, after every signature that leaves
+// threshold or fewer remaining. fn runs with the SafeSigner locked and
+// must not call back into it. A nil fn disables the warning.
+func (s *SafeSigner) SetLowCapacityWarning(threshold uint32, fn func(remaining uint32)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lowCapacityThreshold = threshold
+	s.onLowCapacity = fn
+}
@@ -0,0 +1,331 @@
+package statestore
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/theQRL/go-qrllib/common"
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/crypto/xmss"
+	legacyxmss "github.com/theQRL/go-qrllib/legacywallet/xmss"
+)
+
+var testSeed = make([]uint8, xmss.SeedSize)
+
+func newTestTree(t *testing.T) *xmss.XMSS {
+	t.Helper()
+	tree, err := xmss.InitializeTree(4, xmss.SHAKE_256, testSeed)
+	if err != nil {
+		t.Fatalf("InitializeTree failed: %v", err)
+	}
+	return tree
+}
+
+func treePK(s Signer) []uint8 {
+	return append(s.GetRoot(), s.(*xmss.XMSS).GetPKSeed()...)
+}
+
+// memStore is an in-memory StateStore that can be made to fail.
+type memStore struct {
+	mu     sync.Mutex
+	state  *State
+	stores int
+	fail   error
+}
+
+func (m *memStore) Load() (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state == nil {
+		return State{}, ErrNoState
+	}
+	return State{Key: append([]byte(nil), m.state.Key...), Reserved: m.state.Reserved}, nil
+}
+
+func (m *memStore) Store(s State) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.fail != nil {
+		return m.fail
+	}
+	m.stores++
+	m.state = &State{Key: append([]byte(nil), s.Key...), Reserved: s.Reserved}
+	return nil
+}
+
+func TestNewSafeSignerRejectsZeroReservation(t *testing.T) {
+	if _, err := NewSafeSigner(newTestTree(t), &memStore{}, 0); !errors.Is(err, ErrInvalidReservation) {
+		t.Errorf("err = %v; want ErrInvalidReservation", err)
+	}
+}
+
+func TestSafeSignerReservesBeforeSigning(t *testing.T) {
+	store := &memStore{}
+	s, err := NewSafeSigner(newTestTree(t), store, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := uint32(0); i < 6; i++ {
+		if _, err := s.Sign([]uint8("m")); err != nil {
+			t.Fatal(err)
+		}
+		state, _ := store.Load()
+		if state.Reserved <= i {
+			t.Fatalf("signed with index %d but only %d reserved", i, state.Reserved)
+		}
+	}
+	// Initial record plus the reservations at indices 0 and 4.
+	if store.stores != 3 {
+		t.Errorf("stores = %d; want 3", store.stores)
+	}
+	if s.Index() != 6 || s.Remaining() != 10 {
+		t.Errorf("Index, Remaining = %d, %d; want 6, 10", s.Index(), s.Remaining())
+	}
+}
+
+func TestSafeSignerStoreFailureWithholdsSignature(t *testing.T) {
+	store := &memStore{}
+	s, err := NewSafeSigner(newTestTree(t), store, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errDisk := errors.New("disk full")
+	store.fail = errDisk
+	if sig, err := s.Sign([]uint8("m")); !errors.Is(err, errDisk) || sig != nil {
+		t.Fatalf("Sign = (%d bytes, %v); want (nil, disk full)", len(sig), err)
+	}
+	if s.Index() != 0 {
+		t.Errorf("failed Sign consumed index; Index = %d", s.Index())
+	}
+
+	store.fail = nil
+	if _, err := s.Sign([]uint8("m")); err != nil {
+		t.Fatalf("Sign after store recovered: %v", err)
+	}
+}
+
+// TestSafeSignerCrashNeverReusesIndex simulates crashes by dropping the
+// signer and rebuilding the key from its seed, and checks that no
+// index signs twice and every signature verifies.
+func TestSafeSignerCrashNeverReusesIndex(t *testing.T) {
+	store := NewFileStore(t.TempDir() + "/xmss.state")
+	used := make(map[uint32]bool)
+	msg := []uint8("crash")
+
+	for run := 0; run < 5; run++ {
+		tree := newTestTree(t)
+		s, err := NewSafeSigner(tree, store, 3)
+		if err != nil {
+			t.Fatalf("run %d: NewSafeSigner: %v", run, err)
+		}
+		for i := 0; i < 2; i++ {
+			idx := s.Index()
+			sig, err := s.Sign(msg)
+			if err != nil {
+				t.Fatalf("run %d: Sign: %v", run, err)
+			}
+			if used[idx] {
+				t.Fatalf("run %d: index %d reused after restart", run, idx)
+			}
+			used[idx] = true
+			if !xmss.Verify(xmss.SHAKE_256, msg, sig, treePK(tree)) {
+				t.Fatalf("run %d: signature at %d did not verify", run, idx)
+			}
+		}
+	}
+}
+
+// TestStaleSnapshotAfterStoreAdvanced restores a MarshalBinary snapshot
+// taken before a SafeSigner moved the durable reservation on.
+func TestStaleSnapshotAfterStoreAdvanced(t *testing.T) {
+	store := NewFileStore(t.TempDir() + "/xmss.state")
+	tree := newTestTree(t)
+	snapshot, err := tree.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewSafeSigner(tree, store, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := s.Sign([]uint8("m")); err != nil {
+			t.Fatalf("Sign %d: %v", i, err)
+		}
+	}
+	state, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var restored xmss.XMSS
+	if err := restored.UnmarshalBinaryAtLeast(snapshot, state.Reserved); !errors.Is(err, cryptoerrors.ErrOTSIndexRewind) {
+		t.Fatalf("stale snapshot: err = %v; want ErrOTSIndexRewind", err)
+	}
+	if _, err := restored.MarshalBinary(); !errors.Is(err, cryptoerrors.ErrSecretKeyNil) {
+		t.Fatal("rejected snapshot was restored")
+	}
+
+	if err := tree.SetIndex(state.Reserved); err != nil {
+		t.Fatal(err)
+	}
+	current, err := tree.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := restored.UnmarshalBinaryAtLeast(current, state.Reserved); err != nil {
+		t.Fatalf("snapshot at the reservation: %v", err)
+	}
+	if restored.GetIndex() != state.Reserved {
+		t.Errorf("restored index = %d; want %d", restored.GetIndex(), state.Reserved)
+	}
+}
+
+func TestSafeSignerKeyMismatch(t *testing.T) {
+	store := &memStore{state: &State{Key: []byte("another key's root"), Reserved: 3}}
+	if _, err := NewSafeSigner(newTestTree(t), store, 1); !errors.Is(err, ErrKeyMismatch) {
+		t.Errorf("err = %v; want ErrKeyMismatch", err)
+	}
+}
+
+func TestSafeSignerRejectsReservationPastCapacity(t *testing.T) {
+	tree := newTestTree(t)
+	store := &memStore{state: &State{Key: tree.GetRoot(), Reserved: 17}}
+	if _, err := NewSafeSigner(tree, store, 1); !errors.Is(err, ErrCorruptState) {
+		t.Errorf("err = %v; want ErrCorruptState", err)
+	}
+}
+
+func TestSafeSignerSignerAheadOfStore(t *testing.T) {
+	tree := newTestTree(t)
+	store := &memStore{state: &State{Key: tree.GetRoot(), Reserved: 2}}
+	if err := tree.SetIndex(5); err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSafeSigner(tree, store, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Index() != 5 {
+		t.Errorf("Index = %d; want 5", s.Index())
+	}
+	if state, _ := store.Load(); state.Reserved != 5 {
+		t.Errorf("stored Reserved = %d; want 5", state.Reserved)
+	}
+}
+
+func TestSafeSignerExhaustionAndLowCapacity(t *testing.T) {
+	store := &memStore{}
+	s, err := NewSafeSigner(newTestTree(t), store, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var warnings []uint32
+	s.SetLowCapacityWarning(2, func(remaining uint32) {
+		warnings = append(warnings, remaining)
+	})
+
+	for i := 0; i < 16; i++ {
+		if _, err := s.Sign([]uint8("m")); err != nil {
+			t.Fatalf("Sign %d: %v", i, err)
+		}
+	}
+	if want := []uint32{2, 1, 0}; !slices.Equal(warnings, want) {
+		t.Errorf("warnings = %v; want %v", warnings, want)
+	}
+	if state, _ := store.Load(); state.Reserved != 16 {
+		t.Errorf("reservation ran past the key: Reserved = %d", state.Reserved)
+	}
+
+	_, err = s.Sign([]uint8("m"))
+	if !errors.Is(err, cryptoerrors.ErrSigningFailed) || !errors.Is(err, cryptoerrors.ErrOTSIndexTooHigh) {
+		t.Errorf("Sign on exhausted key: err = %v", err)
+	}
+	if s.Remaining() != 0 {
+		t.Errorf("Remaining = %d; want 0", s.Remaining())
+	}
+
+	// Reopening an exhausted key must not fail, and must not sign.
+	s, err = NewSafeSigner(newTestTree(t), store, 5)
+	if err != nil {
+		t.Fatalf("NewSafeSigner on exhausted state: %v", err)
+	}
+	if _, err := s.Sign([]uint8("m")); !errors.Is(err, cryptoerrors.ErrOTSIndexTooHigh) {
+		t.Errorf("Sign after reopening exhausted key: err = %v", err)
+	}
+}
+
+func TestSafeSignerConcurrentSign(t *testing.T) {
+	tree := newTestTree(t)
+	s, err := NewSafeSigner(tree, &memStore{}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigs := make([][]uint8, 16)
+	var wg sync.WaitGroup
+	for i := range sigs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sig, err := s.Sign([]uint8("m"))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			sigs[i] = sig
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[uint32]bool)
+	for _, sig := range sigs {
+		idx := uint32(sig[0])<<24 | uint32(sig[1])<<16 | uint32(sig[2])<<8 | uint32(sig[3])
+		if seen[idx] {
+			t.Fatalf("index %d signed twice", idx)
+		}
+		seen[idx] = true
+	}
+}
+
+func TestSafeSignerLegacyWallet(t *testing.T) {
+	var seed [legacyxmss.SeedSize]uint8
+	newWallet := func() *legacyxmss.XMSSWallet {
+		w, err := legacyxmss.NewWalletFromSeed(seed, 4, xmss.SHAKE_128, common.SHA256_2X)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return w
+	}
+
+	store := &memStore{}
+	w := newWallet()
+	s, err := NewSafeSigner(w, store, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := s.Sign([]uint8("m"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// After a restart the wallet resumes past the reserved range.
+	w = newWallet()
+	s, err = NewSafeSigner(w, store, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.GetIndex() != 4 {
+		t.Fatalf("wallet index after restart = %d; want 4", w.GetIndex())
+	}
+	second, err := s.Sign([]uint8("m"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(first[:4], second[:4]) {
+		t.Error("legacy wallet reused an index after restart")
+	}
+}
@@ -0,0 +1,95 @@
+package statestore
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+)
+
+// ErrNoState is returned by [StateStore.Load] when nothing has been
+// stored yet.
+var ErrNoState = errors.New("statestore: no state stored")
+
+// ErrCorruptState is returned by [StateStore.Load] when the stored
+// state exists but cannot be read back intact. The index that was in
+// use is then unknown, and the key must not be signed with until the
+// state has been recovered by hand.
+var ErrCorruptState = errors.New("statestore: stored state is corrupt")
+
+// ErrKeyMismatch is returned when stored state belongs to a different
+// XMSS key than the one it is being used with.
+var ErrKeyMismatch = errors.New("statestore: stored state belongs to a different key")
+
+// State is the persistent part of an XMSS signer's state.
+type State struct {
+	// Key identifies the XMSS key the state belongs to. [SafeSigner]
+	// uses the tree root.
+	Key []byte
+
+	// Reserved is the first one-time index not yet reserved. Every
+	// index below it may already have signed something and must never
+	// be used again.
+	Reserved uint32
+}
+
+// StateStore persists [State] for a single XMSS key.
+//
+// Store must not return until the state is durable, so that it
+// survives a crash or power loss immediately afterwards. Load must
+// return the most recent state a completed Store wrote, [ErrNoState]
+// if there is none, or [ErrCorruptState] if it cannot tell.
+//
+// Implementations are used by one signer at a time; two processes
+// sharing a store will reuse indices.
+type StateStore interface {
+	Load() (State, error)
+	Store(State) error
+}
+
+// Records are
+//
+//	magic(4) || version(1) || reserved(4) || keyLen(1) || key || SHA-256(preceding)
+//
+// so a torn or bit-flipped write is detected rather than read as a
+// smaller index.
+const (
+	recordMagic    = "QXST"
+	recordVersion  = 1
+	recordOverhead = len(recordMagic) + 1 + 4 + 1 + sha256.Size
+	maxKeySize     = 255
+)
+
+var errKeyTooLong = errors.New("statestore: key longer than 255 bytes")
+
+func encodeState(s State) ([]byte, error) {
+	if len(s.Key) > maxKeySize {
+		return nil, errKeyTooLong
+	}
+	out := make([]byte, 0, recordOverhead+len(s.Key))
+	out = append(out, recordMagic...)
+	out = append(out, recordVersion)
+	out = binary.BigEndian.AppendUint32(out, s.Reserved)
+	out = append(out, byte(len(s.Key)))
+	out = append(out, s.Key...)
+	sum := sha256.Sum256(out)
+	return append(out, sum[:]...), nil
+}
+
+func decodeState(rec []byte) (State, error) {
+	if len(rec) < recordOverhead || string(rec[:4]) != recordMagic || rec[4] != recordVersion {
+		return State{}, ErrCorruptState
+	}
+	keyLen := int(rec[9])
+	if len(rec) != recordOverhead+keyLen {
+		return State{}, ErrCorruptState
+	}
+	body := rec[:len(rec)-sha256.Size]
+	sum := sha256.Sum256(body)
+	if subtle.ConstantTimeCompare(sum[:], rec[len(body):]) != 1 {
+		return State{}, ErrCorruptState
+	}
+	key := make([]byte, keyLen)
+	copy(key, rec[10:10+keyLen])
+	return State{Key: key, Reserved: binary.BigEndian.Uint32(rec[5:9])}, nil
+}
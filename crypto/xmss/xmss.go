@@ -14,6 +14,10 @@ type XMSS struct {
 	sk           []uint8
 
 	bdsState *BDSState
+
+	// verifyAfterSign enables the fault countermeasure; see
+	// SetVerifyAfterSign.
+	verifyAfterSign bool
 }
 
 // InitializeTree creates a new XMSS tree with the specified parameters,
@@ -125,12 +129,12 @@ func InitializeTree(h Height, hashFunction HashFunction, seed []uint8) (*XMSS, e
 	copy(storedSeed, seed)
 
 	return &XMSS{
-		xmssParams,
-		hashFunction,
-		uint8(height),
-		storedSeed,
-		sk,
-		bdsState,
+		xmssParams:   xmssParams,
+		hashFunction: hashFunction,
+		height:       uint8(height),
+		seed:         storedSeed,
+		sk:           sk,
+		bdsState:     bdsState,
 	}, nil
 }
 
@@ -211,12 +215,12 @@ func InitializeTreeFromExpandedSeed(h Height, hashFunction HashFunction, expande
 	copy(storedSeed, expandedSeed[:])
 
 	return &XMSS{
-		xmssParams,
-		hashFunction,
-		uint8(height),
-		storedSeed,
-		sk,
-		bdsState,
+		xmssParams:   xmssParams,
+		hashFunction: hashFunction,
+		height:       uint8(height),
+		seed:         storedSeed,
+		sk:           sk,
+		bdsState:     bdsState,
 	}, nil
 }
 
@@ -274,7 +278,36 @@ func (x *XMSS) Sign(message []uint8) ([]uint8, error) {
 		return nil, fmt.Errorf("%w: %w", cryptoerrors.ErrSigningFailed, err)
 	}
 
-	return xmssFastSignMessage(x.hashFunction, x.xmssParams, x.sk, x.bdsState, message)
+	sig, err := xmssFastSignMessage(x.hashFunction, x.xmssParams, x.sk, x.bdsState, message)
+	if err != nil {
+		return nil, err
+	}
+	if x.verifyAfterSign {
+		pk := append(x.GetRoot(), x.GetPKSeed()...)
+		if verifyDetailed(x.hashFunction, message, sig, pk, x.xmssParams.wotsParams.w) != nil {
+			for i := range sig {
+				sig[i] = 0
+			}
+			return nil, cryptoerrors.ErrSigningFailed
+		}
+	}
+	return sig, nil
+}
+
+// SetVerifyAfterSign enables or disables verify-after-sign, a
+// countermeasure against fault injection during signing. When enabled,
+// every signature Sign produces is verified under x's own public key
+// before it is returned; a signature that does not verify is wiped and
+// [cryptoerrors.ErrSigningFailed] is returned instead. A faulted WOTS+
+// signature can leak chain values that let an attacker forge for the
+// same one-time key.
+//
+// The one-time index is consumed even when the check fails, so the
+// caller must still persist the advanced index. The check is off by
+// default and SetVerifyAfterSign must not be called concurrently with
+// Sign.
+func (x *XMSS) SetVerifyAfterSign(enabled bool) {
+	x.verifyAfterSign = enabled
 }
 
 // Zeroize clears sensitive key material from memory.
@@ -286,39 +319,66 @@ func (x *XMSS) Zeroize() {
 	for i := range x.seed {
 		x.seed[i] = 0
 	}
-	if x.bdsState != nil {
-		for i := range x.bdsState.stack {
-			x.bdsState.stack[i] = 0
-		}
-		for i := range x.bdsState.auth {
-			x.bdsState.auth[i] = 0
-		}
-		for i := range x.bdsState.keep {
-			x.bdsState.keep[i] = 0
-		}
-		for i := range x.bdsState.retain {
-			x.bdsState.retain[i] = 0
-		}
-		for _, th := range x.bdsState.treeHash {
-			for i := range th.node {
-				th.node[i] = 0
-			}
+	zeroizeBDSState(x.bdsState)
+}
+
+// zeroizeBDSState clears the tree nodes held by a BDS state, which are
+// derived from the secret seed.
+func zeroizeBDSState(bdsState *BDSState) {
+	if bdsState == nil {
+		return
+	}
+	for i := range bdsState.stack {
+		bdsState.stack[i] = 0
+	}
+	for i := range bdsState.auth {
+		bdsState.auth[i] = 0
+	}
+	for i := range bdsState.keep {
+		bdsState.keep[i] = 0
+	}
+	for i := range bdsState.retain {
+		bdsState.retain[i] = 0
+	}
+	for _, th := range bdsState.treeHash {
+		for i := range th.node {
+			th.node[i] = 0
 		}
 	}
 }
 
+// Verify reports whether signature is a valid XMSS signature over
+// message under pk (root || pubSeed). The tree height is taken from the
+// signature size. Use [VerifyDetailed] to learn why a signature was
+// rejected.
 func Verify(hashFunction HashFunction, message, signature []uint8, pk []uint8) (result bool) {
-	return VerifyWithCustomWOTSParamW(hashFunction, message, signature, pk, WOTSParamW)
+	return verifyDetailed(hashFunction, message, signature, pk, WOTSParamW) == nil
+}
+
+// VerifyDetailed is [Verify] returning the reason for a rejection
+// instead of false. It returns nil for a valid signature,
+// [cryptoerrors.ErrInvalidSignatureSize] if the signature length does
+// not correspond to a tree height, [cryptoerrors.ErrInvalidHeight] or
+// [cryptoerrors.ErrInvalidBDSParams] if that height is unusable,
+// [cryptoerrors.ErrInvalidPublicKey] if pk is too short, and
+// [cryptoerrors.ErrInvalidSignature] if the recomputed root does not
+// match pk.
+func VerifyDetailed(hashFunction HashFunction, message, signature []uint8, pk []uint8) error {
+	return verifyDetailed(hashFunction, message, signature, pk, WOTSParamW)
 }
 
 func VerifyWithCustomWOTSParamW(hashFunction HashFunction, message, signature []uint8, pk []uint8, wotsParamW uint32) (result bool) {
+	return verifyDetailed(hashFunction, message, signature, pk, wotsParamW) == nil
+}
+
+func verifyDetailed(hashFunction HashFunction, message, signature []uint8, pk []uint8, wotsParamW uint32) error {
 	// Validate wotsParamW before calling NewWOTSParams to avoid panic on unsupported values.
 	// Valid WOTS w values are powers of 2 where log2(w) ∈ {2, 4, 8}.
 	switch wotsParamW {
 	case 4, 16, 256:
 		// valid
 	default:
-		return false
+		return cryptoerrors.ErrUnsupportedParameterSet
 	}
 	wotsParam := NewWOTSParams(WOTSParamN, wotsParamW)
 	signatureBaseSize := calculateSignatureBaseSize(wotsParam.keySize)
@@ -327,23 +387,23 @@ func VerifyWithCustomWOTSParamW(hashFunction HashFunction, message, signature []
 
 	// Check for undersized signatures
 	if sigSize < signatureBaseSize {
-		return false
+		return cryptoerrors.ErrInvalidSignatureSize
 	}
 
 	// Check signature size alignment (must be 4 + n*32 for some n)
 	if (sigSize-4)%32 != 0 {
-		return false
+		return cryptoerrors.ErrInvalidSignatureSize
 	}
 
 	// Check for oversized signatures
 	if sigSize > signatureBaseSize+uint32(MaxHeight)*32 {
-		return false
+		return cryptoerrors.ErrInvalidSignatureSize
 	}
 
 	// Get height from signature size - returns error for invalid sizes
 	height, err := GetHeightFromSigSize(sigSize, wotsParamW)
 	if err != nil {
-		return false
+		return err
 	}
 
 	k := WOTSParamK
@@ -351,8 +411,8 @@ func VerifyWithCustomWOTSParamW(hashFunction HashFunction, message, signature []
 	n := WOTSParamN
 
 	if k >= height.ToUInt32() || (height.ToUInt32()-k)%2 == 1 {
-		// Invalid BDS traversal parameters - return false instead of panicking
-		return false
+		// Invalid BDS traversal parameters - reject instead of panicking
+		return cryptoerrors.ErrInvalidBDSParams
 	}
 
 	params := NewXMSSParams(n, height.ToUInt32(), w, k)
@@ -0,0 +1,292 @@
+package xmss
+
+import (
+	"bytes"
+	"crypto/sha3"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/misc"
+)
+
+// The binary encoding of an XMSS key is
+//
+//	magic(4) || version(1) || hashFunction(1) || height(1) || seedLen(1) || seed ||
+//	sk(132) || BDS state || MAC(32)
+//
+// where the BDS state is
+//
+//	stackOffset(4) || stack((h+1)*n) || stackLevels(h+1) || auth(h*n) ||
+//	keep((h/2)*n) || (h-k) × [h(4) || nextIdx(4) || stackUsage(4) || completed(1) || node(n)] ||
+//	retain((2^k-k-1)*n) || nextLeaf(4)
+//
+// with big-endian integers. Every field's length follows from the
+// height, so there are no length prefixes to check beyond seedLen.
+const (
+	binaryMagic   = "XBDS"
+	binaryVersion = 1
+	binaryMACSize = 32
+	binaryHeader  = len(binaryMagic) + 4
+
+	// binaryMACLabel separates the state MAC from every other use of
+	// the secret key material.
+	binaryMACLabel = "QRL XMSS state MAC v1"
+)
+
+// MarshalBinary encodes the whole key, including the BDS traversal
+// state, so that [XMSS.UnmarshalBinary] can resume signing at the
+// current index without rebuilding the tree or replaying SetIndex.
+//
+// The encoding contains the seed and secret key and must be stored as
+// carefully as the seed itself. It ends in a MAC keyed with SK_SEED ||
+// SK_PRF. The MAC rejects corrupted files and states spliced together
+// from different keys. It cannot protect against someone who can read
+// the file, since the key is in it.
+//
+// The encoding is a snapshot: signing after MarshalBinary makes it
+// stale, and resuming from a stale snapshot reuses indices. Persist a
+// fresh encoding before releasing each signature, exactly as the index
+// would be, or keep the index in durable storage alongside it.
+func (x *XMSS) MarshalBinary() ([]byte, error) {
+	if x.xmssParams == nil || x.bdsState == nil {
+		return nil, cryptoerrors.ErrSecretKeyNil
+	}
+	params := x.xmssParams
+	h, n, k := params.h, params.n, params.k
+	bds := x.bdsState
+
+	out := make([]byte, 0, binarySize(h, n, k, uint32(len(x.seed))))
+	out = append(out, binaryMagic...)
+	out = append(out, binaryVersion, uint8(x.hashFunction), x.height, uint8(len(x.seed)))
+	out = append(out, x.seed...)
+	out = append(out, x.sk...)
+
+	out = binary.BigEndian.AppendUint32(out, bds.stackOffset)
+	out = append(out, bds.stack...)
+	out = append(out, bds.stackLevels...)
+	out = append(out, bds.auth...)
+	out = append(out, bds.keep...)
+	for _, th := range bds.treeHash {
+		out = binary.BigEndian.AppendUint32(out, th.h)
+		out = binary.BigEndian.AppendUint32(out, th.nextIdx)
+		out = binary.BigEndian.AppendUint32(out, th.stackUsage)
+		out = append(out, th.completed)
+		out = append(out, th.node...)
+	}
+	out = append(out, bds.retain...)
+	out = binary.BigEndian.AppendUint32(out, bds.nextLeaf)
+
+	mac := binaryMAC(x.sk, out)
+	return append(out, mac[:]...), nil
+}
+
+// UnmarshalBinary restores a key encoded by [XMSS.MarshalBinary]. It
+// returns [cryptoerrors.ErrInvalidEncoding] if data is malformed, fails
+// the MAC, or holds a seed that does not derive its secret key.
+//
+// If x already holds a key, data must be a state of that same key at
+// or after its current index; an older state returns
+// [cryptoerrors.ErrOTSIndexRewind] and another key's state
+// [cryptoerrors.ErrInvalidEncoding]. x is left unchanged on error.
+//
+// A key restored into a fresh XMSS cannot tell whether data is stale.
+// When the index is also kept in durable storage, restore with
+// [XMSS.UnmarshalBinaryAtLeast] instead.
+func (x *XMSS) UnmarshalBinary(data []byte) error {
+	return x.UnmarshalBinaryAtLeast(data, 0)
+}
+
+// UnmarshalBinaryAtLeast is [XMSS.UnmarshalBinary] for a snapshot that
+// must not be older than minIndex, the next unused index according to
+// durable storage (for example statestore.State.Reserved). A snapshot
+// whose index is below minIndex returns [cryptoerrors.ErrOTSIndexRewind],
+// so a stale file restored into a fresh XMSS cannot reuse indices.
+//
+// A snapshot at or past minIndex is restored as is; call SetIndex
+// afterwards if signing must resume further along.
+func (x *XMSS) UnmarshalBinaryAtLeast(data []byte, minIndex uint32) error {
+	restored, err := unmarshalXMSS(data)
+	if err != nil {
+		return err
+	}
+	if restored.GetIndex() < minIndex {
+		restored.Zeroize()
+		return cryptoerrors.ErrOTSIndexRewind
+	}
+
+	if x.sk != nil {
+		if !bytes.Equal(x.sk[offsetPubSeed:], restored.sk[offsetPubSeed:]) {
+			restored.Zeroize()
+			return fmt.Errorf("%w: state belongs to a different key", cryptoerrors.ErrInvalidEncoding)
+		}
+		if restored.GetIndex() < x.GetIndex() {
+			restored.Zeroize()
+			return cryptoerrors.ErrOTSIndexRewind
+		}
+		x.Zeroize()
+	}
+
+	restored.verifyAfterSign = x.verifyAfterSign
+	*x = *restored
+	return nil
+}
+
+func unmarshalXMSS(data []byte) (*XMSS, error) {
+	if len(data) < binaryHeader || string(data[:len(binaryMagic)]) != binaryMagic {
+		return nil, cryptoerrors.ErrInvalidEncoding
+	}
+	if data[4] != binaryVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", cryptoerrors.ErrInvalidEncoding, data[4])
+	}
+	hashFunction := HashFunction(data[5])
+	if !hashFunction.IsValid() {
+		return nil, cryptoerrors.ErrInvalidHashFunction
+	}
+	height := Height(data[6])
+	if !height.IsValid() || uint32(height) <= WOTSParamK {
+		return nil, cryptoerrors.ErrInvalidHeight
+	}
+	seedLen := uint32(data[7])
+	if seedLen != SeedSize && seedLen != 96 {
+		return nil, cryptoerrors.ErrInvalidEncoding
+	}
+
+	params := NewXMSSParams(WOTSParamN, uint32(height), WOTSParamW, WOTSParamK)
+	h, n, k := params.h, params.n, params.k
+	if uint32(len(data)) != binarySize(h, n, k, seedLen) {
+		return nil, cryptoerrors.ErrInvalidEncoding
+	}
+
+	body, tag := data[:len(data)-binaryMACSize], data[len(data)-binaryMACSize:]
+	r := binaryReader{buf: body[binaryHeader:]}
+	seed := make([]uint8, seedLen)
+	sk := make([]uint8, 132)
+	r.read(seed)
+	r.read(sk)
+
+	// The MAC is keyed from sk, so check it before trusting anything
+	// else in the encoding.
+	mac := binaryMAC(sk, body)
+	if subtle.ConstantTimeCompare(mac[:], tag) != 1 {
+		return nil, fmt.Errorf("%w: state authentication failed", cryptoerrors.ErrInvalidEncoding)
+	}
+
+	x := &XMSS{
+		xmssParams:   params,
+		hashFunction: hashFunction,
+		height:       uint8(height),
+		seed:         seed,
+		sk:           sk,
+		bdsState:     NewBDSState(h, n, k),
+	}
+	bds := x.bdsState
+	bds.stackOffset = r.readUint32()
+	r.read(bds.stack)
+	r.read(bds.stackLevels)
+	r.read(bds.auth)
+	r.read(bds.keep)
+	for _, th := range bds.treeHash {
+		th.h = r.readUint32()
+		th.nextIdx = r.readUint32()
+		th.stackUsage = r.readUint32()
+		th.completed = r.readByte()
+		r.read(th.node)
+	}
+	r.read(bds.retain)
+	bds.nextLeaf = r.readUint32()
+
+	if err := x.checkBinaryState(); err != nil {
+		x.Zeroize()
+		return nil, err
+	}
+	return x, nil
+}
+
+// checkBinaryState rejects a restored key whose seed does not derive
+// its secret key, or whose BDS state would index outside its buffers.
+// The MAC already rules out corruption; this keeps a well-formed but
+// crafted encoding from panicking the signer.
+func (x *XMSS) checkBinaryState() error {
+	var expanded [96]uint8
+	if len(x.seed) == SeedSize {
+		misc.SHAKE256(expanded[:], x.seed)
+	} else {
+		copy(expanded[:], x.seed)
+	}
+	match := subtle.ConstantTimeCompare(expanded[:], x.sk[offsetSKSeed:offsetRoot])
+	for i := range expanded {
+		expanded[i] = 0
+	}
+	if match != 1 {
+		return fmt.Errorf("%w: seed does not match secret key", cryptoerrors.ErrInvalidEncoding)
+	}
+
+	h := x.xmssParams.h
+	if x.GetIndex() > 1<<h {
+		return cryptoerrors.ErrInvalidEncoding
+	}
+	bds := x.bdsState
+	if bds.stackOffset > h+1 {
+		return cryptoerrors.ErrInvalidEncoding
+	}
+	for _, level := range bds.stackLevels {
+		if uint32(level) > h {
+			return cryptoerrors.ErrInvalidEncoding
+		}
+	}
+	usage := uint32(0)
+	for _, th := range bds.treeHash {
+		if th.h >= h-x.xmssParams.k || th.completed > 1 || th.stackUsage > bds.stackOffset {
+			return cryptoerrors.ErrInvalidEncoding
+		}
+		usage += th.stackUsage
+	}
+	if usage > bds.stackOffset {
+		return cryptoerrors.ErrInvalidEncoding
+	}
+	return nil
+}
+
+// binarySize returns the length of the encoding of a height-h key with
+// a seedLen-byte seed.
+func binarySize(h, n, k, seedLen uint32) uint32 {
+	bds := 4 + (h+1)*n + (h + 1) + h*n + (h>>1)*n + (h-k)*(13+n) + ((1<<k)-k-1)*n + 4
+	return uint32(binaryHeader) + seedLen + 132 + bds + binaryMACSize
+}
+
+// binaryMAC returns SHAKE256(label || SK_SEED || SK_PRF || body).
+func binaryMAC(sk, body []byte) [binaryMACSize]byte {
+	var mac [binaryMACSize]byte
+	hasher := sha3.NewSHAKE256()
+	_, _ = hasher.Write([]byte(binaryMACLabel))
+	_, _ = hasher.Write(sk[offsetSKSeed:offsetPubSeed])
+	_, _ = hasher.Write(body)
+	_, _ = hasher.Read(mac[:]) // ShakeHash.Read never returns an error
+	return mac
+}
+
+// binaryReader reads fields from an encoding whose length has already
+// been checked, so it never runs short.
+type binaryReader struct {
+	buf []byte
+}
+
+// read fills dst from the encoding.
+func (r *binaryReader) read(dst []byte) {
+	copy(dst, r.buf[:len(dst)])
+	r.buf = r.buf[len(dst):]
+}
+
+func (r *binaryReader) readByte() byte {
+	b := r.buf[0]
+	r.buf = r.buf[1:]
+	return b
+}
+
+func (r *binaryReader) readUint32() uint32 {
+	v := binary.BigEndian.Uint32(r.buf[:4])
+	r.buf = r.buf[4:]
+	return v
+}
@@ -0,0 +1,217 @@
+package xmss
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+func mustMarshal(t *testing.T, x *XMSS) []byte {
+	t.Helper()
+	data, err := x.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	return data
+}
+
+// TestMarshalBinaryResumesSigning checks that a restored key signs the
+// rest of the tree exactly as the original does, at several points in
+// the BDS schedule.
+func TestMarshalBinaryResumesSigning(t *testing.T) {
+	msg := []uint8("resume")
+	for _, at := range []uint32{0, 1, 5, 7, 8, 13} {
+		orig := newTestXMSS(t, 6)
+		if err := orig.SetIndex(at); err != nil {
+			t.Fatal(err)
+		}
+		data := mustMarshal(t, orig)
+		if got := uint32(len(data)); got != binarySize(6, WOTSParamN, WOTSParamK, SeedSize) {
+			t.Fatalf("encoding is %d bytes, want %d", got, binarySize(6, WOTSParamN, WOTSParamK, SeedSize))
+		}
+
+		var restored XMSS
+		if err := restored.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary at %d failed: %v", at, err)
+		}
+		if restored.GetIndex() != at || !bytes.Equal(restored.GetSeed(), orig.GetSeed()) ||
+			restored.GetHashFunction() != SHAKE_128 || restored.GetHeight() != 6 {
+			t.Fatalf("restored key at %d does not match the original", at)
+		}
+
+		for i := at; i < 1<<6; i++ {
+			want, err := orig.Sign(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := restored.Sign(msg)
+			if err != nil {
+				t.Fatalf("restored Sign at %d failed: %v", i, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("restored from %d: signature at %d differs from the original", at, i)
+			}
+		}
+		if _, err := restored.Sign(msg); !errors.Is(err, cryptoerrors.ErrOTSIndexTooHigh) {
+			t.Errorf("restored key signed past exhaustion: %v", err)
+		}
+	}
+}
+
+func TestMarshalBinaryExpandedSeed(t *testing.T) {
+	var expanded [96]uint8
+	for i := range expanded {
+		expanded[i] = uint8(i)
+	}
+	orig, err := InitializeTreeFromExpandedSeed(4, SHA2_256, &expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := orig.Sign([]uint8("m")); err != nil {
+		t.Fatal(err)
+	}
+
+	var restored XMSS
+	if err := restored.UnmarshalBinary(mustMarshal(t, orig)); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	sig, err := restored.Sign([]uint8("m"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk := append(orig.GetRoot(), orig.GetPKSeed()...)
+	if !Verify(SHA2_256, []uint8("m"), sig, pk) {
+		t.Error("signature from restored key did not verify")
+	}
+}
+
+func TestUnmarshalBinaryRejectsTampering(t *testing.T) {
+	x := newTestXMSS(t, 4)
+	data := mustMarshal(t, x)
+
+	for i := range data {
+		tampered := append([]byte(nil), data...)
+		tampered[i] ^= 0x01
+		var y XMSS
+		if err := y.UnmarshalBinary(tampered); err == nil {
+			t.Fatalf("flipped bit in byte %d was accepted", i)
+		}
+		if y.sk != nil {
+			t.Fatalf("failed UnmarshalBinary modified the receiver")
+		}
+	}
+
+	for _, n := range []int{0, 3, binaryHeader, len(data) - 1} {
+		var y XMSS
+		if err := y.UnmarshalBinary(data[:n]); !errors.Is(err, cryptoerrors.ErrInvalidEncoding) {
+			t.Errorf("truncated to %d bytes: got %v, want ErrInvalidEncoding", n, err)
+		}
+	}
+	var y XMSS
+	if err := y.UnmarshalBinary(append(data, 0)); !errors.Is(err, cryptoerrors.ErrInvalidEncoding) {
+		t.Errorf("trailing byte: got %v, want ErrInvalidEncoding", err)
+	}
+}
+
+// TestUnmarshalBinaryRejectsForgedState re-MACs encodings, as someone
+// holding the secret key could, to reach the checks behind the MAC.
+func TestUnmarshalBinaryRejectsForgedState(t *testing.T) {
+	x := newTestXMSS(t, 4)
+	data := mustMarshal(t, x)
+	reMAC := func(d []byte) []byte {
+		body := d[:len(d)-binaryMACSize]
+		mac := binaryMAC(body[binaryHeader+SeedSize:], body)
+		return append(append([]byte(nil), body...), mac[:]...)
+	}
+	skStart := binaryHeader + SeedSize
+	bdsStart := skStart + 132
+
+	tests := []struct {
+		name   string
+		modify func(d []byte)
+		want   error
+	}{
+		{"version", func(d []byte) { d[4] = 2 }, cryptoerrors.ErrInvalidEncoding},
+		{"hash function", func(d []byte) { d[5] = 99 }, cryptoerrors.ErrInvalidHashFunction},
+		{"height", func(d []byte) { d[6] = 5 }, cryptoerrors.ErrInvalidHeight},
+		{"seed length", func(d []byte) { d[7] = 47 }, cryptoerrors.ErrInvalidEncoding},
+		{"seed", func(d []byte) { d[binaryHeader] ^= 1 }, cryptoerrors.ErrInvalidEncoding},
+		{"index", func(d []byte) { d[skStart] = 0xff }, cryptoerrors.ErrInvalidEncoding},
+		{"stack offset", func(d []byte) { d[bdsStart+3] = 6 }, cryptoerrors.ErrInvalidEncoding},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := append([]byte(nil), data...)
+			tt.modify(d)
+			var y XMSS
+			if err := y.UnmarshalBinary(reMAC(d)); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalBinaryIntoLiveKey(t *testing.T) {
+	x := newTestXMSS(t, 4)
+	older := mustMarshal(t, x)
+	for i := 0; i < 3; i++ {
+		if _, err := x.Sign([]uint8("m")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	current := mustMarshal(t, x)
+
+	if err := x.UnmarshalBinary(older); !errors.Is(err, cryptoerrors.ErrOTSIndexRewind) {
+		t.Errorf("stale state: got %v, want ErrOTSIndexRewind", err)
+	}
+	if x.GetIndex() != 3 {
+		t.Errorf("rejected stale state moved the index to %d", x.GetIndex())
+	}
+
+	other, err := InitializeTree(4, SHAKE_128, bytes.Repeat([]uint8{1}, SeedSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.UnmarshalBinary(current); !errors.Is(err, cryptoerrors.ErrInvalidEncoding) {
+		t.Errorf("another key's state: got %v, want ErrInvalidEncoding", err)
+	}
+
+	x.SetVerifyAfterSign(true)
+	if err := x.UnmarshalBinary(current); err != nil {
+		t.Fatalf("reloading the current state failed: %v", err)
+	}
+	if !x.verifyAfterSign {
+		t.Error("UnmarshalBinary cleared verify-after-sign")
+	}
+}
+
+func TestUnmarshalBinaryAtLeast(t *testing.T) {
+	x := newTestXMSS(t, 4)
+	if err := x.SetIndex(5); err != nil {
+		t.Fatal(err)
+	}
+	data := mustMarshal(t, x)
+
+	var y XMSS
+	if err := y.UnmarshalBinaryAtLeast(data, 6); !errors.Is(err, cryptoerrors.ErrOTSIndexRewind) {
+		t.Errorf("snapshot behind minIndex: got %v, want ErrOTSIndexRewind", err)
+	}
+	if y.sk != nil {
+		t.Fatal("failed UnmarshalBinaryAtLeast modified the receiver")
+	}
+	if err := y.UnmarshalBinaryAtLeast(data, 5); err != nil {
+		t.Fatalf("snapshot at minIndex: %v", err)
+	}
+	if y.GetIndex() != 5 {
+		t.Errorf("restored index = %d, want 5", y.GetIndex())
+	}
+}
+
+func TestMarshalBinaryZeroValue(t *testing.T) {
+	var x XMSS
+	if _, err := x.MarshalBinary(); !errors.Is(err, cryptoerrors.ErrSecretKeyNil) {
+		t.Errorf("got %v, want ErrSecretKeyNil", err)
+	}
+}
@@ -180,15 +180,16 @@ func xmssFastSignMessage(hashFunction HashFunction, params *XMSSParams, sk []uin
 func treeHashSetup(hashFunction HashFunction, node []uint8, index uint32, bdsState *BDSState, skSeed []uint8, xmssParams *XMSSParams, pubSeed []uint8, addr []uint32) {
 	n := xmssParams.n
 	h := xmssParams.h
-	k := xmssParams.k
 
 	// Defense-in-depth (TOB-QRLLIB-2): both public constructors
 	// (InitializeTree, XMSSFastGenKeyPair) now validate h ∈ [2, MaxHeight]
 	// before reaching here. This guard exists as a tripwire against any
 	// future regression that removes one of those upstream checks, since
 	// h >= 32 would overflow (1 << h) below and silently produce a
-	// zero-rooted tree rather than a controlled failure.
-	if h < 2 || h > uint32(MaxHeight) || h&1 == 1 {
+	// zero-rooted tree rather than a controlled failure. Odd h is
+	// allowed: it is the layer height of XMSS^MT sets such as
+	// XMSSMT-SHA2_20/4_256, validated by validateMTParams.
+	if h < 2 || h > uint32(MaxHeight) {
 		//coverage:ignore
 		//rationale: upstream constructors enforce this; tripwire only.
 		panic("xmss: treeHashSetup reached with invalid height; upstream validation was bypassed")
@@ -196,7 +197,6 @@ func treeHashSetup(hashFunction HashFunction, node []uint8, index uint32, bdsSta
 
 	var otsAddr [8]uint32
 	var lTreeAddr [8]uint32
-	var nodeAddr [8]uint32
 
 	copy(otsAddr[:3], addr[:3])
 	misc.SetType(&otsAddr, 0)
@@ -204,71 +204,116 @@ func treeHashSetup(hashFunction HashFunction, node []uint8, index uint32, bdsSta
 	copy(lTreeAddr[:3], addr[:3])
 	misc.SetType(&lTreeAddr, 1)
 
-	copy(nodeAddr[:3], addr[:3])
-	misc.SetType(&nodeAddr, 2)
+	b := newTreeHashBuilder(hashFunction, index, bdsState, xmssParams, pubSeed, addr)
+	leaf := make([]uint8, n)
+	for lastNode := index + (1 << h); index < lastNode; index++ {
+		misc.SetLTreeAddr(&lTreeAddr, index)
+		misc.SetOTSAddr(&otsAddr, index)
 
-	lastNode := index + (1 << h)
+		genLeafWOTS(hashFunction, leaf, skSeed, xmssParams, pubSeed, &lTreeAddr, &otsAddr)
+		b.push(leaf)
+	}
 
-	bound := h - k
-	stack := make([]uint8, (h+1)*n)
-	stackLevels := make([]uint32, h+1)
-	stackOffset := uint32(0)
-	nodeH := uint32(0)
+	copy(node[:n], b.stack[:n])
+}
+
+// treeHashBuilder is the merge half of treeHashSetup: it takes the
+// leaves of a tree in order, folds them into the root on a stack, and
+// records the authentication path and the treehash and retain nodes
+// the BDS state starts from along the way. Leaf generation is left to
+// the caller so that it can run in parallel; see treeHashSetupContext.
+type treeHashBuilder struct {
+	hashFunction HashFunction
+	params       *XMSSParams
+	bdsState     *BDSState
+	pubSeed      []uint8
+	nodeAddr     [8]uint32
+
+	// index is the address of the next leaf and i the number of
+	// leaves pushed so far.
+	index uint32
+	i     uint32
+
+	stack       []uint8
+	stackLevels []uint32
+	stackOffset uint32
+}
 
-	for i := uint32(0); i < bound; i++ {
+func newTreeHashBuilder(hashFunction HashFunction, index uint32, bdsState *BDSState, xmssParams *XMSSParams, pubSeed []uint8, addr []uint32) *treeHashBuilder {
+	h := xmssParams.h
+	b := &treeHashBuilder{
+		hashFunction: hashFunction,
+		params:       xmssParams,
+		bdsState:     bdsState,
+		pubSeed:      pubSeed,
+		index:        index,
+		stack:        make([]uint8, (h+1)*xmssParams.n),
+		stackLevels:  make([]uint32, h+1),
+	}
+	copy(b.nodeAddr[:3], addr[:3])
+	misc.SetType(&b.nodeAddr, 2)
+
+	for i := uint32(0); i < h-xmssParams.k; i++ {
 		bdsState.treeHash[i].h = i
 		bdsState.treeHash[i].completed = 1
 		bdsState.treeHash[i].stackUsage = 0
 	}
+	return b
+}
 
-	i := uint32(0)
-	for ; index < lastNode; index++ {
-		misc.SetLTreeAddr(&lTreeAddr, index)
-		misc.SetOTSAddr(&otsAddr, index)
-
-		genLeafWOTS(hashFunction, stack[stackOffset*n:stackOffset*n+n], skSeed, xmssParams, pubSeed, &lTreeAddr, &otsAddr)
-		stackLevels[stackOffset] = 0
-		stackOffset++
-		// Faithful-port quirk (matches xmss-reference xmss_fast.c): at
-		// i==3 this reads the slot ONE ABOVE the just-pushed leaf —
-		// uninitialised in C, zero bytes in Go — and the value is then
-		// overwritten in the same iteration by the (i>>nodeH)==3 branch
-		// in the merge loop below. Kept byte-for-byte to preserve
-		// reference equivalence; do not "fix" without re-running the
-		// bidirectional cross-verify.
-		if h-k > 0 && i == 3 {
-			copy(bdsState.treeHash[0].node, stack[stackOffset*n:stackOffset*n+n])
-		}
-		for stackOffset > 1 && stackLevels[stackOffset-1] == stackLevels[stackOffset-2] {
-			nodeH = stackLevels[stackOffset-1]
-			if (i >> nodeH) == 1 {
-				authStart := nodeH * n
+// push adds the next leaf.
+func (b *treeHashBuilder) push(leaf []uint8) {
+	n := b.params.n
+	h := b.params.h
+	k := b.params.k
+	bdsState := b.bdsState
+	stack := b.stack
+	stackLevels := b.stackLevels
+	i := b.i
+
+	copy(stack[b.stackOffset*n:b.stackOffset*n+n], leaf[:n])
+	stackLevels[b.stackOffset] = 0
+	b.stackOffset++
+	stackOffset := b.stackOffset
+	// Faithful-port quirk (matches xmss-reference xmss_fast.c): at
+	// i==3 this reads the slot ONE ABOVE the just-pushed leaf —
+	// uninitialised in C, zero bytes in Go — and the value is then
+	// overwritten in the same iteration by the (i>>nodeH)==3 branch
+	// in the merge loop below. Kept byte-for-byte to preserve
+	// reference equivalence; do not "fix" without re-running the
+	// bidirectional cross-verify.
+	if h-k > 0 && i == 3 {
+		copy(bdsState.treeHash[0].node, stack[stackOffset*n:stackOffset*n+n])
+	}
+	for stackOffset > 1 && stackLevels[stackOffset-1] == stackLevels[stackOffset-2] {
+		nodeH := stackLevels[stackOffset-1]
+		if (i >> nodeH) == 1 {
+			authStart := nodeH * n
+			stackStart := (stackOffset - 1) * n
+			copy(bdsState.auth[authStart:authStart+n], stack[stackStart:stackStart+n])
+		} else {
+			if (nodeH < h-k) && ((i >> nodeH) == 3) {
 				stackStart := (stackOffset - 1) * n
-				copy(bdsState.auth[authStart:authStart+n], stack[stackStart:stackStart+n])
-			} else {
-				if (nodeH < h-k) && ((i >> nodeH) == 3) {
-					stackStart := (stackOffset - 1) * n
-					copy(bdsState.treeHash[nodeH].node, stack[stackStart:stackStart+n])
-				} else if nodeH >= h-k {
-					//memcpy(state->retain + ((1 << (h - 1 - nodeh)) + nodeh - h + (((i >> nodeh) - 3) >> 1)) * n,
-					//	stack + (stackoffset - 1) * n, n);
-					retainStart := ((1 << (h - 1 - nodeH)) + nodeH - h + (((i >> nodeH) - 3) >> 1)) * n
-					stackStart := (stackOffset - 1) * n
-					copy(bdsState.retain[retainStart:retainStart+n], stack[stackStart:stackStart+n])
-				}
+				copy(bdsState.treeHash[nodeH].node, stack[stackStart:stackStart+n])
+			} else if nodeH >= h-k {
+				//memcpy(state->retain + ((1 << (h - 1 - nodeh)) + nodeh - h + (((i >> nodeh) - 3) >> 1)) * n,
+				//	stack + (stackoffset - 1) * n, n);
+				retainStart := ((1 << (h - 1 - nodeH)) + nodeH - h + (((i >> nodeH) - 3) >> 1)) * n
+				stackStart := (stackOffset - 1) * n
+				copy(bdsState.retain[retainStart:retainStart+n], stack[stackStart:stackStart+n])
 			}
-			misc.SetTreeHeight(&nodeAddr, stackLevels[stackOffset-1])
-			misc.SetTreeIndex(&nodeAddr, index>>(stackLevels[stackOffset-1]+1))
-			stackStart := (stackOffset - 2) * n
-			hashH(hashFunction, stack[stackStart:stackStart+n], stack[stackStart:stackStart+2*n], pubSeed,
-				&nodeAddr, n)
-			stackLevels[stackOffset-2]++
-			stackOffset--
 		}
-		i++
-	}
-
-	copy(node[:n], stack[:n])
+		misc.SetTreeHeight(&b.nodeAddr, stackLevels[stackOffset-1])
+		misc.SetTreeIndex(&b.nodeAddr, b.index>>(stackLevels[stackOffset-1]+1))
+		stackStart := (stackOffset - 2) * n
+		hashH(b.hashFunction, stack[stackStart:stackStart+n], stack[stackStart:stackStart+2*n], b.pubSeed,
+			&b.nodeAddr, n)
+		stackLevels[stackOffset-2]++
+		stackOffset--
+	}
+	b.stackOffset = stackOffset
+	b.index++
+	b.i++
 }
 
 func genLeafWOTS(hashFunction HashFunction, leaf, skSeed []uint8, xmssParams *XMSSParams, pubSeed []uint8, lTreeAddr, otsAddr *[8]uint32) {
@@ -620,7 +665,7 @@ func wotsSign(hashFunction HashFunction, sig, msg, sk []uint8, params *WOTSParam
 	}
 }
 
-func verifySig(hashFunction HashFunction, wotsParams *WOTSParams, msg, sigMsg, pk []uint8, h uint32) bool {
+func verifySig(hashFunction HashFunction, wotsParams *WOTSParams, msg, sigMsg, pk []uint8, h uint32) error {
 
 	sigMsgOffset := uint32(0)
 
@@ -628,7 +673,7 @@ func verifySig(hashFunction HashFunction, wotsParams *WOTSParams, msg, sigMsg, p
 
 	// Validate public key length (must be at least 2*n bytes: root + pubSeed)
 	if uint32(len(pk)) < 2*n {
-		return false
+		return cryptoerrors.ErrInvalidPublicKey
 	}
 
 	wotsPK := make([]uint8, wotsParams.keySize)
@@ -669,7 +714,7 @@ func verifySig(hashFunction HashFunction, wotsParams *WOTSParams, msg, sigMsg, p
 	if err != nil {
 		//coverage:ignore
 		//rationale: hashKey is always 3*n bytes (constructed above), so hMsg will not return an error
-		return false
+		return err
 	}
 	//-----------------------
 	// Verify signature
@@ -691,11 +736,11 @@ func verifySig(hashFunction HashFunction, wotsParams *WOTSParams, msg, sigMsg, p
 
 	for i := uint32(0); i < n; i++ {
 		if root[i] != pk[i] {
-			return false
+			return cryptoerrors.ErrInvalidSignature
 		}
 	}
 
-	return true
+	return nil
 }
 
 func validateAuthPath(hashFunc HashFunction, root, leaf []uint8, leafIdx uint32, authpath []uint8, n, h uint32, pub_seed []uint8, addr *[8]uint32) {
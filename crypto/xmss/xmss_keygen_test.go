@@ -0,0 +1,249 @@
+package xmss
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/misc"
+)
+
+// setKeyGenBatch shrinks the keygen batch for the rest of the test.
+func setKeyGenBatch(t *testing.T, batch uint32) {
+	t.Helper()
+	saved := keyGenBatch
+	keyGenBatch = batch
+	t.Cleanup(func() { keyGenBatch = saved })
+}
+
+// requireSameKey compares two keys including their full BDS state.
+func requireSameKey(t *testing.T, got, want *XMSS) {
+	t.Helper()
+	if !bytes.Equal(mustMarshal(t, got), mustMarshal(t, want)) {
+		t.Fatal("key differs from InitializeTree")
+	}
+}
+
+func TestInitializeTreeContextMatchesSerial(t *testing.T) {
+	heights := []Height{4, 6}
+	if !testing.Short() {
+		heights = append(heights, 10)
+	}
+	for _, h := range heights {
+		want := newTestXMSS(t, h)
+		for _, workers := range []int{0, 1, 3, 8} {
+			got, err := InitializeTreeContext(context.Background(), h, SHAKE_128, testSeed, &KeyGenOptions{Workers: workers})
+			if err != nil {
+				t.Fatalf("h=%d workers=%d: %v", h, workers, err)
+			}
+			requireSameKey(t, got, want)
+		}
+	}
+
+	var expanded [96]uint8
+	for i := range expanded {
+		expanded[i] = uint8(i)
+	}
+	want, err := InitializeTreeFromExpandedSeed(6, SHA2_256, &expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := InitializeTreeFromExpandedSeedContext(context.Background(), 6, SHA2_256, &expanded, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireSameKey(t, got, want)
+}
+
+func TestInitializeTreeContextProgress(t *testing.T) {
+	setKeyGenBatch(t, 16)
+	var reports []uint32
+	_, err := InitializeTreeContext(context.Background(), 6, SHAKE_128, testSeed, &KeyGenOptions{
+		Progress: func(done, total uint32) {
+			if total != 64 {
+				t.Errorf("total = %d; want 64", total)
+			}
+			reports = append(reports, done)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint32{16, 32, 48, 64}; !slices.Equal(reports, want) {
+		t.Errorf("progress reports = %v; want %v", reports, want)
+	}
+}
+
+// TestInitializeTreeContextResumeEveryLeaf resumes from a checkpoint at
+// every leaf of a small tree, including those before the i==3 quirk in
+// treeHashBuilder.push.
+func TestInitializeTreeContextResumeEveryLeaf(t *testing.T) {
+	setKeyGenBatch(t, 1)
+	want := newTestXMSS(t, 4)
+
+	var checkpoints [][]byte
+	_, err := InitializeTreeContext(context.Background(), 4, SHAKE_128, testSeed, &KeyGenOptions{
+		CheckpointInterval: 1,
+		Checkpoint: func(cp []byte) error {
+			checkpoints = append(checkpoints, cp)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checkpoints) != 15 {
+		t.Fatalf("got %d checkpoints; want 15", len(checkpoints))
+	}
+
+	for i, cp := range checkpoints {
+		got, err := InitializeTreeContext(context.Background(), 4, SHAKE_128, testSeed, &KeyGenOptions{Workers: 2, Resume: cp})
+		if err != nil {
+			t.Fatalf("resume after %d leaves: %v", i+1, err)
+		}
+		requireSameKey(t, got, want)
+	}
+}
+
+func TestInitializeTreeContextCancel(t *testing.T) {
+	setKeyGenBatch(t, 8)
+	want := newTestXMSS(t, 6)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var last []byte
+	_, err := InitializeTreeContext(ctx, 6, SHAKE_128, testSeed, &KeyGenOptions{
+		Progress: func(done, _ uint32) {
+			if done == 24 {
+				cancel()
+			}
+		},
+		Checkpoint: func(cp []byte) error {
+			last = cp
+			return nil
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v; want context.Canceled", err)
+	}
+	if last == nil {
+		t.Fatal("no checkpoint written on cancellation")
+	}
+
+	got, err := InitializeTreeContext(context.Background(), 6, SHAKE_128, testSeed, &KeyGenOptions{Resume: last})
+	if err != nil {
+		t.Fatalf("resume after cancellation: %v", err)
+	}
+	requireSameKey(t, got, want)
+
+	if _, err := InitializeTreeContext(ctx, 6, SHAKE_128, testSeed, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("already-cancelled context: err = %v", err)
+	}
+}
+
+func TestInitializeTreeContextRejectsForeignCheckpoint(t *testing.T) {
+	setKeyGenBatch(t, 4)
+	var cp []byte
+	_, err := InitializeTreeContext(context.Background(), 6, SHAKE_128, testSeed, &KeyGenOptions{
+		CheckpointInterval: 8,
+		Checkpoint: func(c []byte) error {
+			if cp == nil {
+				cp = c
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherSeed := bytes.Repeat([]uint8{1}, SeedSize)
+	tampered := append([]byte(nil), cp...)
+	tampered[len(tampered)/2] ^= 1
+	tests := []struct {
+		name   string
+		h      Height
+		hf     HashFunction
+		seed   []uint8
+		resume []byte
+	}{
+		{"other seed", 6, SHAKE_128, otherSeed, cp},
+		{"other height", 8, SHAKE_128, testSeed, cp},
+		{"other hash function", 6, SHAKE_256, testSeed, cp},
+		{"tampered", 6, SHAKE_128, testSeed, tampered},
+		{"truncated", 6, SHAKE_128, testSeed, cp[:len(cp)-1]},
+		{"garbage", 6, SHAKE_128, testSeed, []byte("not a checkpoint")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := InitializeTreeContext(context.Background(), tt.h, tt.hf, tt.seed, &KeyGenOptions{Resume: tt.resume})
+			if !errors.Is(err, cryptoerrors.ErrInvalidEncoding) {
+				t.Errorf("err = %v; want ErrInvalidEncoding", err)
+			}
+		})
+	}
+}
+
+func TestInitializeTreeContextCheckpointError(t *testing.T) {
+	setKeyGenBatch(t, 4)
+	errDisk := errors.New("disk full")
+	_, err := InitializeTreeContext(context.Background(), 6, SHAKE_128, testSeed, &KeyGenOptions{
+		CheckpointInterval: 4,
+		Checkpoint:         func([]byte) error { return errDisk },
+	})
+	if !errors.Is(err, errDisk) {
+		t.Errorf("err = %v; want the Checkpoint error", err)
+	}
+}
+
+func TestInitializeTreeContextRejectsInvalidInput(t *testing.T) {
+	ctx := context.Background()
+	if _, err := InitializeTreeContext(ctx, 4, SHAKE_128, make([]uint8, 47), nil); !errors.Is(err, cryptoerrors.ErrInvalidSeed) {
+		t.Errorf("short seed: got %v", err)
+	}
+	if _, err := InitializeTreeContext(ctx, 4, HashFunction(99), testSeed, nil); !errors.Is(err, cryptoerrors.ErrInvalidHashFunction) {
+		t.Errorf("invalid hash function: got %v", err)
+	}
+	if _, err := InitializeTreeContext(ctx, 5, SHAKE_128, testSeed, nil); !errors.Is(err, cryptoerrors.ErrInvalidHeight) {
+		t.Errorf("odd height: got %v", err)
+	}
+	if _, err := InitializeTreeContext(ctx, 2, SHAKE_128, testSeed, nil); !errors.Is(err, cryptoerrors.ErrInvalidBDSParams) {
+		t.Errorf("height 2: got %v", err)
+	}
+	if _, err := InitializeTreeFromExpandedSeedContext(ctx, 4, SHAKE_128, nil, nil); !errors.Is(err, cryptoerrors.ErrInvalidSeed) {
+		t.Errorf("nil expanded seed: got %v", err)
+	}
+}
+
+func TestInitializeTreeContextWipesSeedOnError(t *testing.T) {
+	seed := bytes.Repeat([]uint8{1}, SeedSize)
+	var expanded [96]uint8
+	misc.SHAKE256(expanded[:], seed)
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		h    Height
+		hf   HashFunction
+	}{
+		{"invalid hash function", context.Background(), 4, HashFunction(99)},
+		{"invalid height", context.Background(), 5, SHAKE_128},
+		{"invalid BDS parameters", context.Background(), 2, SHAKE_128},
+		{"cancelled", cancelled, 4, SHAKE_128},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storedSeed := bytes.Clone(seed)
+			if _, err := initializeTreeContext(tt.ctx, tt.h, tt.hf, &expanded, storedSeed, nil); err == nil {
+				t.Fatal("initializeTreeContext succeeded")
+			}
+			if !bytes.Equal(storedSeed, make([]uint8, len(storedSeed))) {
+				t.Error("storedSeed not wiped on error")
+			}
+		})
+	}
+}
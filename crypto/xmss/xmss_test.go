@@ -3,8 +3,11 @@ package xmss
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
 )
 
 // Test vectors generated with known seed for deterministic testing
@@ -630,3 +633,35 @@ func TestLargeMessage(t *testing.T) {
 		t.Error("Verify should return true for large message")
 	}
 }
+
+func TestVerifyAfterSign(t *testing.T) {
+	xmss := newTestXMSS(t, 4)
+	xmss.SetVerifyAfterSign(true)
+	message := []byte("verify after sign")
+
+	sig, err := xmss.Sign(message)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	pk := append(xmss.GetRoot(), xmss.GetPKSeed()...)
+	if !Verify(SHAKE_128, message, sig, pk) {
+		t.Fatal("signature does not verify")
+	}
+
+	// A corrupted root stands in for a fault: the fresh signature no
+	// longer verifies under the signer's public key.
+	xmss.sk[offsetRoot] ^= 1
+	sig, err = xmss.Sign(message)
+	if !errors.Is(err, cryptoerrors.ErrSigningFailed) || sig != nil {
+		t.Errorf("Sign = %d bytes, %v; want nil, ErrSigningFailed", len(sig), err)
+	}
+	// The index is consumed regardless.
+	if xmss.GetIndex() != 2 {
+		t.Errorf("index = %d after a failed check, want 2", xmss.GetIndex())
+	}
+
+	xmss.SetVerifyAfterSign(false)
+	if _, err := xmss.Sign(message); err != nil {
+		t.Errorf("Sign with the check disabled failed: %v", err)
+	}
+}
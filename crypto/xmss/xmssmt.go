@@ -0,0 +1,301 @@
+package xmss
+
+import (
+	"fmt"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/misc"
+)
+
+// XMSSMT is an XMSS^MT (multi-tree XMSS) key as specified in RFC 8391
+// §4.2. The hypertree has total height height split into layers
+// layers of XMSS trees of height height/layers; the key signs up to
+// 2^height messages while key generation only builds one tree per
+// layer.
+//
+// Like XMSS, XMSSMT is a STATEFUL scheme: every index must be used at
+// most once, and the advanced index must be persisted before a
+// signature is released. See the package documentation.
+type XMSSMT struct {
+	// xmssParams describes a single layer; its h is the layer height.
+	xmssParams   *XMSSParams
+	hashFunction HashFunction
+	height       uint8
+	layers       uint8
+	seed         []uint8
+
+	// sk is idx || SK_SEED || SK_PRF || root || PUB_SEED, the layout
+	// of the RFC 8391 reference implementation, with a ceil(height/8)
+	// byte index.
+	sk    []uint8
+	index uint64
+
+	// bdsStates and wotsSigs are the traversal state described in
+	// xmssmt_fast.go.
+	bdsStates []*BDSState
+	wotsSigs  []uint8
+
+	// verifyAfterSign enables the fault countermeasure; see
+	// SetVerifyAfterSign.
+	verifyAfterSign bool
+}
+
+// validateMTParams checks an XMSS^MT (height, layers) pair. layers
+// must be at least 2 and divide height; the layer height must lie in
+// [MinMTLayerHeight, MaxHeight] and height must not exceed
+// MaxMTHeight.
+func validateMTParams(height, layers uint8) error {
+	if layers < 2 || height%layers != 0 {
+		return cryptoerrors.ErrUnsupportedParameterSet
+	}
+	treeHeight := height / layers
+	if height > MaxMTHeight || treeHeight < MinMTLayerHeight || treeHeight > MaxHeight {
+		return cryptoerrors.ErrInvalidHeight
+	}
+	return nil
+}
+
+// newMTParams returns the per-layer parameters of a validated XMSS^MT
+// (height, layers) pair.
+func newMTParams(height, layers uint8) *XMSSParams {
+	treeHeight := uint32(height / layers)
+	return NewXMSSParams(WOTSParamN, treeHeight, WOTSParamW, mtBDSK(treeHeight))
+}
+
+// InitializeMultiTree creates a new XMSS^MT key of total height height
+// split over layers layers, expanding the 48-byte seed via SHAKE256
+// into SK_SEED || SK_PRF || PUB_SEED as [InitializeTree] does.
+//
+// Returns an error if the hashFunction is not one of the recognised
+// values, if (height, layers) is not a valid split (see
+// [ErrUnsupportedParameterSet] and [ErrInvalidHeight] in
+// crypto/errors), or if the seed is not exactly SeedSize (48) bytes.
+//
+// For RFC 8391 reference-implementation interop use
+// [InitializeMultiTreeFromExpandedSeed] or the
+// [github.com/theQRL/go-qrllib/crypto/xmss/rfc8391] sub-package.
+func InitializeMultiTree(height, layers uint8, hashFunction HashFunction, seed []uint8) (*XMSSMT, error) {
+	if len(seed) != SeedSize {
+		return nil, cryptoerrors.ErrInvalidSeed
+	}
+
+	var expanded [96]uint8
+	misc.SHAKE256(expanded[:], seed)
+
+	storedSeed := make([]uint8, len(seed))
+	copy(storedSeed, seed)
+
+	x, err := initializeMultiTree(height, layers, hashFunction, &expanded, storedSeed)
+	for i := range expanded {
+		expanded[i] = 0
+	}
+	return x, err
+}
+
+// InitializeMultiTreeFromExpandedSeed creates a new XMSS^MT key from
+// 96 bytes of pre-expanded seed material (SK_SEED || SK_PRF ||
+// PUB_SEED), the layout the RFC 8391 reference implementation
+// consumes. Validation mirrors [InitializeMultiTree].
+func InitializeMultiTreeFromExpandedSeed(height, layers uint8, hashFunction HashFunction, expandedSeed *[96]uint8) (*XMSSMT, error) {
+	if expandedSeed == nil {
+		return nil, cryptoerrors.ErrInvalidSeed
+	}
+
+	storedSeed := make([]uint8, 96)
+	copy(storedSeed, expandedSeed[:])
+
+	return initializeMultiTree(height, layers, hashFunction, expandedSeed, storedSeed)
+}
+
+func initializeMultiTree(height, layers uint8, hashFunction HashFunction, expandedSeed *[96]uint8, storedSeed []uint8) (*XMSSMT, error) {
+	if !hashFunction.IsValid() {
+		return nil, cryptoerrors.ErrInvalidHashFunction
+	}
+	if err := validateMTParams(height, layers); err != nil {
+		return nil, err
+	}
+
+	xmssParams := newMTParams(height, layers)
+	n := xmssParams.n
+	ib := mtIndexBytes(uint32(height))
+
+	sk := make([]uint8, ib+4*n)
+	bdsStates := newMTBDSStates(xmssParams, uint32(layers))
+	wotsSigs := make([]uint8, uint32(layers-1)*xmssParams.wotsParams.keySize)
+
+	xmssMTFastGenKeyPairCore(hashFunction, xmssParams, uint32(layers), sk, bdsStates, wotsSigs, expandedSeed)
+
+	// Same non-zero-root tripwire as InitializeTree (TOB-QRLLIB-13).
+	allZero := true
+	for _, b := range sk[ib+2*n : ib+3*n] {
+		if b != 0 {
+			allZero = false
+			break
+		}
+	}
+	if allZero {
+		//coverage:ignore
+		//rationale: tripwire only — the hash function and parameter
+		//guards above prevent the degenerate-root path.
+		return nil, cryptoerrors.ErrKeyGeneration
+	}
+
+	return &XMSSMT{
+		xmssParams:   xmssParams,
+		hashFunction: hashFunction,
+		height:       height,
+		layers:       layers,
+		seed:         storedSeed,
+		sk:           sk,
+		bdsStates:    bdsStates,
+		wotsSigs:     wotsSigs,
+	}, nil
+}
+
+func (x *XMSSMT) GetSeed() []uint8 {
+	result := make([]uint8, len(x.seed))
+	copy(result, x.seed)
+	return result
+}
+
+func (x *XMSSMT) GetSK() []uint8 {
+	result := make([]uint8, len(x.sk))
+	copy(result, x.sk)
+	return result
+}
+
+func (x *XMSSMT) GetPKSeed() []uint8 {
+	offset := mtIndexBytes(uint32(x.height)) + 3*x.xmssParams.n
+	result := make([]uint8, x.xmssParams.n)
+	copy(result, x.sk[offset:offset+x.xmssParams.n])
+	return result
+}
+
+func (x *XMSSMT) GetRoot() []uint8 {
+	offset := mtIndexBytes(uint32(x.height)) + 2*x.xmssParams.n
+	result := make([]uint8, x.xmssParams.n)
+	copy(result, x.sk[offset:offset+x.xmssParams.n])
+	return result
+}
+
+func (x *XMSSMT) GetHashFunction() HashFunction {
+	return x.hashFunction
+}
+
+// GetHeight returns the total height of the hypertree.
+func (x *XMSSMT) GetHeight() uint8 {
+	return x.height
+}
+
+func (x *XMSSMT) GetLayers() uint8 {
+	return x.layers
+}
+
+// GetIndex returns the next unused one-time index. It is 2^height once
+// the key is exhausted.
+func (x *XMSSMT) GetIndex() uint64 {
+	return x.index
+}
+
+// SetIndex advances the key to newIndex, as [XMSS.SetIndex] does.
+// Rewinding returns ErrOTSIndexRewind and an index at or past
+// 2^height returns ErrOTSIndexTooHigh. Moves of more than one bottom
+// tree rebuild the traversal state directly, so recovery far into a
+// large key costs about as much as key generation.
+func (x *XMSSMT) SetIndex(newIndex uint64) error {
+	if err := xmssMTFastUpdate(x.hashFunction, x.xmssParams, uint32(x.layers), x.sk,
+		x.bdsStates, x.wotsSigs, x.index, newIndex); err != nil {
+		return err
+	}
+	x.index = newIndex
+	return nil
+}
+
+// Sign generates a signature for message and advances the one-time
+// index. The caller MUST persist the updated index (via GetIndex) to
+// durable storage before using the returned signature.
+func (x *XMSSMT) Sign(message []uint8) ([]uint8, error) {
+	if x.index>>x.height != 0 {
+		return nil, fmt.Errorf("%w: %w", cryptoerrors.ErrSigningFailed, cryptoerrors.ErrOTSIndexTooHigh)
+	}
+
+	sig, err := xmssMTFastSignMessage(x.hashFunction, x.xmssParams, uint32(x.layers), x.sk,
+		x.bdsStates, x.wotsSigs, x.index, message)
+	if err != nil {
+		//coverage:ignore
+		//rationale: the only error is index exhaustion, checked above
+		return nil, err
+	}
+	x.index++
+
+	if x.verifyAfterSign {
+		pk := append(x.GetRoot(), x.GetPKSeed()...)
+		if verifyMTSig(x.hashFunction, x.xmssParams, uint32(x.layers), message, sig, pk) != nil {
+			for i := range sig {
+				sig[i] = 0
+			}
+			return nil, cryptoerrors.ErrSigningFailed
+		}
+	}
+	return sig, nil
+}
+
+// SetVerifyAfterSign enables or disables verify-after-sign, as
+// [XMSS.SetVerifyAfterSign] does. It must not be called concurrently
+// with Sign.
+func (x *XMSSMT) SetVerifyAfterSign(enabled bool) {
+	x.verifyAfterSign = enabled
+}
+
+// Zeroize clears sensitive key material from memory.
+// This should be called when the XMSSMT instance is no longer needed.
+func (x *XMSSMT) Zeroize() {
+	for i := range x.sk {
+		x.sk[i] = 0
+	}
+	for i := range x.seed {
+		x.seed[i] = 0
+	}
+	for i := range x.wotsSigs {
+		x.wotsSigs[i] = 0
+	}
+	for _, bdsState := range x.bdsStates {
+		zeroizeBDSState(bdsState)
+	}
+}
+
+// GetMTSignatureSize returns the byte length of an XMSS^MT signature
+// of total height height over layers layers, or an error if the pair
+// is not a valid split.
+func GetMTSignatureSize(height, layers uint8) (uint32, error) {
+	if err := validateMTParams(height, layers); err != nil {
+		return 0, err
+	}
+	return getMTSignatureSize(newMTParams(height, layers), uint32(layers)), nil
+}
+
+// VerifyMT reports whether signature is a valid XMSS^MT signature over
+// message under pk (root || pubSeed) for a hypertree of total height
+// height over layers layers. Unlike [Verify], the parameters cannot be
+// taken from the signature size, since different splits can share it.
+func VerifyMT(hashFunction HashFunction, height, layers uint8, message, signature, pk []uint8) bool {
+	return VerifyMTDetailed(hashFunction, height, layers, message, signature, pk) == nil
+}
+
+// VerifyMTDetailed is [VerifyMT] returning the reason for a rejection:
+// [cryptoerrors.ErrInvalidHashFunction] for an unknown hash function,
+// [cryptoerrors.ErrUnsupportedParameterSet] or
+// [cryptoerrors.ErrInvalidHeight] for an invalid (height, layers)
+// pair, [cryptoerrors.ErrInvalidSignatureSize],
+// [cryptoerrors.ErrInvalidPublicKey] if pk is too short, and
+// [cryptoerrors.ErrInvalidSignature] if the recomputed root does not
+// match pk.
+func VerifyMTDetailed(hashFunction HashFunction, height, layers uint8, message, signature, pk []uint8) error {
+	if !hashFunction.IsValid() {
+		return cryptoerrors.ErrInvalidHashFunction
+	}
+	if err := validateMTParams(height, layers); err != nil {
+		return err
+	}
+	return verifyMTSig(hashFunction, newMTParams(height, layers), uint32(layers), message, signature, pk)
+}
@@ -0,0 +1,474 @@
+package xmss
+
+import (
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+	"github.com/theQRL/go-qrllib/misc"
+)
+
+// XMSS^MT keeps 2*layers-1 BDS states: states[i] traverses the current
+// tree on layer i and states[layers+i] builds the next tree on that
+// layer, one leaf at a time, so it is complete by the time the current
+// one runs out. The top layer has a single tree and no next state.
+// wotsSigs caches, for every layer but the top, the WOTS+ signature of
+// the current tree's root by its parent leaf. This is the layout of
+// xmss_core_fast.c in the reference implementation.
+
+// mtIndexBytes is the length of the XMSS^MT index field, ceil(h/8).
+func mtIndexBytes(height uint32) uint32 {
+	return (height + 7) / 8
+}
+
+// mtBDSK returns the BDS parameter k for one XMSS^MT layer of height
+// treeHeight. BDS needs treeHeight-k even, so odd layer heights such
+// as the 5 of XMSSMT-SHA2_20/4_256 use k=1. k only trades memory
+// against signing time; signatures are the same for every choice.
+func mtBDSK(treeHeight uint32) uint32 {
+	if treeHeight%2 == 0 {
+		return WOTSParamK
+	}
+	return 1
+}
+
+func getMTSignatureSize(params *XMSSParams, layers uint32) uint32 {
+	return mtIndexBytes(params.h*layers) + params.n + layers*(params.wotsParams.keySize+params.h*params.n)
+}
+
+func bytesToUint64(in []uint8) uint64 {
+	r := uint64(0)
+	for _, b := range in {
+		r = r<<8 | uint64(b)
+	}
+	return r
+}
+
+// uint64ToBytes writes in to out big-endian, filling all of out.
+func uint64ToBytes(out []uint8, in uint64) {
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = uint8(in)
+		in >>= 8
+	}
+}
+
+// newMTBDSStates allocates the BDS states of an XMSS^MT key.
+func newMTBDSStates(params *XMSSParams, layers uint32) []*BDSState {
+	states := make([]*BDSState, 2*layers-1)
+	for i := range states {
+		states[i] = NewBDSState(params.h, params.n, params.k)
+	}
+	return states
+}
+
+// setMTIndexBytes writes idx to the index field of an XMSS^MT secret
+// key. The field is ceil(h/8) bytes, which for h a multiple of 8 cannot
+// hold 2^h, so an exhausted key is marked with all-0xFF bytes as the
+// reference implementation does.
+func setMTIndexBytes(sk []uint8, height uint32, idx uint64) {
+	ib := mtIndexBytes(height)
+	if idx>>height != 0 {
+		for i := uint32(0); i < ib; i++ {
+			sk[i] = 0xff
+		}
+		return
+	}
+	uint64ToBytes(sk[:ib], idx)
+}
+
+// xmssMTFastGenKeyPairCore derives an XMSS^MT key from SK_SEED ||
+// SK_PRF || PUB_SEED. sk receives idx || SK_SEED || SK_PRF || root ||
+// PUB_SEED, the reference layout. The first tree of every layer is
+// built, and the root of each but the top is signed by the first leaf
+// of the layer above.
+func xmssMTFastGenKeyPairCore(hashFunction HashFunction, params *XMSSParams, layers uint32,
+	sk []uint8, states []*BDSState, wotsSigs []uint8, expandedSeed *[96]uint8) {
+	n := params.n
+	ib := mtIndexBytes(params.h * layers)
+	keySize := params.wotsParams.keySize
+
+	for i := uint32(0); i < ib; i++ {
+		sk[i] = 0
+	}
+	copy(sk[ib:ib+2*n], expandedSeed[:2*n])
+	copy(sk[ib+3*n:ib+4*n], expandedSeed[2*n:3*n])
+	skSeed := sk[ib : ib+n]
+	pubSeed := sk[ib+3*n : ib+4*n]
+
+	root := make([]uint8, n)
+	otsSeed := make([]uint8, n)
+	var addr [8]uint32
+	for i := uint32(0); i < layers-1; i++ {
+		treeHashSetup(hashFunction, root, 0, states[i], skSeed, params, pubSeed, addr[:])
+		misc.SetLayerAddr(&addr, i+1)
+		getSeed(hashFunction, otsSeed, skSeed, n, &addr)
+		wotsSign(hashFunction, wotsSigs[i*keySize:(i+1)*keySize], root, otsSeed, params.wotsParams, pubSeed, &addr)
+	}
+	treeHashSetup(hashFunction, root, 0, states[layers-1], skSeed, params, pubSeed, addr[:])
+	copy(sk[ib+2*n:ib+3*n], root)
+}
+
+// xmssMTFastSignMessage signs message with the one-time key at idx,
+// then advances the states to idx+1. The index is kept by the caller
+// and written back to sk here.
+func xmssMTFastSignMessage(hashFunction HashFunction, params *XMSSParams, layers uint32,
+	sk []uint8, states []*BDSState, wotsSigs []uint8, idx uint64, message []uint8) ([]uint8, error) {
+	n := params.n
+	treeHeight := params.h
+	ib := mtIndexBytes(treeHeight * layers)
+	keySize := params.wotsParams.keySize
+
+	if idx>>(treeHeight*layers) != 0 {
+		return nil, cryptoerrors.ErrOTSIndexTooHigh
+	}
+
+	skSeed := make([]uint8, n)
+	copy(skSeed, sk[ib:ib+n])
+	skPRF := make([]uint8, n)
+	copy(skPRF, sk[ib+n:ib+2*n])
+	pubSeed := make([]uint8, n)
+	copy(pubSeed, sk[ib+3*n:ib+4*n])
+
+	setMTIndexBytes(sk, treeHeight*layers, idx+1)
+
+	var idxBytes32 [32]uint8
+	uint64ToBytes(idxBytes32[:], idx) // RFC 8391 requires big-endian encoding
+
+	R := make([]uint8, n)
+	prf(hashFunction, R, &idxBytes32, skPRF, n)
+
+	hashKey := make([]uint8, 3*n)
+	copy(hashKey[:n], R)
+	copy(hashKey[n:2*n], sk[ib+2*n:ib+3*n])
+	uint64ToBytes(hashKey[2*n:3*n], idx)
+	msgHash := make([]uint8, n)
+	if err := hMsg(hashFunction, msgHash, message, hashKey, n); err != nil {
+		//coverage:ignore
+		//rationale: hashKey is always 3*n bytes (constructed above), so hMsg will not return an error
+		return nil, err
+	}
+
+	sigMsg := make([]uint8, getMTSignatureSize(params, layers))
+	uint64ToBytes(sigMsg[:ib], idx)
+	copy(sigMsg[ib:ib+n], R)
+	sigMsgLen := ib + n
+
+	// Only the bottom layer is signed here; the signatures of the
+	// layers above come from wotsSigs.
+	var otsAddr [8]uint32
+	misc.SetTreeAddr(&otsAddr, idx>>treeHeight)
+	misc.SetType(&otsAddr, 0)
+	misc.SetOTSAddr(&otsAddr, uint32(idx&(1<<treeHeight-1)))
+
+	otsSeed := make([]uint8, n)
+	getSeed(hashFunction, otsSeed, skSeed, n, &otsAddr)
+	wotsSign(hashFunction, sigMsg[sigMsgLen:], msgHash, otsSeed, params.wotsParams, pubSeed, &otsAddr)
+	sigMsgLen += keySize
+
+	copy(sigMsg[sigMsgLen:sigMsgLen+treeHeight*n], states[0].auth[:treeHeight*n])
+	sigMsgLen += treeHeight * n
+
+	for i := uint32(1); i < layers; i++ {
+		copy(sigMsg[sigMsgLen:sigMsgLen+keySize], wotsSigs[(i-1)*keySize:i*keySize])
+		sigMsgLen += keySize
+		copy(sigMsg[sigMsgLen:sigMsgLen+treeHeight*n], states[i].auth[:treeHeight*n])
+		sigMsgLen += treeHeight * n
+	}
+
+	xmssMTFastAdvance(hashFunction, params, layers, states, wotsSigs, idx, skSeed, pubSeed)
+
+	return sigMsg, nil
+}
+
+// xmssMTFastAdvance moves the traversal from index idx to idx+1, as
+// xmssmt_core_sign does after emitting a signature: the bottom layer
+// runs a BDS round, the treehash budget is shared from the bottom up,
+// the next-tree states grow, and every layer whose tree is used up
+// swaps in its next tree and has its root signed by the layer above.
+func xmssMTFastAdvance(hashFunction HashFunction, params *XMSSParams, layers uint32,
+	states []*BDSState, wotsSigs []uint8, idx uint64, skSeed, pubSeed []uint8) {
+	n := params.n
+	treeHeight := params.h
+	fullHeight := treeHeight * layers
+	keySize := params.wotsParams.keySize
+	mask := uint64(1)<<treeHeight - 1
+
+	updates := (treeHeight - params.k) >> 1
+
+	idxTree := idx >> treeHeight
+	idxLeaf := idx & mask
+
+	var addr [8]uint32
+	misc.SetTreeAddr(&addr, idxTree+1)
+	// The next tree on the bottom layer gets one leaf per signature.
+	if (idxTree+1)<<treeHeight+idxLeaf < uint64(1)<<fullHeight {
+		bdsStateUpdate(hashFunction, states[layers], skSeed, params, pubSeed, &addr)
+	}
+
+	needSwapUpTo := -1
+	otsSeed := make([]uint8, n)
+	for i := uint32(0); i < layers; i++ {
+		if (idx+1)&(uint64(1)<<((i+1)*treeHeight)-1) != 0 {
+			// Not at the end of the tree on layer i
+			idxLeaf = (idx >> (treeHeight * i)) & mask
+			idxTree = idx >> (treeHeight * (i + 1))
+			misc.SetLayerAddr(&addr, i)
+			misc.SetTreeAddr(&addr, idxTree)
+			if int(i) == needSwapUpTo+1 {
+				bdsRound(hashFunction, states[i], uint32(idxLeaf), skSeed, params, pubSeed, &addr)
+			}
+			updates = bdsTreeHashUpdate(hashFunction, states[i], updates, skSeed, params, pubSeed, &addr)
+			misc.SetTreeAddr(&addr, idxTree+1)
+			// Spend a leftover update on the next tree, if there is one
+			if (idxTree+1)<<treeHeight+idxLeaf < uint64(1)<<(fullHeight-treeHeight*i) {
+				if i > 0 && updates > 0 {
+					bdsStateUpdate(hashFunction, states[layers+i], skSeed, params, pubSeed, &addr)
+					updates--
+				}
+			}
+		} else if idx < uint64(1)<<fullHeight-1 {
+			states[i], states[layers+i] = states[layers+i], states[i]
+
+			var otsAddr [8]uint32
+			misc.SetLayerAddr(&otsAddr, i+1)
+			misc.SetTreeAddr(&otsAddr, (idx+1)>>((i+2)*treeHeight))
+			misc.SetType(&otsAddr, 0)
+			misc.SetOTSAddr(&otsAddr, uint32(((idx>>((i+1)*treeHeight))+1)&mask))
+			getSeed(hashFunction, otsSeed, skSeed, n, &otsAddr)
+			wotsSign(hashFunction, wotsSigs[i*keySize:(i+1)*keySize], states[i].stack[:n], otsSeed, params.wotsParams, pubSeed, &otsAddr)
+
+			states[layers+i].stackOffset = 0
+			states[layers+i].nextLeaf = 0
+
+			// The WOTS+ signature counts as one update
+			updates--
+			needSwapUpTo = int(i)
+			for j := uint32(0); j < treeHeight-params.k; j++ {
+				states[i].treeHash[j].completed = 1
+			}
+		}
+	}
+}
+
+// bdsStateUpdate adds the next leaf to a next-tree state, merging
+// completed nodes into its auth, treehash and retain buffers as
+// treeHashSetup does for a whole tree. It does nothing once the tree
+// is complete, when the root is left in stack[:n].
+func bdsStateUpdate(hashFunction HashFunction, bdsState *BDSState, skSeed []uint8, params *XMSSParams, pubSeed []uint8, addr *[8]uint32) {
+	n := params.n
+	h := params.h
+	k := params.k
+
+	idx := bdsState.nextLeaf
+	if idx == 1<<h {
+		return
+	}
+
+	var otsAddr [8]uint32
+	var lTreeAddr [8]uint32
+	var nodeAddr [8]uint32
+
+	copy(otsAddr[:3], addr[:3])
+	misc.SetType(&otsAddr, 0)
+
+	copy(lTreeAddr[:3], addr[:3])
+	misc.SetType(&lTreeAddr, 1)
+
+	copy(nodeAddr[:3], addr[:3])
+	misc.SetType(&nodeAddr, 2)
+
+	misc.SetOTSAddr(&otsAddr, idx)
+	misc.SetLTreeAddr(&lTreeAddr, idx)
+
+	stackStart := bdsState.stackOffset * n
+	genLeafWOTS(hashFunction, bdsState.stack[stackStart:stackStart+n], skSeed, params, pubSeed, &lTreeAddr, &otsAddr)
+
+	bdsState.stackLevels[bdsState.stackOffset] = 0
+	bdsState.stackOffset++
+	// Same faithful-port quirk as in treeHashSetup: this reads the slot
+	// above the new leaf and is overwritten by the merge loop below.
+	if h-k > 0 && idx == 3 {
+		stackStart = bdsState.stackOffset * n
+		copy(bdsState.treeHash[0].node, bdsState.stack[stackStart:stackStart+n])
+	}
+	for bdsState.stackOffset > 1 && bdsState.stackLevels[bdsState.stackOffset-1] == bdsState.stackLevels[bdsState.stackOffset-2] {
+		nodeH := uint32(bdsState.stackLevels[bdsState.stackOffset-1])
+		stackStart = (bdsState.stackOffset - 1) * n
+		if idx>>nodeH == 1 {
+			copy(bdsState.auth[nodeH*n:nodeH*n+n], bdsState.stack[stackStart:stackStart+n])
+		} else {
+			if nodeH < h-k && idx>>nodeH == 3 {
+				copy(bdsState.treeHash[nodeH].node, bdsState.stack[stackStart:stackStart+n])
+			} else if nodeH >= h-k {
+				retainStart := ((1 << (h - 1 - nodeH)) + nodeH - h + (((idx >> nodeH) - 3) >> 1)) * n
+				copy(bdsState.retain[retainStart:retainStart+n], bdsState.stack[stackStart:stackStart+n])
+			}
+		}
+		misc.SetTreeHeight(&nodeAddr, nodeH)
+		misc.SetTreeIndex(&nodeAddr, idx>>(nodeH+1))
+		stackStart = (bdsState.stackOffset - 2) * n
+		hashH(hashFunction, bdsState.stack[stackStart:stackStart+n], bdsState.stack[stackStart:stackStart+2*n], pubSeed, &nodeAddr, n)
+		bdsState.stackLevels[bdsState.stackOffset-2]++
+		bdsState.stackOffset--
+	}
+	bdsState.nextLeaf++
+}
+
+// xmssMTFastUpdate moves the key from its current index to newIdx.
+// Short moves replay xmssMTFastAdvance; longer ones rebuild every
+// layer at newIdx directly, which costs about one key generation plus
+// a single-tree SetIndex per layer instead of a walk over every
+// skipped index.
+func xmssMTFastUpdate(hashFunction HashFunction, params *XMSSParams, layers uint32,
+	sk []uint8, states []*BDSState, wotsSigs []uint8, currentIdx, newIdx uint64) error {
+	n := params.n
+	treeHeight := params.h
+	fullHeight := treeHeight * layers
+	ib := mtIndexBytes(fullHeight)
+
+	if newIdx>>fullHeight != 0 {
+		return cryptoerrors.ErrOTSIndexTooHigh
+	}
+	if newIdx < currentIdx {
+		return cryptoerrors.ErrOTSIndexRewind
+	}
+
+	skSeed := make([]uint8, n)
+	copy(skSeed, sk[ib:ib+n])
+	pubSeed := make([]uint8, n)
+	copy(pubSeed, sk[ib+3*n:ib+4*n])
+
+	if newIdx-currentIdx <= uint64(1)<<treeHeight {
+		for j := currentIdx; j < newIdx; j++ {
+			xmssMTFastAdvance(hashFunction, params, layers, states, wotsSigs, j, skSeed, pubSeed)
+		}
+	} else {
+		xmssMTFastRebuild(hashFunction, params, layers, states, wotsSigs, newIdx, skSeed, pubSeed)
+	}
+
+	setMTIndexBytes(sk, fullHeight, newIdx)
+	return nil
+}
+
+// xmssMTFastRebuild sets up every state for signing at idx from
+// scratch. The current tree on each layer is rebuilt and walked to
+// its leaf as xmssFastUpdate does for a single tree. Next trees are
+// built completely rather than to the point the incremental schedule
+// would have reached, which is always enough for them to be ready.
+func xmssMTFastRebuild(hashFunction HashFunction, params *XMSSParams, layers uint32,
+	states []*BDSState, wotsSigs []uint8, idx uint64, skSeed, pubSeed []uint8) {
+	n := params.n
+	treeHeight := params.h
+	fullHeight := treeHeight * layers
+	keySize := params.wotsParams.keySize
+	mask := uint64(1)<<treeHeight - 1
+
+	root := make([]uint8, n)
+	otsSeed := make([]uint8, n)
+	for i := uint32(0); i < layers; i++ {
+		idxTree := idx >> (treeHeight * (i + 1))
+		idxLeaf := uint32((idx >> (treeHeight * i)) & mask)
+
+		var addr [8]uint32
+		misc.SetLayerAddr(&addr, i)
+		misc.SetTreeAddr(&addr, idxTree)
+
+		states[i] = NewBDSState(treeHeight, n, params.k)
+		treeHashSetup(hashFunction, root, 0, states[i], skSeed, params, pubSeed, addr[:])
+		for j := uint32(0); j < idxLeaf; j++ {
+			bdsRound(hashFunction, states[i], j, skSeed, params, pubSeed, &addr)
+			bdsTreeHashUpdate(hashFunction, states[i], (treeHeight-params.k)>>1, skSeed, params, pubSeed, &addr)
+		}
+
+		if i == layers-1 {
+			break
+		}
+
+		var otsAddr [8]uint32
+		misc.SetLayerAddr(&otsAddr, i+1)
+		misc.SetTreeAddr(&otsAddr, idxTree>>treeHeight)
+		misc.SetType(&otsAddr, 0)
+		misc.SetOTSAddr(&otsAddr, uint32(idxTree&mask))
+		getSeed(hashFunction, otsSeed, skSeed, n, &otsAddr)
+		wotsSign(hashFunction, wotsSigs[i*keySize:(i+1)*keySize], root, otsSeed, params.wotsParams, pubSeed, &otsAddr)
+
+		states[layers+i] = NewBDSState(treeHeight, n, params.k)
+		if (idxTree+1)<<(treeHeight*(i+1)) < uint64(1)<<fullHeight {
+			misc.SetTreeAddr(&addr, idxTree+1)
+			for j := uint32(0); j < 1<<treeHeight; j++ {
+				bdsStateUpdate(hashFunction, states[layers+i], skSeed, params, pubSeed, &addr)
+			}
+		}
+	}
+}
+
+// verifyMTSig checks an XMSS^MT signature: each layer recovers a WOTS+
+// public key and authenticates it up to a root, which is the message
+// the layer above signed. The final root must equal pk[:n].
+func verifyMTSig(hashFunction HashFunction, params *XMSSParams, layers uint32, msg, sigMsg, pk []uint8) error {
+	n := params.n
+	treeHeight := params.h
+	ib := mtIndexBytes(treeHeight * layers)
+	wotsParams := params.wotsParams
+
+	if uint32(len(pk)) < 2*n {
+		return cryptoerrors.ErrInvalidPublicKey
+	}
+	if uint32(len(sigMsg)) != getMTSignatureSize(params, layers) {
+		return cryptoerrors.ErrInvalidSignatureSize
+	}
+
+	pubSeed := make([]uint8, n)
+	copy(pubSeed, pk[n:2*n])
+
+	idx := bytesToUint64(sigMsg[:ib])
+	if idx>>(treeHeight*layers) != 0 {
+		return cryptoerrors.ErrInvalidSignature
+	}
+
+	hashKey := make([]uint8, 3*n)
+	copy(hashKey[:n], sigMsg[ib:ib+n])
+	copy(hashKey[n:2*n], pk[:n])
+	uint64ToBytes(hashKey[2*n:3*n], idx)
+
+	root := make([]uint8, n)
+	if err := hMsg(hashFunction, root, msg, hashKey, n); err != nil {
+		//coverage:ignore
+		//rationale: hashKey is always 3*n bytes (constructed above), so hMsg will not return an error
+		return err
+	}
+	sigMsgOffset := ib + n
+
+	wotsPK := make([]uint8, wotsParams.keySize)
+	leaf := make([]uint8, n)
+	for i := uint32(0); i < layers; i++ {
+		idxLeaf := uint32(idx & (1<<treeHeight - 1))
+		idx >>= treeHeight
+
+		var otsAddr [8]uint32
+		var lTreeAddr [8]uint32
+		var nodeAddr [8]uint32
+		for _, addr := range []*[8]uint32{&otsAddr, &lTreeAddr, &nodeAddr} {
+			misc.SetLayerAddr(addr, i)
+			misc.SetTreeAddr(addr, idx)
+		}
+		misc.SetType(&otsAddr, 0)
+		misc.SetType(&lTreeAddr, 1)
+		misc.SetType(&nodeAddr, 2)
+
+		misc.SetOTSAddr(&otsAddr, idxLeaf)
+		wotsPKFromSig(hashFunction, wotsPK, sigMsg[sigMsgOffset:], root, wotsParams, pubSeed, &otsAddr)
+		sigMsgOffset += wotsParams.keySize
+
+		misc.SetLTreeAddr(&lTreeAddr, idxLeaf)
+		lTree(hashFunction, wotsParams, leaf, wotsPK, pubSeed, &lTreeAddr)
+
+		validateAuthPath(hashFunction, root, leaf, idxLeaf, sigMsg[sigMsgOffset:], n, treeHeight, pubSeed, &nodeAddr)
+		sigMsgOffset += treeHeight * n
+	}
+
+	for i := uint32(0); i < n; i++ {
+		if root[i] != pk[i] {
+			return cryptoerrors.ErrInvalidSignature
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,276 @@
+package xmss
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
+)
+
+func newTestXMSSMT(t *testing.T, height, layers uint8) *XMSSMT {
+	t.Helper()
+	x, err := InitializeMultiTree(height, layers, SHAKE_128, testSeed)
+	if err != nil {
+		t.Fatalf("InitializeMultiTree(%d, %d) failed: %v", height, layers, err)
+	}
+	return x
+}
+
+func mtPK(x *XMSSMT) []uint8 {
+	return append(x.GetRoot(), x.GetPKSeed()...)
+}
+
+func TestValidateMTParams(t *testing.T) {
+	tests := []struct {
+		height, layers uint8
+		want           error
+	}{
+		{8, 2, nil},
+		{10, 2, nil},
+		{12, 3, nil},
+		{20, 4, nil},
+		{40, 8, nil},
+		{60, 3, nil},
+		{60, 12, nil},
+		{10, 1, cryptoerrors.ErrUnsupportedParameterSet},
+		{10, 0, cryptoerrors.ErrUnsupportedParameterSet},
+		{10, 3, cryptoerrors.ErrUnsupportedParameterSet},
+		{6, 2, cryptoerrors.ErrInvalidHeight},
+		{64, 2, cryptoerrors.ErrInvalidHeight},
+		{62, 2, cryptoerrors.ErrInvalidHeight},
+	}
+	for _, tt := range tests {
+		if err := validateMTParams(tt.height, tt.layers); !errors.Is(err, tt.want) {
+			t.Errorf("validateMTParams(%d, %d) = %v, want %v", tt.height, tt.layers, err, tt.want)
+		}
+	}
+}
+
+func TestInitializeMultiTreeRejectsInvalidInput(t *testing.T) {
+	if _, err := InitializeMultiTree(8, 2, SHAKE_128, make([]uint8, 47)); !errors.Is(err, cryptoerrors.ErrInvalidSeed) {
+		t.Errorf("short seed: got %v, want ErrInvalidSeed", err)
+	}
+	if _, err := InitializeMultiTree(8, 2, HashFunction(99), testSeed); !errors.Is(err, cryptoerrors.ErrInvalidHashFunction) {
+		t.Errorf("invalid hash function: got %v, want ErrInvalidHashFunction", err)
+	}
+	if _, err := InitializeMultiTree(8, 3, SHAKE_128, testSeed); !errors.Is(err, cryptoerrors.ErrUnsupportedParameterSet) {
+		t.Errorf("uneven split: got %v, want ErrUnsupportedParameterSet", err)
+	}
+	if _, err := InitializeMultiTreeFromExpandedSeed(8, 2, SHAKE_128, nil); !errors.Is(err, cryptoerrors.ErrInvalidSeed) {
+		t.Errorf("nil expanded seed: got %v, want ErrInvalidSeed", err)
+	}
+}
+
+func TestInitializeMultiTreeDeterministic(t *testing.T) {
+	a := newTestXMSSMT(t, 8, 2)
+	b := newTestXMSSMT(t, 8, 2)
+	if !bytes.Equal(a.GetSK(), b.GetSK()) {
+		t.Error("same seed produced different keys")
+	}
+	if a.GetHeight() != 8 || a.GetLayers() != 2 || a.GetIndex() != 0 {
+		t.Errorf("got height %d layers %d index %d", a.GetHeight(), a.GetLayers(), a.GetIndex())
+	}
+
+	c := newTestXMSSMT(t, 12, 3)
+	if bytes.Equal(a.GetRoot(), c.GetRoot()) {
+		t.Error("different splits produced the same root")
+	}
+}
+
+// TestXMSSMTSignAllIndices exhausts small hypertrees, covering every
+// tree switch on every layer, and checks the exhausted key refuses to
+// sign.
+func TestXMSSMTSignAllIndices(t *testing.T) {
+	splits := [][2]uint8{{8, 2}}
+	if !testing.Short() {
+		splits = append(splits, [2]uint8{10, 2}, [2]uint8{12, 3})
+	}
+	for _, split := range splits {
+		x := newTestXMSSMT(t, split[0], split[1])
+		pk := mtPK(x)
+		msg := []uint8("xmssmt message")
+		size, err := GetMTSignatureSize(split[0], split[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := uint64(0); i < 1<<split[0]; i++ {
+			sig, err := x.Sign(msg)
+			if err != nil {
+				t.Fatalf("%d/%d: Sign at %d failed: %v", split[0], split[1], i, err)
+			}
+			if uint32(len(sig)) != size {
+				t.Fatalf("%d/%d: signature is %d bytes, want %d", split[0], split[1], len(sig), size)
+			}
+			if err := VerifyMTDetailed(SHAKE_128, split[0], split[1], msg, sig, pk); err != nil {
+				t.Fatalf("%d/%d: signature at %d did not verify: %v", split[0], split[1], i, err)
+			}
+		}
+		if _, err := x.Sign(msg); !errors.Is(err, cryptoerrors.ErrOTSIndexTooHigh) {
+			t.Errorf("%d/%d: Sign on exhausted key: got %v, want ErrOTSIndexTooHigh", split[0], split[1], err)
+		}
+		if x.GetIndex() != 1<<split[0] {
+			t.Errorf("%d/%d: exhausted index = %d", split[0], split[1], x.GetIndex())
+		}
+	}
+}
+
+// TestXMSSMTSetIndexMatchesSequential checks both SetIndex paths, the
+// replayed short move and the rebuild, against a key that signed its
+// way to the same index.
+func TestXMSSMTSetIndexMatchesSequential(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow XMSS^MT SetIndex test in short mode")
+	}
+	msg := []uint8("set index")
+	targets := []uint64{1, 5, 16, 17, 100, 255, 500, 1023}
+
+	seq := newTestXMSSMT(t, 10, 2)
+	want := make(map[uint64][]uint8)
+	for i := uint64(0); i <= targets[len(targets)-1]; i++ {
+		sig, err := seq.Sign(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want[i] = sig
+	}
+
+	for _, target := range targets {
+		x := newTestXMSSMT(t, 10, 2)
+		if err := x.SetIndex(target); err != nil {
+			t.Fatalf("SetIndex(%d) failed: %v", target, err)
+		}
+		// Sign a few past the target so the rebuilt next trees are used.
+		for i := target; i < target+40 && i < 1<<10; i++ {
+			sig, err := x.Sign(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if w, ok := want[i]; ok && !bytes.Equal(sig, w) {
+				t.Fatalf("SetIndex(%d): signature at %d differs from sequential signing", target, i)
+			}
+			if !VerifyMT(SHAKE_128, 10, 2, msg, sig, mtPK(x)) {
+				t.Fatalf("SetIndex(%d): signature at %d did not verify", target, i)
+			}
+		}
+	}
+}
+
+func TestXMSSMTSetIndexErrors(t *testing.T) {
+	x := newTestXMSSMT(t, 8, 2)
+	if err := x.SetIndex(1 << 8); !errors.Is(err, cryptoerrors.ErrOTSIndexTooHigh) {
+		t.Errorf("SetIndex(2^h): got %v, want ErrOTSIndexTooHigh", err)
+	}
+	if err := x.SetIndex(10); err != nil {
+		t.Fatal(err)
+	}
+	if err := x.SetIndex(9); !errors.Is(err, cryptoerrors.ErrOTSIndexRewind) {
+		t.Errorf("rewind: got %v, want ErrOTSIndexRewind", err)
+	}
+	if x.GetIndex() != 10 {
+		t.Errorf("failed SetIndex moved the index to %d", x.GetIndex())
+	}
+}
+
+// TestXMSSMTBottomLayerMatchesXMSS checks that the first bottom tree is
+// the single-tree XMSS of the same height and seed, whose addresses
+// are all on layer 0, tree 0.
+func TestXMSSMTBottomLayerMatchesXMSS(t *testing.T) {
+	var expanded [96]uint8
+	for i := range expanded {
+		expanded[i] = uint8(i)
+	}
+	mt, err := InitializeMultiTreeFromExpandedSeed(8, 2, SHA2_256, &expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	single, err := InitializeTreeFromExpandedSeed(4, SHA2_256, &expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ib := mtIndexBytes(8)
+	n := WOTSParamN
+	keySize := mt.xmssParams.wotsParams.keySize
+	for i := 0; i < 1<<4; i++ {
+		mtSig, err := mt.Sign([]uint8("m"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := single.Sign([]uint8("m"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mtAuth := mtSig[ib+n+keySize : ib+n+keySize+4*n]
+		auth := sig[4+n+keySize:]
+		if !bytes.Equal(mtAuth, auth) {
+			t.Fatalf("bottom-layer auth path at %d differs from XMSS h=4", i)
+		}
+	}
+}
+
+func TestVerifyMTRejects(t *testing.T) {
+	x := newTestXMSSMT(t, 8, 2)
+	pk := mtPK(x)
+	msg := []uint8("message")
+	sig, err := x.Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := append([]uint8(nil), sig...)
+	tampered[len(tampered)/2] ^= 1
+	if err := VerifyMTDetailed(SHAKE_128, 8, 2, msg, tampered, pk); !errors.Is(err, cryptoerrors.ErrInvalidSignature) {
+		t.Errorf("tampered signature: got %v", err)
+	}
+	if err := VerifyMTDetailed(SHAKE_128, 8, 2, []uint8("other"), sig, pk); !errors.Is(err, cryptoerrors.ErrInvalidSignature) {
+		t.Errorf("wrong message: got %v", err)
+	}
+	if err := VerifyMTDetailed(SHAKE_128, 8, 2, msg, sig[:len(sig)-1], pk); !errors.Is(err, cryptoerrors.ErrInvalidSignatureSize) {
+		t.Errorf("short signature: got %v", err)
+	}
+	if err := VerifyMTDetailed(SHAKE_128, 8, 2, msg, sig, pk[:40]); !errors.Is(err, cryptoerrors.ErrInvalidPublicKey) {
+		t.Errorf("short pk: got %v", err)
+	}
+	if err := VerifyMTDetailed(SHAKE_256, 8, 2, msg, sig, pk); !errors.Is(err, cryptoerrors.ErrInvalidSignature) {
+		t.Errorf("wrong hash function: got %v", err)
+	}
+	if err := VerifyMTDetailed(HashFunction(99), 8, 2, msg, sig, pk); !errors.Is(err, cryptoerrors.ErrInvalidHashFunction) {
+		t.Errorf("invalid hash function: got %v", err)
+	}
+	if VerifyMT(SHAKE_128, 8, 4, msg, sig, pk) {
+		t.Error("signature verified under the wrong split")
+	}
+}
+
+func TestXMSSMTVerifyAfterSignAndZeroize(t *testing.T) {
+	x := newTestXMSSMT(t, 8, 2)
+	x.SetVerifyAfterSign(true)
+	if _, err := x.Sign([]uint8("checked")); err != nil {
+		t.Fatalf("Sign with verify-after-sign failed: %v", err)
+	}
+
+	x.Zeroize()
+	for _, b := range x.GetSK() {
+		if b != 0 {
+			t.Fatal("Zeroize left secret key bytes")
+		}
+	}
+	for _, b := range x.wotsSigs {
+		if b != 0 {
+			t.Fatal("Zeroize left cached WOTS+ signatures")
+		}
+	}
+}
+
+func TestSetMTIndexBytesExhausted(t *testing.T) {
+	sk := make([]uint8, 5)
+	setMTIndexBytes(sk, 40, 1<<40)
+	if !bytes.Equal(sk, []uint8{0xff, 0xff, 0xff, 0xff, 0xff}) {
+		t.Errorf("exhausted index bytes = %x", sk)
+	}
+	setMTIndexBytes(sk, 40, 0x0102030405)
+	if !bytes.Equal(sk, []uint8{1, 2, 3, 4, 5}) {
+		t.Errorf("index bytes = %x", sk)
+	}
+}
@@ -1,9 +1,11 @@
 package xmss
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/theQRL/go-qrllib/common"
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
 	xmsscrypto "github.com/theQRL/go-qrllib/crypto/xmss"
 )
 
@@ -101,6 +103,9 @@ func TestVerify_HeightMismatch(t *testing.T) {
 	if Verify(message, sig, pk) {
 		t.Error("expected Verify to return false for height mismatch")
 	}
+	if err := VerifyDetailed(message, sig, pk); !errors.Is(err, cryptoerrors.ErrHeightMismatch) {
+		t.Errorf("VerifyDetailed returned %v, want ErrHeightMismatch", err)
+	}
 }
 
 func TestVerify_InvalidDescriptor(t *testing.T) {
@@ -111,9 +116,12 @@ func TestVerify_InvalidDescriptor(t *testing.T) {
 	message := []byte("test")
 	// Need a signature of valid size - for height 4, it's specific size
 	// Just use any signature, it should fail at descriptor parsing first
-	sig := make([]byte, 2287) // Some size that gives valid height
+	sig := make([]byte, 2308) // Height 4: 4 + 32 + 67*32 + 4*32 bytes
 
 	if Verify(message, sig, pk) {
 		t.Error("expected Verify to return false for invalid descriptor")
 	}
+	if err := VerifyDetailed(message, sig, pk); !errors.Is(err, cryptoerrors.ErrDescriptorMismatch) {
+		t.Errorf("VerifyDetailed returned %v, want ErrDescriptorMismatch", err)
+	}
 }
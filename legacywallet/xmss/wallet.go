@@ -164,6 +164,14 @@ func (w *XMSSWallet) GetIndex() uint32 {
 	return w.xmss.GetIndex()
 }
 
+// SetVerifyAfterSign enables or disables verifying each signature
+// under the wallet's own public key before Sign returns it; see
+// [xmss.XMSS.SetVerifyAfterSign]. A failed check still consumes the
+// OTS index.
+func (w *XMSSWallet) SetVerifyAfterSign(enabled bool) {
+	w.xmss.SetVerifyAfterSign(enabled)
+}
+
 // Sign produces an XMSS signature over message and advances the
 // wallet's internal one-time-signature (OTS) index by one.
 //
@@ -210,28 +218,44 @@ func (w *XMSSWallet) Zeroize() {
 	}
 }
 
+// Verify reports whether signature is a valid XMSS signature over
+// message under extendedPK, whose descriptor must match the
+// signature's tree height. Use [VerifyDetailed] to learn why a
+// signature was rejected.
 func Verify(message, signature []uint8, extendedPK [ExtendedPKSize]uint8) (result bool) {
+	return VerifyDetailed(message, signature, extendedPK) == nil
+}
+
+// VerifyDetailed is [Verify] returning the reason for a rejection
+// instead of false. It returns nil for a valid signature,
+// [cryptoerrors.ErrInvalidSignatureSize] if the signature length does
+// not correspond to a tree height, [cryptoerrors.ErrDescriptorMismatch]
+// if the descriptor in extendedPK is not a valid XMSS descriptor,
+// [cryptoerrors.ErrHeightMismatch] if the signature's height differs
+// from the descriptor's, and otherwise the error from
+// [xmss.VerifyDetailed].
+func VerifyDetailed(message, signature []uint8, extendedPK [ExtendedPKSize]uint8) error {
 	height, err := xmss.GetHeightFromSigSize(uint32(len(signature)), xmss.WOTSParamW)
 	if err != nil {
-		return false
+		return err
 	}
 
 	desc, err := NewQRLDescriptorFromExtendedPK(&extendedPK)
 	if err != nil {
-		return false
+		return fmt.Errorf("%w: %w", cryptoerrors.ErrDescriptorMismatch, err)
 	}
 
 	if desc.GetSignatureType() != legacywallet.WalletTypeXMSS {
 		//coverage:ignore
 		//rationale: NewQRLDescriptorFromExtendedPK validates signature type, only XMSS (0) is accepted
-		return false
+		return cryptoerrors.ErrDescriptorMismatch
 	}
 
 	if desc.GetHeight() != height {
-		return false
+		return cryptoerrors.ErrHeightMismatch
 	}
 
 	pk := extendedPK[DescriptorSize:]
 
-	return xmss.Verify(desc.hashFunction, message, signature, pk)
+	return xmss.VerifyDetailed(desc.hashFunction, message, signature, pk)
 }
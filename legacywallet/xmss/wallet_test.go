@@ -539,3 +539,20 @@ func TestLegacyQRLDescriptorFromExtendedPK(t *testing.T) {
 		t.Errorf("height mismatch: got %d, want 4", desc.GetHeight())
 	}
 }
+
+func TestXMSS_VerifyAfterSign(t *testing.T) {
+	xmss := newTestXMSSWallet(t, 4)
+	xmss.SetVerifyAfterSign(true)
+
+	message := []uint8("verify after sign")
+	signature, err := xmss.Sign(message)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if !Verify(message, signature, xmss.GetPK()) {
+		t.Error("signature verification failed")
+	}
+	if xmss.GetIndex() != 1 {
+		t.Errorf("index = %d, want 1", xmss.GetIndex())
+	}
+}
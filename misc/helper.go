@@ -57,6 +57,18 @@ func SHA256(out, msg []byte) []byte {
 	return out
 }
 
+// SetLayerAddr sets the XMSS^MT layer word of an address.
+func SetLayerAddr(addr *[8]uint32, layer uint32) {
+	addr[0] = layer
+}
+
+// SetTreeAddr sets the 64-bit XMSS^MT tree address, most significant
+// word first.
+func SetTreeAddr(addr *[8]uint32, tree uint64) {
+	addr[1] = uint32(tree >> 32)
+	addr[2] = uint32(tree)
+}
+
 func SetType(addr *[8]uint32, typeValue uint32) {
 	addr[3] = typeValue
 	for i := 4; i < 8; i++ {
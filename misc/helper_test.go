@@ -358,4 +358,13 @@ func TestAddressSetters(t *testing.T) {
 	if addr[7] != 1 {
 		t.Errorf("SetKeyAndMask: got %d, want 1", addr[7])
 	}
+
+	// Layer and tree words survive SetType
+	addr = [8]uint32{}
+	SetLayerAddr(&addr, 3)
+	SetTreeAddr(&addr, 0x0000000a_0000000b)
+	SetType(&addr, 2)
+	if addr[0] != 3 || addr[1] != 0xa || addr[2] != 0xb {
+		t.Errorf("SetLayerAddr/SetTreeAddr: got %v", addr[:3])
+	}
 }
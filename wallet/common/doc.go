@@ -54,4 +54,11 @@
 //   - XMSS:         Direct use of seed bytes
 //
 // This allows the same mnemonic to generate different wallet types.
+//
+// # Hierarchical Derivation
+//
+// [Seed.DeriveChild] and [Seed.DerivePath] derive child seeds along
+// hardened-only paths ([ParseDerivationPath], [NewDerivationPath]) with
+// KMAC256 from NIST SP 800-185. A child seed is an ordinary Seed and can
+// back a wallet of any type.
 package common
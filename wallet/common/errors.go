@@ -30,11 +30,24 @@ const (
 // [github.com/theQRL/go-qrllib/wallet/common/wallettype.WalletType.IsIssuable].
 //
 // ErrWalletTypeNotVerifiable is the equivalent for wallet-level Verify
-// dispatch. Today wallet-level Verify functions return false rather than
-// surfacing this error directly (Verify's signature is a bool), but the
-// sentinel is exposed for callers that need to distinguish "signature
-// invalid" from "wallet type not currently supported".
+// dispatch. Wallet-level Verify functions return false in that case
+// (Verify's signature is a bool); the VerifyDetailed variants return
+// this sentinel so callers can distinguish "signature invalid" from
+// "wallet type not currently supported".
 var (
 	ErrWalletTypeNotIssuable   = errors.New("wallet type is not currently issuable")
 	ErrWalletTypeNotVerifiable = errors.New("wallet type is not currently verifiable")
 )
+
+// Sentinel errors for hierarchical deterministic derivation. Compare
+// with errors.Is.
+//
+// ErrInvalidDerivationPath is returned for a path that is not of the
+// form m/a'/b'/... with each index below HardenedOffset.
+// ErrNonHardenedDerivation is returned for a non-hardened index: seeds
+// have no public counterpart to derive from, so only hardened
+// derivation is defined.
+var (
+	ErrInvalidDerivationPath = errors.New("invalid derivation path")
+	ErrNonHardenedDerivation = errors.New("non-hardened derivation is not supported")
+)
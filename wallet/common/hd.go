@@ -0,0 +1,166 @@
+package common
+
+import (
+	"crypto/sha3"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// HardenedOffset is added to a path component to mark it hardened,
+	// as in BIP-32. Only hardened components are accepted.
+	HardenedOffset uint32 = 0x80000000
+
+	// HDPurpose and HDCoinType are the first two components of the
+	// default path m/44'/238'/account'/index' (BIP-44 purpose, SLIP-44
+	// coin type for QRL).
+	HDPurpose  uint32 = 44
+	HDCoinType uint32 = 238
+)
+
+// hdCustomization is the KMAC256 customization string for child seed
+// derivation. Changing it changes every derived seed.
+const hdCustomization = "QRL HD seed v1"
+
+// DerivationPath is a sequence of hardened child indices, each stored
+// with HardenedOffset added.
+type DerivationPath []uint32
+
+// NewDerivationPath returns m/44'/238'/account'/index'.
+func NewDerivationPath(account, index uint32) (DerivationPath, error) {
+	if account >= HardenedOffset || index >= HardenedOffset {
+		return nil, ErrInvalidDerivationPath
+	}
+	return DerivationPath{
+		HardenedOffset + HDPurpose,
+		HardenedOffset + HDCoinType,
+		HardenedOffset + account,
+		HardenedOffset + index,
+	}, nil
+}
+
+// ParseDerivationPath parses a path of the form m/44'/238'/0'/0'.
+// Every component must be hardened, marked with ' or h, and below
+// HardenedOffset before marking. "m" alone is the empty path.
+func ParseDerivationPath(s string) (DerivationPath, error) {
+	parts := strings.Split(s, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("%w: %q must start with m", ErrInvalidDerivationPath, s)
+	}
+	path := make(DerivationPath, 0, len(parts)-1)
+	for _, p := range parts[1:] {
+		if p == "" {
+			return nil, fmt.Errorf("%w: empty component in %q", ErrInvalidDerivationPath, s)
+		}
+		digits, hardened := strings.CutSuffix(p, "'")
+		if !hardened {
+			digits, hardened = strings.CutSuffix(p, "h")
+		}
+		if !hardened {
+			return nil, fmt.Errorf("%w: %q", ErrNonHardenedDerivation, p)
+		}
+		// Reject signs and leading zeros so every index has exactly one
+		// spelling.
+		if digits == "" || digits[0] < '0' || digits[0] > '9' || (len(digits) > 1 && digits[0] == '0') {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidDerivationPath, p)
+		}
+		n, err := strconv.ParseUint(digits, 10, 32)
+		if err != nil || uint32(n) >= HardenedOffset {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidDerivationPath, p)
+		}
+		path = append(path, HardenedOffset+uint32(n))
+	}
+	return path, nil
+}
+
+// String returns p in the m/44'/238'/0'/0' form.
+func (p DerivationPath) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, c := range p {
+		b.WriteString("/")
+		b.WriteString(strconv.FormatUint(uint64(c&^HardenedOffset), 10))
+		if c >= HardenedOffset {
+			b.WriteString("'")
+		}
+	}
+	return b.String()
+}
+
+// DeriveChild returns the hardened child of s at index, which must
+// include HardenedOffset:
+//
+//	child = KMAC256(K = s, X = BE32(index), L = 384, S = "QRL HD seed v1")
+//
+// The child is an ordinary Seed and works with every wallet type. A
+// child does not reveal its parent or siblings.
+func (s Seed) DeriveChild(index uint32) (Seed, error) {
+	if index < HardenedOffset {
+		return Seed{}, ErrNonHardenedDerivation
+	}
+	var data [4]byte
+	binary.BigEndian.PutUint32(data[:], index)
+	var child Seed
+	kmac256(child[:], s[:], data[:], []byte(hdCustomization))
+	return child, nil
+}
+
+// DerivePath applies DeriveChild for each component of path in turn.
+// The empty path returns s.
+func (s Seed) DerivePath(path DerivationPath) (Seed, error) {
+	seed := s
+	for _, index := range path {
+		child, err := seed.DeriveChild(index)
+		clear(seed[:])
+		if err != nil {
+			return Seed{}, err
+		}
+		seed = child
+	}
+	return seed, nil
+}
+
+// kmac256 writes KMAC256(key, data, 8*len(out), custom) to out, as
+// defined in NIST SP 800-185 §4.
+func kmac256(out, key, data, custom []byte) {
+	const rate = 136
+	h := sha3.NewCSHAKE256([]byte("KMAC"), custom)
+
+	// bytepad(encode_string(key), rate)
+	prefix := leftEncode(rate)
+	prefix = append(prefix, leftEncode(uint64(len(key))*8)...)
+	_, _ = h.Write(prefix)
+	_, _ = h.Write(key)
+	if pad := (len(prefix) + len(key)) % rate; pad != 0 {
+		_, _ = h.Write(make([]byte, rate-pad))
+	}
+
+	_, _ = h.Write(data)
+	_, _ = h.Write(rightEncode(uint64(len(out)) * 8))
+	_, _ = h.Read(out)
+}
+
+// leftEncode and rightEncode are the integer encodings of NIST SP
+// 800-185 §2.3.1: the minimal big-endian bytes of x, with their count
+// before (left) or after (right).
+func leftEncode(x uint64) []byte {
+	b := minimalBigEndian(x)
+	return append([]byte{byte(len(b))}, b...)
+}
+
+func rightEncode(x uint64) []byte {
+	b := minimalBigEndian(x)
+	return append(b, byte(len(b)))
+}
+
+func minimalBigEndian(x uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], x)
+	i := 0
+	for i < 7 && buf[i] == 0 {
+		i++
+	}
+	return append([]byte(nil), buf[i:]...)
+}
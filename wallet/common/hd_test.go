@@ -0,0 +1,138 @@
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// TestKMAC256 checks kmac256 against NIST SP 800-185 KMAC sample #4.
+func TestKMAC256(t *testing.T) {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(0x40 + i)
+	}
+	want, _ := hex.DecodeString("20c570c31346f703c9ac36c61c03cb64c3970d0cfc787e9b79599d273a68d2f7" +
+		"f69d4cc3de9d104a351689f27cf6f5951f0103f33f4f24871024d9c27773a8dd")
+	got := make([]byte, 64)
+	kmac256(got, key, []byte{0, 1, 2, 3}, []byte("My Tagged Application"))
+	if !bytes.Equal(got, want) {
+		t.Errorf("KMAC256 = %x, want %x", got, want)
+	}
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	h := HardenedOffset
+	tests := []struct {
+		path string
+		want DerivationPath
+		err  error
+	}{
+		{"m", DerivationPath{}, nil},
+		{"m/44'/238'/0'/0'", DerivationPath{h + 44, h + 238, h, h}, nil},
+		{"m/44h/238h/7h/2147483647h", DerivationPath{h + 44, h + 238, h + 7, h + 0x7fffffff}, nil},
+		{"", nil, ErrInvalidDerivationPath},
+		{"M/44'", nil, ErrInvalidDerivationPath},
+		{"44'/238'", nil, ErrInvalidDerivationPath},
+		{"m/", nil, ErrInvalidDerivationPath},
+		{"m/44'//0'", nil, ErrInvalidDerivationPath},
+		{"m/'", nil, ErrInvalidDerivationPath},
+		{"m/01'", nil, ErrInvalidDerivationPath},
+		{"m/+1'", nil, ErrInvalidDerivationPath},
+		{"m/x'", nil, ErrInvalidDerivationPath},
+		{"m/2147483648'", nil, ErrInvalidDerivationPath},
+		{"m/44'/238'/0'/0", nil, ErrNonHardenedDerivation},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			got, err := ParseDerivationPath(tc.path)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("error = %v, want %v", err, tc.err)
+			}
+			if err != nil {
+				return
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("path = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("path = %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDerivationPathString(t *testing.T) {
+	p, err := NewDerivationPath(3, 9)
+	if err != nil {
+		t.Fatalf("NewDerivationPath: %v", err)
+	}
+	if s := p.String(); s != "m/44'/238'/3'/9'" {
+		t.Errorf("String = %q", s)
+	}
+	if s := (DerivationPath{}).String(); s != "m" {
+		t.Errorf("empty String = %q", s)
+	}
+	if _, err := NewDerivationPath(HardenedOffset, 0); !errors.Is(err, ErrInvalidDerivationPath) {
+		t.Errorf("account overflow error = %v", err)
+	}
+	if _, err := NewDerivationPath(0, HardenedOffset); !errors.Is(err, ErrInvalidDerivationPath) {
+		t.Errorf("index overflow error = %v", err)
+	}
+}
+
+func TestDeriveChild(t *testing.T) {
+	var master Seed
+	for i := range master {
+		master[i] = byte(i)
+	}
+
+	// Test vectors for m/44'/238'/account'/index' below the seed
+	// 000102...2f.
+	vectors := []struct {
+		account, index uint32
+		want           string
+	}{
+		{0, 0, "91c4fca4418050bf5451a47c142a58993300e5350a02ee3233080a0decf950898d5321c3ab693247c9360232f999c2f9"},
+		{0, 1, "c649b99232ad70053892115278546b13ba701b4b8fce168b0b89aff83ebb209bbda71e765d0796e2d275dd4bbe9a98a2"},
+		{1, 0, "9fbd75c620028c9171c284e4fe73220bc913fabb0d4f207de52ef01ee5aecdf71d7512e6dd1f13ad755913b2fad3ad50"},
+	}
+	for _, v := range vectors {
+		p, err := NewDerivationPath(v.account, v.index)
+		if err != nil {
+			t.Fatalf("NewDerivationPath: %v", err)
+		}
+		child, err := master.DerivePath(p)
+		if err != nil {
+			t.Fatalf("DerivePath(%s): %v", p, err)
+		}
+		if got := hex.EncodeToString(child[:]); got != v.want {
+			t.Errorf("DerivePath(%s) = %s, want %s", p, got, v.want)
+		}
+
+		// Deriving one step at a time gives the same seed.
+		step := master
+		for _, index := range p {
+			step, err = step.DeriveChild(index)
+			if err != nil {
+				t.Fatalf("DeriveChild: %v", err)
+			}
+		}
+		if step != child {
+			t.Errorf("stepwise derivation of %s differs", p)
+		}
+	}
+
+	if child, err := master.DerivePath(nil); err != nil || child != master {
+		t.Errorf("empty path = %x, %v; want the master seed", child, err)
+	}
+	if _, err := master.DeriveChild(0); !errors.Is(err, ErrNonHardenedDerivation) {
+		t.Errorf("non-hardened DeriveChild error = %v", err)
+	}
+	if _, err := master.DerivePath(DerivationPath{HardenedOffset, 1}); !errors.Is(err, ErrNonHardenedDerivation) {
+		t.Errorf("non-hardened DerivePath error = %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package ml_dsa_87
+
+import (
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+	"github.com/theQRL/go-qrllib/wallet/common"
+	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
+)
+
+// BatchItem is one (message, signature, public key, descriptor) tuple
+// for [VerifyBatch], with the same meaning as the arguments of
+// [Verify].
+type BatchItem struct {
+	Message    []uint8
+	Signature  []uint8
+	PK         *PK
+	Descriptor [descriptor.DescriptorSize]byte
+}
+
+// VerifyBatch verifies every item and returns one result per item, in
+// order: results[i] is exactly what [Verify] would return for
+// items[i]. Items with a nil PK, an invalid descriptor or a
+// wrong-sized signature are reported as false without affecting the
+// other items.
+//
+// The remaining items are verified concurrently by
+// [ml_dsa_87.VerifyBatch], which shares expanded public-key state
+// between items that repeat a key.
+func VerifyBatch(items []BatchItem) []bool {
+	results := make([]bool, len(items))
+	batch := make([]ml_dsa_87.BatchItem, 0, len(items))
+	index := make([]int, 0, len(items))
+
+	for i, item := range items {
+		if item.PK == nil || len(item.Signature) != SigSize {
+			continue
+		}
+		d, err := NewMLDSA87DescriptorFromDescriptorBytes(item.Descriptor)
+		if err != nil {
+			continue
+		}
+		b := ml_dsa_87.BatchItem{
+			PK:      (*[ml_dsa_87.CRYPTO_PUBLIC_KEY_BYTES]uint8)(item.PK),
+			Context: common.SigningContext(d.ToDescriptor()),
+			Message: item.Message,
+		}
+		copy(b.Signature[:], item.Signature)
+		batch = append(batch, b)
+		index = append(index, i)
+	}
+
+	for j, ok := range ml_dsa_87.VerifyBatch(batch) {
+		results[index[j]] = ok
+	}
+	return results
+}
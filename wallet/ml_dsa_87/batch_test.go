@@ -0,0 +1,47 @@
+package ml_dsa_87
+
+import (
+	"testing"
+
+	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
+)
+
+func TestVerifyBatch(t *testing.T) {
+	w, err := NewWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Zeroize()
+	pk := w.GetPK()
+	desc := w.GetDescriptor().ToDescriptor()
+
+	msgs := [][]byte{[]byte("tx-0"), []byte("tx-1"), []byte("tx-2"), []byte("tx-3"), []byte("tx-4")}
+	items := make([]BatchItem, len(msgs))
+	for i, msg := range msgs {
+		sig, err := w.Sign(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		items[i] = BatchItem{Message: msg, Signature: sig[:], PK: &pk, Descriptor: desc}
+	}
+
+	items[1].PK = nil
+	items[2].Signature = items[2].Signature[:SigSize-1]
+	items[3].Descriptor = descriptor.Descriptor{0xff, 0x00, 0x00}
+	items[4].Message = []byte("tampered")
+
+	got := VerifyBatch(items)
+	want := []bool{true, false, false, false, false}
+	for i := range items {
+		if got[i] != want[i] {
+			t.Errorf("item %d: got %v, want %v", i, got[i], want[i])
+		}
+		if single := Verify(items[i].Message, items[i].Signature, items[i].PK, items[i].Descriptor); got[i] != single {
+			t.Errorf("item %d: batch result %v differs from Verify %v", i, got[i], single)
+		}
+	}
+
+	if got := VerifyBatch(nil); len(got) != 0 {
+		t.Errorf("VerifyBatch(nil) returned %d results", len(got))
+	}
+}
@@ -44,6 +44,19 @@
 //
 //	Common Seed (48 bytes) → SHA-256 → ML-DSA-87 Seed (32 bytes) → Keypair
 //
+// # Hierarchical Derivation
+//
+// [Wallet.DeriveChild] derives further wallets from one seed along
+// hardened paths such as m/44'/238'/account'/index', so a single
+// mnemonic backs up any number of addresses. Each step is
+//
+//	child seed = KMAC256(K = parent seed, X = BE32(index), L = 384, S = "QRL HD seed v1")
+//
+// and the child is then an ordinary wallet built from that seed; see
+// [github.com/theQRL/go-qrllib/wallet/common.Seed.DeriveChild].
+// Non-hardened derivation is not defined: there is no public-key-only
+// path for watch-only wallets.
+//
 // # Signing Mode
 //
 // Wallet signing is hedged by default as per FIPS 204: each call to
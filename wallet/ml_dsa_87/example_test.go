@@ -103,3 +103,30 @@ func ExampleVerify() {
 	// Valid: true
 	// After tampering: false
 }
+
+// ExampleWallet_DeriveChild derives deposit addresses from one master
+// wallet, so a single mnemonic backup covers all of them.
+func ExampleWallet_DeriveChild() {
+	master, err := ml_dsa_87.NewWallet()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer master.Zeroize()
+
+	first, err := master.DeriveChild("m/44'/238'/0'/0'")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	again, err := master.DeriveAccountAddress(0, 0)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println("Same address:", first.GetAddressStr() == again.GetAddressStr())
+	fmt.Println("Differs from master:", first.GetAddressStr() != master.GetAddressStr())
+	// Output:
+	// Same address: true
+	// Differs from master: true
+}
@@ -0,0 +1,48 @@
+package ml_dsa_87
+
+import (
+	"fmt"
+
+	"github.com/theQRL/go-qrllib/wallet/common"
+	"github.com/theQRL/go-qrllib/wallet/common/wallettype"
+)
+
+// DeriveChild returns the wallet at path below w's seed, for example
+// "m/44'/238'/0'/5'". Only hardened components are accepted; see
+// [common.Seed.DerivePath] for the derivation. The child is an
+// independent wallet: its seed, mnemonic and address are those of
+// the derived seed, and w itself is the wallet at "m".
+//
+// Deriving the same path from the same seed always gives the same
+// wallet, so one backup of w's mnemonic recovers every child.
+func (w *Wallet) DeriveChild(path string) (*Wallet, error) {
+	p, err := common.ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return w.derivePath(p)
+}
+
+// DeriveAccountAddress returns the wallet at m/44'/238'/account'/index'
+// below w's seed.
+func (w *Wallet) DeriveAccountAddress(account, index uint32) (*Wallet, error) {
+	p, err := common.NewDerivationPath(account, index)
+	if err != nil {
+		return nil, err
+	}
+	return w.derivePath(p)
+}
+
+func (w *Wallet) derivePath(p common.DerivationPath) (*Wallet, error) {
+	seed, err := w.seed.DerivePath(p)
+	if err != nil {
+		//coverage:ignore
+		//rationale: ParseDerivationPath and NewDerivationPath only return hardened paths
+		return nil, fmt.Errorf("failed to derive %s child %s: %w", wallettype.ML_DSA_87, p, err)
+	}
+	child, err := NewWalletFromSeed(seed)
+	for i := range seed {
+		seed[i] = 0
+	}
+	return child, err
+}
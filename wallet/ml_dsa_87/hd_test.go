@@ -0,0 +1,68 @@
+package ml_dsa_87
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/theQRL/go-qrllib/wallet/common"
+)
+
+func TestWallet_DeriveChild(t *testing.T) {
+	var seed common.Seed
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	master, err := NewWalletFromSeed(seed)
+	if err != nil {
+		t.Fatalf("NewWalletFromSeed() error: %v", err)
+	}
+
+	vectors := []struct {
+		path, address string
+	}{
+		{"m/44'/238'/0'/0'", "Q67ca33bbb0e57ab969cd8924d4e98ba875f0b33726f08032a5737e74a860481559621177791f6711c48b8c58c225d5bd3cda15de9a2a37b9c3994d260a008f82"},
+		{"m/44'/238'/0'/1'", "Qcf963a0a62ffbcdcaf5dac7cf49d878c89c6030636edf8a4e1025246356876cca20177259fb0b145c475bde255d0824eb0d12ccef8eac0c55f50be4438fd97a0"},
+		{"m/44'/238'/1'/0'", "Q9f7009b85633295089d6703b0e2e52fee256a95d945ba5de46800bc7e495ea35656972f0a64b0faf21bde0524f7219110f0aa68ce3964b79f3f7d3a723af8adc"},
+	}
+	for _, v := range vectors {
+		child, err := master.DeriveChild(v.path)
+		if err != nil {
+			t.Fatalf("DeriveChild(%s) error: %v", v.path, err)
+		}
+		if got := child.GetAddressStr(); got != v.address {
+			t.Errorf("DeriveChild(%s) address = %s, want %s", v.path, got, v.address)
+		}
+
+		// The child is restorable from its own seed.
+		restored, err := NewWalletFromSeed(child.GetSeed())
+		if err != nil {
+			t.Fatalf("NewWalletFromSeed() error: %v", err)
+		}
+		if restored.GetAddressStr() != v.address {
+			t.Errorf("restored %s address = %s", v.path, restored.GetAddressStr())
+		}
+	}
+
+	child, err := master.DeriveAccountAddress(0, 1)
+	if err != nil {
+		t.Fatalf("DeriveAccountAddress() error: %v", err)
+	}
+	if child.GetAddressStr() != vectors[1].address {
+		t.Errorf("DeriveAccountAddress(0, 1) address = %s", child.GetAddressStr())
+	}
+
+	self, err := master.DeriveChild("m")
+	if err != nil || self.GetAddressStr() != master.GetAddressStr() {
+		t.Errorf(`DeriveChild("m") = %v, %v; want the master wallet`, self, err)
+	}
+
+	if _, err := master.DeriveChild("m/44'/238'/0'/0"); !errors.Is(err, common.ErrNonHardenedDerivation) {
+		t.Errorf("non-hardened path error = %v", err)
+	}
+	if _, err := master.DeriveChild("44'/238'"); !errors.Is(err, common.ErrInvalidDerivationPath) {
+		t.Errorf("malformed path error = %v", err)
+	}
+	if _, err := master.DeriveAccountAddress(common.HardenedOffset, 0); !errors.Is(err, common.ErrInvalidDerivationPath) {
+		t.Errorf("account overflow error = %v", err)
+	}
+}
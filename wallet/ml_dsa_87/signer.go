@@ -0,0 +1,89 @@
+package ml_dsa_87
+
+import (
+	"crypto"
+	"crypto/subtle"
+	"errors"
+	"io"
+
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+	"github.com/theQRL/go-qrllib/wallet/common"
+)
+
+var errUnsupportedSignerOpts = errors.New("wallet/ml_dsa_87: opts must not select a hash or carry a context; the signing context is bound to the descriptor")
+
+// CryptoPublicKey is a wallet public key for crypto.PublicKey
+// compatibility. It carries the descriptor because wallet signatures
+// are bound to the descriptor's signing context.
+type CryptoPublicKey struct {
+	pk   PK
+	desc Descriptor
+}
+
+// Equal reports whether x is a *CryptoPublicKey with the same key and
+// descriptor.
+func (pk *CryptoPublicKey) Equal(x crypto.PublicKey) bool {
+	other, ok := x.(*CryptoPublicKey)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare(pk.pk[:], other.pk[:]) == 1 && pk.desc == other.desc
+}
+
+// PK returns a copy of the raw public key.
+func (pk *CryptoPublicKey) PK() PK {
+	return pk.pk
+}
+
+// Descriptor returns the descriptor the key signs under.
+func (pk *CryptoPublicKey) Descriptor() Descriptor {
+	return pk.desc
+}
+
+// Verify reports whether signature is a valid wallet signature over
+// message under pk, as [Verify] does.
+func (pk *CryptoPublicKey) Verify(message, signature []uint8) bool {
+	return Verify(message, signature, &pk.pk, pk.desc.ToDescriptor())
+}
+
+// CryptoSigner wraps a Wallet to implement crypto.Signer and
+// crypto.MessageSigner. Signatures use the descriptor-bound signing
+// context, exactly like [Wallet.Sign].
+type CryptoSigner struct {
+	w *Wallet
+}
+
+// NewCryptoSigner returns a crypto.MessageSigner backed by the given Wallet.
+func NewCryptoSigner(w *Wallet) *CryptoSigner {
+	return &CryptoSigner{w: w}
+}
+
+func (s *CryptoSigner) Public() crypto.PublicKey {
+	return &CryptoPublicKey{pk: s.w.GetPK(), desc: s.w.desc}
+}
+
+// Sign implements crypto.Signer. digest is the message itself, not a
+// hash of it; Sign behaves exactly like [CryptoSigner.SignMessage].
+func (s *CryptoSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.SignMessage(rand, digest, opts)
+}
+
+// SignMessage implements crypto.MessageSigner. The context is always
+// the wallet's descriptor-bound signing context: opts may be nil or any
+// SignerOpts whose HashFunc is 0 (such as the crypto.Hash(0) passed by
+// crypto.SignMessage), but a *ml_dsa_87.SignerOpts carrying its own
+// context is rejected rather than silently ignored.
+//
+// The rand parameter, when non-nil, is the source of the
+// per-signature RND_BYTES; when nil, crypto/rand is used.
+func (s *CryptoSigner) SignMessage(rand io.Reader, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts != nil && opts.HashFunc() != 0 {
+		return nil, errUnsupportedSignerOpts
+	}
+	if o, ok := opts.(*ml_dsa_87.SignerOpts); ok && o != nil && len(o.Context) > 0 {
+		return nil, errUnsupportedSignerOpts
+	}
+	return ml_dsa_87.NewCryptoSigner(s.w.d).SignMessage(rand, msg, &ml_dsa_87.SignerOpts{
+		Context: common.SigningContext(s.w.desc.ToDescriptor()),
+	})
+}
@@ -0,0 +1,125 @@
+package ml_dsa_87
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"testing"
+
+	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+	"github.com/theQRL/go-qrllib/wallet/common"
+)
+
+// zeroReader is an io.Reader that always returns zero bytes, making
+// hedged signing reproducible.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestCryptoSigner(t *testing.T) {
+	w, err := NewWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Zeroize()
+	signer := NewCryptoSigner(w)
+	var _ crypto.MessageSigner = signer
+
+	pub, ok := signer.Public().(*CryptoPublicKey)
+	if !ok {
+		t.Fatal("Public() did not return *CryptoPublicKey")
+	}
+	if pub.PK() != w.GetPK() || pub.Descriptor() != w.GetDescriptor() {
+		t.Error("Public key or descriptor mismatch between CryptoSigner and Wallet")
+	}
+
+	msg := []byte("wallet message signer test")
+	sig, err := crypto.SignMessage(signer, nil, msg, nil)
+	if err != nil {
+		t.Fatalf("crypto.SignMessage failed: %v", err)
+	}
+	pk := w.GetPK()
+	if !Verify(msg, sig, &pk, w.GetDescriptor().ToDescriptor()) {
+		t.Error("CryptoSigner signature failed wallet verification")
+	}
+	if !pub.Verify(msg, sig) {
+		t.Error("CryptoPublicKey.Verify rejected a valid signature")
+	}
+
+	// The signature is bound to the descriptor context: it does not
+	// verify as a plain empty-context ML-DSA-87 signature.
+	var sigArr [SigSize]uint8
+	copy(sigArr[:], sig)
+	pkArr := [ml_dsa_87.CRYPTO_PUBLIC_KEY_BYTES]uint8(pk)
+	if ml_dsa_87.Verify(nil, msg, sigArr, &pkArr) {
+		t.Error("wallet signature verified under an empty context")
+	}
+	if !ml_dsa_87.Verify(common.SigningContext(w.GetDescriptor().ToDescriptor()), msg, sigArr, &pkArr) {
+		t.Error("wallet signature failed under the descriptor signing context")
+	}
+
+	viaSign, err := signer.Sign(zeroReader{}, msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	viaSignMessage, err := signer.SignMessage(zeroReader{}, msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(viaSign, viaSignMessage) {
+		t.Error("Sign and SignMessage produced different signatures from the same rand")
+	}
+
+	// crypto.Hash(0) is how generic callers ask for pure signing.
+	viaZeroHash, err := crypto.SignMessage(signer, zeroReader{}, msg, crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("crypto.SignMessage with crypto.Hash(0) failed: %v", err)
+	}
+	if !bytes.Equal(viaSign, viaZeroHash) {
+		t.Error("crypto.Hash(0) opts produced a different signature than nil opts")
+	}
+}
+
+func TestCryptoSignerRejectsOpts(t *testing.T) {
+	w, err := NewWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Zeroize()
+	signer := NewCryptoSigner(w)
+
+	for _, opts := range []crypto.SignerOpts{crypto.SHA256, &ml_dsa_87.SignerOpts{Context: []byte("other")}} {
+		if _, err := signer.SignMessage(nil, []byte("m"), opts); !errors.Is(err, errUnsupportedSignerOpts) {
+			t.Errorf("SignMessage with %T: got %v, want %v", opts, err, errUnsupportedSignerOpts)
+		}
+	}
+}
+
+func TestCryptoPublicKeyEqual(t *testing.T) {
+	w1, err := NewWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w1.Zeroize()
+	w2, err := NewWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w2.Zeroize()
+
+	pub1 := NewCryptoSigner(w1).Public().(*CryptoPublicKey)
+	if !pub1.Equal(NewCryptoSigner(w1).Public()) {
+		t.Error("Equal should accept the same wallet's key")
+	}
+	if pub1.Equal(NewCryptoSigner(w2).Public()) {
+		t.Error("Equal should reject a different wallet's key")
+	}
+	if pub1.Equal(ml_dsa_87.NewCryptoSigner(w1.d).Public()) {
+		t.Error("Equal should reject a bare ml_dsa_87 key")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
 	"github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
 	"github.com/theQRL/go-qrllib/wallet/common"
 	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
@@ -189,6 +190,13 @@ func (w *Wallet) GetChecksumAddressStr() string {
 	return common.ToChecksumAddress(w.GetAddress())
 }
 
+// SetVerifyAfterSign enables or disables verifying each signature
+// under the wallet's own public key before Sign returns it; see
+// [ml_dsa_87.MLDSA87.SetVerifyAfterSign].
+func (w *Wallet) SetVerifyAfterSign(enabled bool) {
+	w.d.SetVerifyAfterSign(enabled)
+}
+
 // Sign produces an ML-DSA-87 signature over message using the
 // descriptor-bound signing context. Signing is hedged by default as per
 // FIPS 204: each call mixes fresh `crypto/rand` randomness into the per-signature
@@ -212,18 +220,29 @@ func (w *Wallet) Zeroize() {
 // Verify reports whether the signature is a valid ML-DSA-87 signature
 // over message under pk and the descriptor-bound signing context.
 // Returns false (rather than panicking) if pk is nil. (TOB-QRLLIB-11)
+// Use [VerifyDetailed] to learn why a signature was rejected.
 func Verify(message, signature []uint8, pk *PK, desc [descriptor.DescriptorSize]byte) (result bool) {
+	return VerifyDetailed(message, signature, pk, desc) == nil
+}
+
+// VerifyDetailed is [Verify] returning the reason for a rejection
+// instead of false. It returns nil for a valid signature,
+// [cryptoerrors.ErrPublicKeyNil] if pk is nil,
+// [cryptoerrors.ErrDescriptorMismatch] if desc is not an ML-DSA-87
+// descriptor, [cryptoerrors.ErrInvalidSignatureSize] if signature is
+// not SigSize bytes, and otherwise the error from
+// [ml_dsa_87.VerifyDetailed].
+func VerifyDetailed(message, signature []uint8, pk *PK, desc [descriptor.DescriptorSize]byte) error {
 	if pk == nil {
-		return false
+		return cryptoerrors.ErrPublicKeyNil
 	}
 	d, err := NewMLDSA87DescriptorFromDescriptorBytes(desc)
 	if err != nil {
-		return false
+		return fmt.Errorf("%w: %w", cryptoerrors.ErrDescriptorMismatch, err)
 	}
 
 	if len(signature) != SigSize {
-		// Invalid signature size - return false instead of panicking
-		return false
+		return cryptoerrors.ErrInvalidSignatureSize
 	}
 
 	var sig [SigSize]uint8
@@ -232,5 +251,5 @@ func Verify(message, signature []uint8, pk *PK, desc [descriptor.DescriptorSize]
 	var pk2 [ml_dsa_87.CRYPTO_PUBLIC_KEY_BYTES]uint8
 	copy(pk2[:], pk[:])
 
-	return ml_dsa_87.Verify(common.SigningContext(d.ToDescriptor()), message, sig, &pk2)
+	return ml_dsa_87.VerifyDetailed(common.SigningContext(d.ToDescriptor()), message, sig, &pk2)
 }
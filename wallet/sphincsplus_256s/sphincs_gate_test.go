@@ -140,5 +140,8 @@ func TestVerify_GatedWhenNotVerifiable(t *testing.T) {
 		if Verify(msg, sig[:], &pk, desc) {
 			t.Fatal("Verify accepted a signature with the gate closed; expected false")
 		}
+		if err := VerifyDetailed(msg, sig[:], &pk, desc); !errors.Is(err, common.ErrWalletTypeNotVerifiable) {
+			t.Fatalf("VerifyDetailed returned %v with the gate closed, want ErrWalletTypeNotVerifiable", err)
+		}
 	})
 }
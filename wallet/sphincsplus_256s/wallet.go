@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
 	"github.com/theQRL/go-qrllib/crypto/sphincsplus_256s"
 	"github.com/theQRL/go-qrllib/wallet/common"
 	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
@@ -276,6 +277,13 @@ func domainSeparatedMessage(desc descriptor.Descriptor, message []uint8) []uint8
 	return out
 }
 
+// SetVerifyAfterSign enables or disables verifying each signature
+// under the wallet's own public key before Sign returns it; see
+// [sphincsplus_256s.SphincsPlus256s.SetVerifyAfterSign].
+func (w *Wallet) SetVerifyAfterSign(enabled bool) {
+	w.s.SetVerifyAfterSign(enabled)
+}
+
 func (w *Wallet) Sign(message []uint8) ([SigSize]uint8, error) {
 	return w.s.Sign(domainSeparatedMessage(w.desc.ToDescriptor(), message))
 }
@@ -292,21 +300,33 @@ func (w *Wallet) Zeroize() {
 // Verify reports whether the signature is a valid SPHINCS+-256s signature
 // over message under pk and the descriptor-bound signing context.
 // Returns false (rather than panicking) if pk is nil. (TOB-QRLLIB-11)
+// Use [VerifyDetailed] to learn why a signature was rejected.
 func Verify(message, signature []uint8, pk *PK, desc [descriptor.DescriptorSize]byte) (result bool) {
+	return VerifyDetailed(message, signature, pk, desc) == nil
+}
+
+// VerifyDetailed is [Verify] returning the reason for a rejection
+// instead of false. It returns nil for a valid signature,
+// [common.ErrWalletTypeNotVerifiable] while SPHINCSPLUS_256S is not
+// verifiable, [cryptoerrors.ErrPublicKeyNil] if pk is nil,
+// [cryptoerrors.ErrDescriptorMismatch] if desc is not a SPHINCS+-256s
+// descriptor, [cryptoerrors.ErrInvalidSignatureSize] if signature is
+// not SigSize bytes, and otherwise the error from
+// [sphincsplus_256s.VerifyDetailed].
+func VerifyDetailed(message, signature []uint8, pk *PK, desc [descriptor.DescriptorSize]byte) error {
 	if !verifiable() {
-		return false
+		return common.ErrWalletTypeNotVerifiable
 	}
 	if pk == nil {
-		return false
+		return cryptoerrors.ErrPublicKeyNil
 	}
 	d, err := NewSphincsPlus256sDescriptorFromDescriptorBytes(desc)
 	if err != nil {
-		return false
+		return fmt.Errorf("%w: %w", cryptoerrors.ErrDescriptorMismatch, err)
 	}
 
 	if len(signature) != SigSize {
-		// Invalid signature size - return false instead of panicking
-		return false
+		return cryptoerrors.ErrInvalidSignatureSize
 	}
 
 	var sig [SigSize]uint8
@@ -315,5 +335,5 @@ func Verify(message, signature []uint8, pk *PK, desc [descriptor.DescriptorSize]
 	var pk2 [PKSize]uint8
 	copy(pk2[:], pk[:])
 
-	return sphincsplus_256s.Verify(domainSeparatedMessage(d.ToDescriptor(), message), sig, &pk2)
+	return sphincsplus_256s.VerifyDetailed(domainSeparatedMessage(d.ToDescriptor(), message), sig, &pk2)
 }
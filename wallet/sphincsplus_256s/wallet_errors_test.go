@@ -2,9 +2,11 @@ package sphincsplus_256s
 
 import (
 	"encoding/hex"
+	"errors"
 	"strings"
 	"testing"
 
+	cryptoerrors "github.com/theQRL/go-qrllib/crypto/errors"
 	"github.com/theQRL/go-qrllib/wallet/common"
 	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
 	"github.com/theQRL/go-qrllib/wallet/common/wallettype"
@@ -400,3 +402,44 @@ func TestNewWalletFromMnemonic_InvalidDescriptorTypeViaMnemonic(t *testing.T) {
 		t.Error("expected error when creating wallet from mnemonic with invalid descriptor type")
 	}
 }
+
+func TestVerifyDetailed(t *testing.T) {
+	wallet, err := NewWallet()
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+
+	message := []byte("test message")
+	sig, err := wallet.Sign(message)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	pk := wallet.GetPK()
+	desc := wallet.GetDescriptor().ToDescriptor()
+	wrongDesc := [descriptor.DescriptorSize]byte{byte(wallettype.ML_DSA_87), 0, 0}
+
+	tests := []struct {
+		name    string
+		message []byte
+		sig     []byte
+		pk      *PK
+		desc    [descriptor.DescriptorSize]byte
+		want    error
+	}{
+		{"nil_pk", message, sig[:], nil, desc, cryptoerrors.ErrPublicKeyNil},
+		{"wrong_descriptor", message, sig[:], &pk, wrongDesc, cryptoerrors.ErrDescriptorMismatch},
+		{"short_signature", message, sig[:SigSize-1], &pk, desc, cryptoerrors.ErrInvalidSignatureSize},
+		{"wrong_message", []byte("other"), sig[:], &pk, desc, cryptoerrors.ErrInvalidSignature},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := VerifyDetailed(tc.message, tc.sig, tc.pk, tc.desc); !errors.Is(err, tc.want) {
+				t.Errorf("VerifyDetailed error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	if err := VerifyDetailed(message, sig[:], &pk, desc); err != nil {
+		t.Errorf("VerifyDetailed(valid) = %v", err)
+	}
+}
@@ -475,3 +475,24 @@ func TestWallet_Zeroize(t *testing.T) {
 		t.Error("Seed was not zeroed after Zeroize()")
 	}
 }
+
+func TestWallet_VerifyAfterSign(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow SPHINCS+-256s sign test in -short mode")
+	}
+	wallet, err := NewWallet()
+	if err != nil {
+		t.Fatalf("NewWallet() error: %v", err)
+	}
+	wallet.SetVerifyAfterSign(true)
+
+	message := []uint8("verify after sign")
+	sig, err := wallet.Sign(message)
+	if err != nil {
+		t.Fatalf("Sign() error: %v", err)
+	}
+	pk := wallet.GetPK()
+	if !Verify(message, sig[:], &pk, wallet.GetDescriptor().ToDescriptor()) {
+		t.Error("signature verification failed")
+	}
+}